@@ -2,7 +2,9 @@ package bun
 
 import (
 	"context"
+	"strings"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
 )
@@ -13,19 +15,54 @@ type (
 	Name  = schema.Name
 
 	NullTime  = schema.NullTime
+	Decimal   = schema.Decimal
 	BaseModel = schema.BaseModel
 	Query     = schema.Query
 
 	BeforeAppendModelHook = schema.BeforeAppendModelHook
+	ValidateHook          = schema.ValidateHook
 
 	BeforeScanRowHook = schema.BeforeScanRowHook
 	AfterScanRowHook  = schema.AfterScanRowHook
 )
 
+// SafeQuery returns a query fragment with its own args. The fragment can be used
+// as an argument in another query, where it is formatted in place, e.g.
+//
+//	q.Where("created_at > ?", bun.SafeQuery("now() - ?::interval", "1 day"))
+//
+// Fragments can be nested.
 func SafeQuery(query string, args ...interface{}) schema.QueryWithArgs {
 	return schema.SafeQuery(query, args)
 }
 
+// DialectExpr returns a query argument that appends the expression picked from exprs
+// by the current dialect, so it can be used anywhere in a query, e.g.
+//
+//	q.Where("? = ?", bun.DialectExpr(map[dialect.Name]string{
+//		dialect.PG:    "data->>'name'",
+//		dialect.MySQL: "JSON_UNQUOTE(JSON_EXTRACT(data, '$.name'))",
+//	}), "alice")
+//
+// Like other arguments that fail to format, it appends the error instead of the expression
+// if exprs does not contain the current dialect, so the database rejects the query.
+func DialectExpr(exprs map[dialect.Name]string, args ...interface{}) schema.QueryAppender {
+	return dialectExprArg{exprs: exprs, args: args}
+}
+
+type dialectExprArg struct {
+	exprs map[dialect.Name]string
+	args  []interface{}
+}
+
+func (e dialectExprArg) AppendQuery(fmter schema.Formatter, b []byte) ([]byte, error) {
+	query, err := dialectQuery(e.exprs, fmter.Dialect().Name())
+	if err != nil {
+		return nil, err
+	}
+	return schema.SafeQuery(query, e.args).AppendQuery(fmter, b)
+}
+
 type BeforeSelectHook interface {
 	BeforeSelect(ctx context.Context, query *SelectQuery) error
 }
@@ -83,6 +120,19 @@ func In(slice interface{}) schema.QueryAppender {
 	return schema.In(slice)
 }
 
+// EscapeLike escapes the LIKE wildcards % and _, as well as the backslash escape character,
+// so the string is matched literally, for example:
+//
+//	q.WhereILike("name", "%"+bun.EscapeLike(search)+"%")
+//
+// It also escapes [, which starts a character class in MSSQL patterns.
+// Other databases match the escaped [ literally as well.
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`)
+
 func NullZero(value interface{}) schema.QueryAppender {
 	return schema.NullZero(value)
 }
@@ -5,12 +5,16 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
@@ -18,15 +22,83 @@ import (
 
 const (
 	discardUnknownColumns internal.Flag = 1 << iota
+	withoutServerPrepare
+	strictNullScan
 )
 
+var errServerPrepareDisabled = errors.New("bun: Prepare is not allowed with WithoutServerPrepare")
+
 type DBStats struct {
 	Queries uint32
 	Errors  uint32
+	// Timeouts is the number of queries that failed because the context deadline was exceeded.
+	// The failed queries are also counted in Errors.
+	Timeouts uint32
+	// Cancellations is the number of queries that failed because the context was canceled.
+	// The failed queries are also counted in Errors.
+	Cancellations uint32
+	// RelationLoads is the number of queries that loaded has-many and m2m relations.
+	RelationLoads uint32
+
+	// Selects, Inserts, Updates, and Deletes are the numbers of queries executed
+	// by the corresponding query builders. Raw queries are only counted in Queries.
+	Selects uint32
+	Inserts uint32
+	Updates uint32
+	Deletes uint32
+	// Transactions is the number of started transactions.
+	Transactions uint32
+}
+
+// TimeoutError is returned when a query is interrupted because the context deadline
+// is exceeded. errors.Is(err, context.DeadlineExceeded) reports true for it.
+type TimeoutError struct {
+	Err error
+}
+
+func (err *TimeoutError) Error() string {
+	return "bun: query timeout: " + err.Err.Error()
+}
+
+func (err *TimeoutError) Unwrap() error {
+	return err.Err
+}
+
+func (err *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// CanceledError is returned when a query is interrupted because the context is canceled.
+// errors.Is(err, context.Canceled) reports true for it.
+type CanceledError struct {
+	Err error
+}
+
+func (err *CanceledError) Error() string {
+	return "bun: query canceled: " + err.Err.Error()
+}
+
+func (err *CanceledError) Unwrap() error {
+	return err.Err
+}
+
+func (err *CanceledError) Is(target error) bool {
+	return target == context.Canceled
 }
 
 type DBOption func(db *DB)
 
+var defaultDBOptions atomic.Pointer[[]DBOption]
+
+// SetDefaultOptions sets the options that NewDB applies to every new DB before the options
+// passed to NewDB, so explicit options take precedence. It replaces the previously set
+// default options; call it without arguments to remove them. It is safe for concurrent use,
+// but DBs created before the call are not affected.
+func SetDefaultOptions(opts ...DBOption) {
+	opts = append([]DBOption(nil), opts...)
+	defaultDBOptions.Store(&opts)
+}
+
 func WithOptions(opts ...DBOption) DBOption {
 	return func(db *DB) {
 		for _, opt := range opts {
@@ -41,6 +113,36 @@ func WithDiscardUnknownColumns() DBOption {
 	}
 }
 
+// WithStrictNullScan makes bun return an error when NULL is scanned into a Go value
+// that can't hold it, e.g. a string or an int, instead of setting the zero value.
+// It helps to catch mismatches between the models and the database schema.
+//
+// Pointers, slices, maps, interfaces, sql.Scanner implementations,
+// and fields with the `nullzero` tag option still accept NULL.
+// Columns of joined relations are not checked, because LEFT JOIN returns NULLs
+// for the missing rows.
+func WithStrictNullScan() DBOption {
+	return func(db *DB) {
+		db.flags = db.flags.Set(strictNullScan)
+	}
+}
+
+// WithoutServerPrepare guarantees that bun does not create server-side prepared statements,
+// which conflict with connection poolers such as PgBouncer in transaction pooling mode.
+//
+// Bun formats queries itself and always sends them to the driver without arguments,
+// so queries are only prepared if the driver does so on its own. With this option,
+// the Prepare methods of DB, Conn, and Tx return an error instead of preparing the statement.
+//
+// pgdriver uses the simple query protocol for such queries. pgx prepares and caches
+// every query by default, so configure it to use the simple protocol too,
+// for example, with default_query_exec_mode=simple_protocol in the connection string.
+func WithoutServerPrepare() DBOption {
+	return func(db *DB) {
+		db.flags = db.flags.Set(withoutServerPrepare)
+	}
+}
+
 func WithConnResolver(resolver ConnResolver) DBOption {
 	return func(db *DB) {
 		db.resolver = resolver
@@ -55,6 +157,8 @@ type DB struct {
 
 	fmter schema.Formatter
 	stats DBStats
+
+	relationLoads *relationLoadCounter
 }
 
 // noCopyState contains DB fields that must not be copied on clone(),
@@ -66,6 +170,8 @@ type noCopyState struct {
 
 	flags  internal.Flag
 	closed atomic.Bool
+
+	polymorphicTypes sync.Map // discriminator column -> map[string]reflect.Type
 }
 
 func NewDB(sqldb *sql.DB, dialect schema.Dialect, opts ...DBOption) *DB {
@@ -79,6 +185,11 @@ func NewDB(sqldb *sql.DB, dialect schema.Dialect, opts ...DBOption) *DB {
 		fmter: schema.NewFormatter(dialect),
 	}
 
+	if defaults := defaultDBOptions.Load(); defaults != nil {
+		for _, opt := range *defaults {
+			opt(db)
+		}
+	}
 	for _, opt := range opts {
 		opt(db)
 	}
@@ -110,10 +221,72 @@ func (db *DB) Close() error {
 	return firstErr
 }
 
+// Warmup opens and pings n connections to prime the pool, so the first queries
+// don't pay the connect latency. It also warms up the replicas of ReadWriteConnResolver.
+// n is limited by SetMaxOpenConns. The connections are returned to the pool afterwards,
+// so the pool keeps at most SetMaxIdleConns of them.
+func (db *DB) Warmup(ctx context.Context, n int) error {
+	dbs := []*sql.DB{db.DB}
+	if r, ok := db.resolver.(*ReadWriteConnResolver); ok {
+		dbs = append(dbs, r.replicas...)
+	}
+
+	for _, sqldb := range dbs {
+		if err := warmup(ctx, sqldb, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func warmup(ctx context.Context, sqldb *sql.DB, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	if maxOpen := sqldb.Stats().MaxOpenConnections; maxOpen > 0 && n > maxOpen {
+		n = maxOpen
+	}
+
+	conns := make([]*sql.Conn, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			conn, err := sqldb.Conn(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			conns[i] = conn
+			errs[i] = conn.PingContext(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, conn := range conns {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (db *DB) DBStats() DBStats {
 	return DBStats{
-		Queries: atomic.LoadUint32(&db.stats.Queries),
-		Errors:  atomic.LoadUint32(&db.stats.Errors),
+		Queries:       atomic.LoadUint32(&db.stats.Queries),
+		Errors:        atomic.LoadUint32(&db.stats.Errors),
+		Timeouts:      atomic.LoadUint32(&db.stats.Timeouts),
+		Cancellations: atomic.LoadUint32(&db.stats.Cancellations),
+		RelationLoads: atomic.LoadUint32(&db.stats.RelationLoads),
+		Selects:       atomic.LoadUint32(&db.stats.Selects),
+		Inserts:       atomic.LoadUint32(&db.stats.Inserts),
+		Updates:       atomic.LoadUint32(&db.stats.Updates),
+		Deletes:       atomic.LoadUint32(&db.stats.Deletes),
+		Transactions:  atomic.LoadUint32(&db.stats.Transactions),
 	}
 }
 
@@ -173,6 +346,22 @@ func (db *DB) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(db)
 }
 
+func (db *DB) NewAlterColumn() *AlterColumnQuery {
+	return NewAlterColumnQuery(db)
+}
+
+func (db *DB) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(db)
+}
+
+func (db *DB) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(db)
+}
+
+func (db *DB) NewCreateMaterializedView() *CreateMaterializedViewQuery {
+	return NewCreateMaterializedViewQuery(db)
+}
+
 func (db *DB) ResetModel(ctx context.Context, models ...interface{}) error {
 	for _, model := range models {
 		if _, err := db.NewDropTable().Model(model).IfExists().Cascade().Exec(ctx); err != nil {
@@ -240,6 +429,32 @@ func (db *DB) RegisterModel(models ...interface{}) {
 	db.dialect.Tables().Register(models...)
 }
 
+// RegisterPolymorphic registers the concrete types that are used to scan interface fields
+// with the `discriminator:column` tag option. The value of the discriminator column
+// selects the type, which is then instantiated and populated from the JSON payload:
+//
+//	type Event struct {
+//		Kind    string
+//		Payload Payload `bun:"type:jsonb,discriminator:kind"`
+//	}
+//
+//	db.RegisterPolymorphic("kind", map[string]reflect.Type{
+//		"click": reflect.TypeFor[*ClickPayload](),
+//		"view":  reflect.TypeFor[*ViewPayload](),
+//	})
+func (db *DB) RegisterPolymorphic(column string, types map[string]reflect.Type) {
+	db.polymorphicTypes.Store(column, maps.Clone(types))
+}
+
+func (db *DB) polymorphicType(column, value string) (reflect.Type, bool) {
+	v, ok := db.polymorphicTypes.Load(column)
+	if !ok {
+		return nil, false
+	}
+	typ, ok := v.(map[string]reflect.Type)[value]
+	return typ, ok
+}
+
 func (db *DB) clone() *DB {
 	clone := *db
 
@@ -255,6 +470,53 @@ func (db *DB) WithNamedArg(name string, value interface{}) *DB {
 	return clone
 }
 
+// WithRelationLoadWarning returns a copy of the DB that logs a warning when the number of
+// queries that load has-many and m2m relations with the same context exceeds the threshold,
+// which usually means that the relations are loaded one parent at a time (N+1 queries).
+// Only contexts created with RelationLoadContext are tracked, and the contexts derived
+// from them share the count. It is meant to be used during development.
+func (db *DB) WithRelationLoadWarning(threshold int) *DB {
+	clone := db.clone()
+	clone.relationLoads = &relationLoadCounter{threshold: uint32(threshold)}
+	return clone
+}
+
+type relationLoadsKey struct{}
+
+// RelationLoadContext returns a context that counts the relation loads for
+// WithRelationLoadWarning, for example, in an HTTP middleware:
+//
+//	ctx := bun.RelationLoadContext(req.Context())
+//	next.ServeHTTP(w, req.WithContext(ctx))
+func RelationLoadContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, relationLoadsKey{}, new(atomic.Uint32))
+}
+
+func (db *DB) onRelationLoad(ctx context.Context, j *relationJoin) {
+	atomic.AddUint32(&db.stats.RelationLoads, 1)
+	if db.relationLoads != nil {
+		db.relationLoads.inc(ctx, j)
+	}
+}
+
+type relationLoadCounter struct {
+	threshold uint32
+}
+
+func (c *relationLoadCounter) inc(ctx context.Context, j *relationJoin) {
+	count, ok := ctx.Value(relationLoadsKey{}).(*atomic.Uint32)
+	if !ok {
+		return
+	}
+
+	if count.Add(1) == c.threshold+1 {
+		internal.Warn.Printf(
+			"more than %d relation queries with the same context (last model=%s relation=%q); "+
+				"possible N+1 queries, consider loading the relation for all parents at once",
+			c.threshold, j.BaseModel.Table().TypeName, j.Relation.Field.GoName)
+	}
+}
+
 func (db *DB) Formatter() schema.Formatter {
 	return db.fmter
 }
@@ -273,6 +535,11 @@ func (db *DB) HasFeature(feat feature.Feature) bool {
 	return db.dialect.Features().Has(feat)
 }
 
+// Features returns the features supported by the dialect.
+func (db *DB) Features() []feature.Feature {
+	return db.dialect.Features().Split()
+}
+
 //------------------------------------------------------------------------------
 
 func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
@@ -285,7 +552,7 @@ func (db *DB) ExecContext(
 	formattedQuery := db.format(query, args)
 	ctx, event := db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	res, err := db.DB.ExecContext(ctx, formattedQuery)
-	db.afterQuery(ctx, event, res, err)
+	err = db.afterQuery(ctx, event, res, err)
 	return res, err
 }
 
@@ -299,7 +566,7 @@ func (db *DB) QueryContext(
 	formattedQuery := db.format(query, args)
 	ctx, event := db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	rows, err := db.DB.QueryContext(ctx, formattedQuery)
-	db.afterQuery(ctx, event, nil, err)
+	err = db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
 
@@ -324,6 +591,8 @@ func (db *DB) format(query string, args []interface{}) string {
 type Conn struct {
 	db *DB
 	*sql.Conn
+
+	session *sessionVars
 }
 
 func (db *DB) Conn(ctx context.Context) (Conn, error) {
@@ -332,8 +601,9 @@ func (db *DB) Conn(ctx context.Context) (Conn, error) {
 		return Conn{}, err
 	}
 	return Conn{
-		db:   db,
-		Conn: conn,
+		db:      db,
+		Conn:    conn,
+		session: new(sessionVars),
 	}, nil
 }
 
@@ -343,7 +613,7 @@ func (c Conn) ExecContext(
 	formattedQuery := c.db.format(query, args)
 	ctx, event := c.db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	res, err := c.Conn.ExecContext(ctx, formattedQuery)
-	c.db.afterQuery(ctx, event, res, err)
+	err = c.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
 
@@ -353,7 +623,7 @@ func (c Conn) QueryContext(
 	formattedQuery := c.db.format(query, args)
 	ctx, event := c.db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	rows, err := c.Conn.QueryContext(ctx, formattedQuery)
-	c.db.afterQuery(ctx, event, nil, err)
+	err = c.db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
 
@@ -365,6 +635,13 @@ func (c Conn) QueryRowContext(ctx context.Context, query string, args ...interfa
 	return row
 }
 
+func (c Conn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if c.db.flags.Has(withoutServerPrepare) {
+		return nil, errServerPrepareDisabled
+	}
+	return c.Conn.PrepareContext(ctx, query)
+}
+
 func (c Conn) Dialect() schema.Dialect {
 	return c.db.Dialect()
 }
@@ -425,6 +702,22 @@ func (c Conn) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(c.db).Conn(c)
 }
 
+func (c Conn) NewAlterColumn() *AlterColumnQuery {
+	return NewAlterColumnQuery(c.db).Conn(c)
+}
+
+func (c Conn) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(c.db).Conn(c)
+}
+
+func (c Conn) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(c.db).Conn(c)
+}
+
+func (c Conn) NewCreateMaterializedView() *CreateMaterializedViewQuery {
+	return NewCreateMaterializedViewQuery(c.db).Conn(c)
+}
+
 // RunInTx runs the function in a transaction. If the function returns an error,
 // the transaction is rolled back. Otherwise, the transaction is committed.
 func (c Conn) RunInTx(
@@ -454,10 +747,11 @@ func (c Conn) RunInTx(
 func (c Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 	ctx, event := c.db.beforeQuery(ctx, nil, "BEGIN", nil, "BEGIN", nil)
 	tx, err := c.Conn.BeginTx(ctx, opts)
-	c.db.afterQuery(ctx, event, nil, err)
+	err = c.db.afterQuery(ctx, event, nil, err)
 	if err != nil {
 		return Tx{}, err
 	}
+	atomic.AddUint32(&c.db.stats.Transactions, 1)
 	return Tx{
 		ctx: ctx,
 		db:  c.db,
@@ -465,6 +759,106 @@ func (c Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 	}, nil
 }
 
+// SetSessionVar sets the session variable for the lifetime of the connection, for example,
+// statement_timeout or a custom setting like app.current_user used by row-level security policies.
+// It uses set_config on PostgreSQL and SET SESSION on MySQL; MySQL user variables
+// like @name are set with SET. Other dialects return an error.
+func (c Conn) SetSessionVar(ctx context.Context, name string, value interface{}) error {
+	if !isSessionVarName(name) {
+		return fmt.Errorf("bun: invalid session variable name: %q", name)
+	}
+
+	switch c.db.Dialect().Name() {
+	case dialect.PG:
+		if _, ok := value.(string); !ok {
+			value = fmt.Sprint(value)
+		}
+		_, err := c.ExecContext(ctx, "SELECT set_config(?, ?, false)", name, value)
+		return err
+	case dialect.MySQL:
+		query := "SET SESSION " + name + " = ?"
+		if strings.HasPrefix(name, "@") {
+			query = "SET " + name + " = ?"
+		}
+		if _, err := c.ExecContext(ctx, query, value); err != nil {
+			return err
+		}
+		c.session.add(name)
+		return nil
+	default:
+		return fmt.Errorf("bun: %s does not support session variables", c.db.Dialect().Name())
+	}
+}
+
+// ResetSession restores the default values of the session variables.
+// On PostgreSQL, it resets all session variables with RESET ALL.
+// On MySQL, it resets the variables set with SetSessionVar.
+func (c Conn) ResetSession(ctx context.Context) error {
+	switch c.db.Dialect().Name() {
+	case dialect.PG:
+		_, err := c.ExecContext(ctx, "RESET ALL")
+		return err
+	case dialect.MySQL:
+		for _, name := range c.session.reset() {
+			query := "SET SESSION " + name + " = DEFAULT"
+			if strings.HasPrefix(name, "@") {
+				query = "SET " + name + " = NULL"
+			}
+			if _, err := c.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("bun: %s does not support session variables", c.db.Dialect().Name())
+	}
+}
+
+// sessionVars keeps track of the session variables set on a Conn,
+// because MySQL can't reset all of them with a single statement.
+type sessionVars struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (s *sessionVars) add(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.names {
+		if n == name {
+			return
+		}
+	}
+	s.names = append(s.names, name)
+}
+
+func (s *sessionVars) reset() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := s.names
+	s.names = nil
+	return names
+}
+
+func isSessionVarName(name string) bool {
+	name = strings.TrimPrefix(name, "@")
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
 //------------------------------------------------------------------------------
 
 type Stmt struct {
@@ -476,6 +870,9 @@ func (db *DB) Prepare(query string) (Stmt, error) {
 }
 
 func (db *DB) PrepareContext(ctx context.Context, query string) (Stmt, error) {
+	if db.flags.Has(withoutServerPrepare) {
+		return Stmt{}, errServerPrepareDisabled
+	}
 	stmt, err := db.DB.PrepareContext(ctx, query)
 	if err != nil {
 		return Stmt{}, err
@@ -519,6 +916,61 @@ func (db *DB) RunInTx(
 	return tx.Commit()
 }
 
+// RunInTxRetry is like RunInTx, but it re-runs the function in a new transaction
+// up to maxRetries times when the transaction fails because of a serialization failure
+// or a deadlock, which is expected when using the SERIALIZABLE isolation level.
+// Other errors are returned immediately. The function must be idempotent
+// because it may be executed several times.
+func (db *DB) RunInTxRetry(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	maxRetries int,
+	fn func(ctx context.Context, tx Tx) error,
+) error {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := internal.RetryBackoff(attempt-1, 10*time.Millisecond, time.Second)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		lastErr = db.RunInTx(ctx, opts, fn)
+		if lastErr == nil || !isRetryableTxError(lastErr) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// isRetryableTxError reports whether the transaction was aborted
+// because of a serialization failure or a deadlock.
+func isRetryableTxError(err error) bool {
+	// pgdriver and pgx errors.
+	var sqlStateErr interface{ SQLState() string }
+	if errors.As(err, &sqlStateErr) {
+		switch sqlStateErr.SQLState() {
+		case "40001", // serialization_failure
+			"40P01": // deadlock_detected
+			return true
+		}
+		return false
+	}
+
+	// go-sql-driver/mysql formats errors as "Error 1213 (40001): Deadlock found ...".
+	for ; err != nil; err = errors.Unwrap(err) {
+		if strings.HasPrefix(err.Error(), "Error 1213") {
+			return true
+		}
+	}
+	return false
+}
+
 func (db *DB) Begin() (Tx, error) {
 	return db.BeginTx(context.Background(), nil)
 }
@@ -526,10 +978,11 @@ func (db *DB) Begin() (Tx, error) {
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 	ctx, event := db.beforeQuery(ctx, nil, "BEGIN", nil, "BEGIN", nil)
 	tx, err := db.DB.BeginTx(ctx, opts)
-	db.afterQuery(ctx, event, nil, err)
+	err = db.afterQuery(ctx, event, nil, err)
 	if err != nil {
 		return Tx{}, err
 	}
+	atomic.AddUint32(&db.stats.Transactions, 1)
 	return Tx{
 		ctx: ctx,
 		db:  db,
@@ -547,7 +1000,7 @@ func (tx Tx) Commit() error {
 func (tx Tx) commitTX() error {
 	ctx, event := tx.db.beforeQuery(tx.ctx, nil, "COMMIT", nil, "COMMIT", nil)
 	err := tx.Tx.Commit()
-	tx.db.afterQuery(ctx, event, nil, err)
+	err = tx.db.afterQuery(ctx, event, nil, err)
 	return err
 }
 
@@ -570,7 +1023,7 @@ func (tx Tx) Rollback() error {
 func (tx Tx) rollbackTX() error {
 	ctx, event := tx.db.beforeQuery(tx.ctx, nil, "ROLLBACK", nil, "ROLLBACK", nil)
 	err := tx.Tx.Rollback()
-	tx.db.afterQuery(ctx, event, nil, err)
+	err = tx.db.afterQuery(ctx, event, nil, err)
 	return err
 }
 
@@ -593,7 +1046,7 @@ func (tx Tx) ExecContext(
 	formattedQuery := tx.db.format(query, args)
 	ctx, event := tx.db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	res, err := tx.Tx.ExecContext(ctx, formattedQuery)
-	tx.db.afterQuery(ctx, event, res, err)
+	err = tx.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
 
@@ -607,7 +1060,7 @@ func (tx Tx) QueryContext(
 	formattedQuery := tx.db.format(query, args)
 	ctx, event := tx.db.beforeQuery(ctx, nil, query, args, formattedQuery, nil)
 	rows, err := tx.Tx.QueryContext(ctx, formattedQuery)
-	tx.db.afterQuery(ctx, event, nil, err)
+	err = tx.db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
 
@@ -623,6 +1076,17 @@ func (tx Tx) QueryRowContext(ctx context.Context, query string, args ...interfac
 	return row
 }
 
+func (tx Tx) Prepare(query string) (*sql.Stmt, error) {
+	return tx.PrepareContext(context.TODO(), query)
+}
+
+func (tx Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if tx.db.flags.Has(withoutServerPrepare) {
+		return nil, errServerPrepareDisabled
+	}
+	return tx.Tx.PrepareContext(ctx, query)
+}
+
 //------------------------------------------------------------------------------
 
 func (tx Tx) Begin() (Tx, error) {
@@ -679,6 +1143,23 @@ func (tx Tx) RunInTx(
 	return sp.Commit()
 }
 
+// SetConstraintsDeferred defers constraint checks until the transaction is committed,
+// so rows that reference each other can be inserted in any order.
+// PostgreSQL only defers constraints declared as DEFERRABLE, while SQLite defers all foreign keys.
+func (tx Tx) SetConstraintsDeferred(ctx context.Context) error {
+	var query string
+	switch {
+	case tx.db.dialect.Name() == dialect.SQLite:
+		query = "PRAGMA defer_foreign_keys = ON"
+	case tx.db.HasFeature(feature.DeferrableFK):
+		query = "SET CONSTRAINTS ALL DEFERRED"
+	default:
+		return feature.NewNotSupportError(feature.DeferrableFK)
+	}
+	_, err := tx.ExecContext(ctx, query)
+	return err
+}
+
 func (tx Tx) Dialect() schema.Dialect {
 	return tx.db.Dialect()
 }
@@ -739,6 +1220,22 @@ func (tx Tx) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(tx.db).Conn(tx)
 }
 
+func (tx Tx) NewAlterColumn() *AlterColumnQuery {
+	return NewAlterColumnQuery(tx.db).Conn(tx)
+}
+
+func (tx Tx) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(tx.db).Conn(tx)
+}
+
+func (tx Tx) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(tx.db).Conn(tx)
+}
+
+func (tx Tx) NewCreateMaterializedView() *CreateMaterializedViewQuery {
+	return NewCreateMaterializedViewQuery(tx.db).Conn(tx)
+}
+
 //------------------------------------------------------------------------------
 
 func (db *DB) makeQueryBytes() []byte {
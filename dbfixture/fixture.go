@@ -17,6 +17,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/schema"
 )
 
@@ -37,6 +38,9 @@ func WithRecreateTables() FixtureOption {
 	}
 }
 
+// WithTruncateTables truncates the tables before loading fixtures, preserving the schema.
+// Tables are truncated before any rows are inserted with dependent tables going first.
+// On MySQL, foreign key checks are disabled while truncating.
 func WithTruncateTables() FixtureOption {
 	return func(l *Fixture) {
 		if l.recreateTables {
@@ -55,6 +59,21 @@ func WithTemplateFuncs(funcMap template.FuncMap) FixtureOption {
 	}
 }
 
+// WithTimeout limits the time Load may take, so a large or slow fixture does not hang the test.
+func WithTimeout(timeout time.Duration) FixtureOption {
+	return func(f *Fixture) {
+		f.timeout = timeout
+	}
+}
+
+// WithMaxRows limits the number of rows Load may insert. Load fails before inserting
+// any row if the fixtures contain more rows, so an oversized fixture is caught early.
+func WithMaxRows(n int) FixtureOption {
+	return func(f *Fixture) {
+		f.maxRows = n
+	}
+}
+
 type BeforeInsertData struct {
 	Query *bun.InsertQuery
 	Model interface{}
@@ -74,6 +93,8 @@ type Fixture struct {
 	recreateTables bool
 	truncateTables bool
 	beforeInsert   []BeforeInsertFunc
+	timeout        time.Duration
+	maxRows        int
 
 	seenTables map[string]struct{}
 
@@ -123,55 +144,88 @@ func (f *Fixture) MustRow(id string) interface{} {
 }
 
 func (f *Fixture) Load(ctx context.Context, fsys fs.FS, names ...string) error {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	var fixtures []fixtureData
+
 	for _, name := range names {
-		if err := f.load(ctx, fsys, name); err != nil {
+		data, err := f.load(fsys, name)
+		if err != nil {
+			return err
+		}
+		fixtures = append(fixtures, data...)
+	}
+
+	if f.maxRows > 0 {
+		var numRow int
+		for i := range fixtures {
+			numRow += len(fixtures[i].Rows)
+		}
+		if numRow > f.maxRows {
+			return fmt.Errorf("dbfixture: fixtures have %d rows, which exceeds the limit of %d",
+				numRow, f.maxRows)
+		}
+	}
+
+	if f.truncateTables {
+		if err := f.truncate(ctx, fixtures); err != nil {
+			return err
+		}
+	}
+
+	for i := range fixtures {
+		if err := f.addFixture(ctx, &fixtures[i]); err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
 
-func (f *Fixture) load(ctx context.Context, fsys fs.FS, name string) error {
+func (f *Fixture) load(fsys fs.FS, name string) ([]fixtureData, error) {
 	fh, err := fsys.Open(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer fh.Close()
 
 	var fixtures []fixtureData
 
 	dec := yaml.NewDecoder(fh)
 	if err := dec.Decode(&fixtures); err != nil {
-		return err
+		return nil, err
 	}
 
-	for i := range fixtures {
-		if err := f.addFixture(ctx, &fixtures[i]); err != nil {
-			return err
-		}
-	}
+	return fixtures, nil
+}
 
-	return nil
+func (f *Fixture) modelTable(model string) (*schema.Table, error) {
+	table := f.db.Dialect().Tables().ByModel(model)
+	if table == nil {
+		return nil, fmt.Errorf("fixture: can't find model=%q (use db.RegisterModel)", model)
+	}
+	return table, nil
 }
 
 func (f *Fixture) addFixture(ctx context.Context, data *fixtureData) error {
-	table := f.db.Dialect().Tables().ByModel(data.Model)
-	if table == nil {
-		return fmt.Errorf("fixture: can't find model=%q (use db.RegisterModel)", data.Model)
+	table, err := f.modelTable(data.Model)
+	if err != nil {
+		return err
 	}
 
 	if f.recreateTables {
 		if err := f.dropTable(ctx, table); err != nil {
 			return err
 		}
-	} else if f.truncateTables {
-		if err := f.truncateTable(ctx, table); err != nil {
-			return err
-		}
 	}
 
-	for _, row := range data.Rows {
+	for i, row := range data.Rows {
 		if err := f.addRow(ctx, table, row); err != nil {
-			return err
+			return fmt.Errorf("dbfixture: model=%s row=%s: %w", data.Model, rowName(i, row), err)
 		}
 	}
 
@@ -196,7 +250,7 @@ func (f *Fixture) addRow(ctx context.Context, table *schema.Table, row row) erro
 		}
 
 		if err := f.decodeField(strct, field, &value); err != nil {
-			return fmt.Errorf("dbfixture: decoding %s failed: %w", key, err)
+			return fmt.Errorf("decoding %s failed: %w", key, err)
 		}
 	}
 
@@ -295,22 +349,125 @@ func (f *Fixture) dropTable(ctx context.Context, table *schema.Table) error {
 	return nil
 }
 
-func (f *Fixture) truncateTable(ctx context.Context, table *schema.Table) error {
-	if _, ok := f.seenTables[table.Name]; ok {
+// truncate truncates the tables used by the fixtures that were not truncated yet.
+func (f *Fixture) truncate(ctx context.Context, fixtures []fixtureData) error {
+	var tables []*schema.Table
+	for i := range fixtures {
+		table, err := f.modelTable(fixtures[i].Model)
+		if err != nil {
+			return err
+		}
+		if _, ok := f.seenTables[table.Name]; ok {
+			continue
+		}
+		f.seenTables[table.Name] = struct{}{}
+		tables = append(tables, table)
+	}
+	if len(tables) == 0 {
 		return nil
 	}
-	f.seenTables[table.Name] = struct{}{}
 
-	if _, err := f.db.NewTruncateTable().
-		Model(table.ZeroIface).
-		Cascade().
-		Exec(ctx); err != nil {
+	tables = sortTablesForTruncate(tables)
+
+	if f.db.Dialect().Name() != dialect.MySQL {
+		return f.execTruncate(ctx, f.db, tables)
+	}
+
+	// MySQL refuses to truncate tables referenced by foreign keys, so the checks
+	// are disabled for the session. That requires a dedicated connection.
+	db := f.db
+	if bunDB, ok := db.(*bun.DB); ok {
+		conn, err := bunDB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		db = conn
+	}
+
+	if _, err := db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		return err
+	}
+	truncateErr := f.execTruncate(ctx, db, tables)
+	if _, err := db.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1"); err != nil && truncateErr == nil {
 		return err
 	}
+	return truncateErr
+}
 
+func (f *Fixture) execTruncate(ctx context.Context, db bun.IDB, tables []*schema.Table) error {
+	for _, table := range tables {
+		if _, err := db.NewTruncateTable().
+			Model(table.ZeroIface).
+			Cascade().
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// sortTablesForTruncate orders the tables so that tables with foreign keys
+// come before the tables they reference.
+func sortTablesForTruncate(tables []*schema.Table) []*schema.Table {
+	// parents maps a table to the tables it references.
+	parents := make(map[*schema.Table][]*schema.Table, len(tables))
+	for _, table := range tables {
+		for _, rel := range table.Relations {
+			switch rel.Type {
+			case schema.BelongsToRelation:
+				parents[table] = append(parents[table], rel.JoinTable)
+			case schema.HasOneRelation, schema.HasManyRelation:
+				parents[rel.JoinTable] = append(parents[rel.JoinTable], table)
+			case schema.ManyToManyRelation:
+				parents[rel.M2MTable] = append(parents[rel.M2MTable], table, rel.JoinTable)
+			}
+		}
+	}
+
+	// children counts the remaining tables that reference the table.
+	children := make(map[*schema.Table]int, len(tables))
+	for _, table := range tables {
+		for _, parent := range parents[table] {
+			if parent != table {
+				children[parent]++
+			}
+		}
+	}
+
+	sorted := make([]*schema.Table, 0, len(tables))
+	done := make(map[*schema.Table]bool, len(tables))
+	for len(sorted) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if done[table] || children[table] > 0 {
+				continue
+			}
+			done[table] = true
+			sorted = append(sorted, table)
+			progress = true
+
+			for _, parent := range parents[table] {
+				if parent != table {
+					children[parent]--
+				}
+			}
+		}
+		if progress {
+			continue
+		}
+
+		// Cyclic references: keep the original order for the rest.
+		for _, table := range tables {
+			if !done[table] {
+				done[table] = true
+				sorted = append(sorted, table)
+			}
+		}
+	}
+	return sorted
+}
+
 func (f *Fixture) eval(templ string) (interface{}, error) {
 	if v, ok := f.evalFuncCall(templ); ok {
 		return v, nil
@@ -412,6 +569,17 @@ type fixtureData struct {
 
 type row map[string]yaml.Node
 
+// rowName identifies the row in errors using its _id or its position in the fixture.
+func rowName(i int, row row) string {
+	if node, ok := row["_id"]; ok {
+		var rowID string
+		if err := node.Decode(&rowID); err == nil {
+			return strconv.Quote(rowID)
+		}
+	}
+	return "#" + strconv.Itoa(i+1)
+}
+
 func asString(rv reflect.Value) string {
 	switch rv.Kind() {
 	case reflect.Bool:
@@ -3,11 +3,11 @@ package feature
 import (
 	"fmt"
 	"strconv"
-
-	"github.com/uptrace/bun/internal"
+	"strings"
 )
 
-type Feature = internal.Flag
+// Feature is a set of dialect capabilities, e.g. CTE|Returning.
+type Feature uint64
 
 const (
 	CTE Feature = 1 << iota
@@ -41,18 +41,62 @@ const (
 	DeleteOrderLimit // DELETE ... ORDER BY ... LIMIT ...
 	DeleteReturning
 	AlterColumnExists // ADD/DROP COLUMN IF NOT EXISTS/IF EXISTS
+	ForeignKeyChecks  // SET FOREIGN_KEY_CHECKS = 0
+	WindowClause      // SELECT ... WINDOW w AS (...)
+	FullTextSearch    // tsvector @@ tsquery
+	FullJoin          // SELECT ... FULL JOIN
+	DistinctFrom      // IS [NOT] DISTINCT FROM
+	RelationJSON      // relations loaded with row_to_json and json_agg subqueries
+	DeferrableFK      // FOREIGN KEY ... DEFERRABLE INITIALLY DEFERRED
+	InsertRowAlias    // INSERT ... VALUES (...) AS new ON DUPLICATE KEY UPDATE col = new.col
+	MaterializedView  // CREATE MATERIALIZED VIEW ... AS SELECT ...
+	FetchWithTies     // FETCH FIRST n ROWS WITH TIES
+	ILike             // column ILIKE pattern
 )
 
+func (f Feature) Has(other Feature) bool {
+	return f&other != 0
+}
+
+func (f Feature) Set(other Feature) Feature {
+	return f | other
+}
+
+func (f Feature) Remove(other Feature) Feature {
+	return f &^ other
+}
+
+// Split returns the individual features of the set in ascending order.
+func (f Feature) Split() []Feature {
+	var features []Feature
+	for i := 0; i < 64; i++ {
+		if flag := Feature(1) << i; f.Has(flag) {
+			features = append(features, flag)
+		}
+	}
+	return features
+}
+
+// String returns the feature name, or the names of all features in the set joined with "|".
+func (f Feature) String() string {
+	features := f.Split()
+	names := make([]string, len(features))
+	for i, flag := range features {
+		name, ok := flag2str[flag]
+		if !ok {
+			name = strconv.FormatUint(uint64(flag), 10)
+		}
+		names[i] = name
+	}
+	return strings.Join(names, "|")
+}
+
 type NotSupportError struct {
 	Flag Feature
 }
 
 func (err *NotSupportError) Error() string {
-	name, ok := flag2str[err.Flag]
-	if !ok {
-		name = strconv.FormatInt(int64(err.Flag), 10)
-	}
-	return fmt.Sprintf("bun: feature %s is not supported by current dialect", name)
+	return fmt.Sprintf("bun: feature %s is not supported by current dialect", err.Flag)
 }
 
 func NewNotSupportError(flag Feature) *NotSupportError {
@@ -91,4 +135,15 @@ var flag2str = map[Feature]string{
 	DeleteOrderLimit:     "DeleteOrderLimit",
 	DeleteReturning:      "DeleteReturning",
 	AlterColumnExists:    "AlterColumnExists",
+	ForeignKeyChecks:     "ForeignKeyChecks",
+	WindowClause:         "WindowClause",
+	FullTextSearch:       "FullTextSearch",
+	FullJoin:             "FullJoin",
+	DistinctFrom:         "DistinctFrom",
+	RelationJSON:         "RelationJSON",
+	DeferrableFK:         "DeferrableFK",
+	InsertRowAlias:       "InsertRowAlias",
+	MaterializedView:     "MaterializedView",
+	FetchWithTies:        "FetchWithTies",
+	ILike:                "ILike",
 }
@@ -0,0 +1,26 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureString(t *testing.T) {
+	for flag, name := range flag2str {
+		require.Equal(t, name, flag.String())
+	}
+
+	require.Equal(t, "", Feature(0).String())
+	require.Equal(t, "CTE|Returning", (Returning | CTE).String())
+	require.Equal(t, "bun: feature CTE is not supported by current dialect", NewNotSupportError(CTE).Error())
+}
+
+func TestFeatureSplit(t *testing.T) {
+	require.Nil(t, Feature(0).Split())
+	require.Equal(t, []Feature{CTE, Returning, CompositeIn}, (CompositeIn | Returning | CTE).Split())
+
+	f := CTE.Set(Returning)
+	require.True(t, f.Has(CTE))
+	require.False(t, f.Remove(CTE).Has(CTE))
+}
@@ -35,8 +35,9 @@ func init() {
 type Dialect struct {
 	schema.BaseDialect
 
-	tables   *schema.Tables
-	features feature.Feature
+	tables       *schema.Tables
+	features     feature.Feature
+	identQuoting dialect.IdentQuoting
 }
 
 func New(opts ...DialectOption) *Dialect {
@@ -48,7 +49,8 @@ func New(opts ...DialectOption) *Dialect {
 		feature.Output |
 		feature.OffsetFetch |
 		feature.UpdateFromTable |
-		feature.MSSavepoint
+		feature.MSSavepoint |
+		feature.FullJoin
 
 	for _, opt := range opts {
 		opt(d)
@@ -64,6 +66,14 @@ func WithoutFeature(other feature.Feature) DialectOption {
 	}
 }
 
+// WithIdentQuoting controls when identifiers are quoted, for example,
+// dialect.QuoteMinimal only quotes reserved words and names with special characters.
+func WithIdentQuoting(quoting dialect.IdentQuoting) DialectOption {
+	return func(d *Dialect) {
+		d.identQuoting = quoting
+	}
+}
+
 func (d *Dialect) Init(db *sql.DB) {
 	var version string
 	if err := db.QueryRow("SELECT @@VERSION").Scan(&version); err != nil {
@@ -72,6 +82,10 @@ func (d *Dialect) Init(db *sql.DB) {
 	}
 
 	version = semver.MajorMinor("v" + cleanupVersion(version))
+	// SQL Server 2022 (16.x) supports the WINDOW clause.
+	if semver.Compare(version, "v16.0") >= 0 {
+		d.features |= feature.WindowClause
+	}
 }
 
 func cleanupVersion(v string) string {
@@ -108,6 +122,10 @@ func (d *Dialect) IdentQuote() byte {
 	return '"'
 }
 
+func (d *Dialect) IdentQuoting() dialect.IdentQuoting {
+	return d.identQuoting
+}
+
 func (*Dialect) AppendTime(b []byte, tm time.Time) []byte {
 	b = append(b, '\'')
 	b = tm.AppendFormat(b, "2006-01-02 15:04:05.999")
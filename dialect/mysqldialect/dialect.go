@@ -30,9 +30,10 @@ func init() {
 type Dialect struct {
 	schema.BaseDialect
 
-	tables   *schema.Tables
-	features feature.Feature
-	loc      *time.Location
+	tables       *schema.Tables
+	features     feature.Feature
+	loc          *time.Location
+	identQuoting dialect.IdentQuoting
 }
 
 func New(opts ...DialectOption) *Dialect {
@@ -49,6 +50,7 @@ func New(opts ...DialectOption) *Dialect {
 		feature.SelectExists |
 		feature.CompositeIn |
 		feature.UpdateOrderLimit |
+		feature.ForeignKeyChecks |
 		feature.DeleteOrderLimit
 
 	for _, opt := range opts {
@@ -70,12 +72,28 @@ func WithTimeLocation(loc string) DialectOption {
 	}
 }
 
+// WithFeature enables the feature, for example, when the server version
+// can't be discovered or the dialect is used without a connection.
+func WithFeature(other feature.Feature) DialectOption {
+	return func(d *Dialect) {
+		d.features |= other
+	}
+}
+
 func WithoutFeature(other feature.Feature) DialectOption {
 	return func(d *Dialect) {
 		d.features = d.features.Remove(other)
 	}
 }
 
+// WithIdentQuoting controls when identifiers are quoted, for example,
+// dialect.QuoteMinimal only quotes reserved words and names with special characters.
+func WithIdentQuoting(quoting dialect.IdentQuoting) DialectOption {
+	return func(d *Dialect) {
+		d.identQuoting = quoting
+	}
+}
+
 func (d *Dialect) Init(db *sql.DB) {
 	var version string
 	if err := db.QueryRow("SELECT version()").Scan(&version); err != nil {
@@ -88,6 +106,9 @@ func (d *Dialect) Init(db *sql.DB) {
 		if semver.Compare(version, "v10.0.5") >= 0 {
 			d.features |= feature.DeleteReturning
 		}
+		if semver.Compare(version, "v10.2.0") >= 0 {
+			d.features |= feature.WindowClause
+		}
 		if semver.Compare(version, "v10.5.0") >= 0 {
 			d.features |= feature.InsertReturning
 		}
@@ -96,11 +117,15 @@ func (d *Dialect) Init(db *sql.DB) {
 
 	version = "v" + cleanupVersion(version)
 	if semver.Compare(version, "v8.0") >= 0 {
-		d.features |= feature.CTE | feature.WithValues
+		d.features |= feature.CTE | feature.WithValues | feature.WindowClause
 	}
 	if semver.Compare(version, "v8.0.16") >= 0 {
 		d.features |= feature.DeleteTableAlias
 	}
+	if semver.Compare(version, "v8.0.20") >= 0 {
+		// VALUES(col) in ON DUPLICATE KEY UPDATE is deprecated since 8.0.20.
+		d.features |= feature.InsertRowAlias
+	}
 }
 
 func cleanupVersion(s string) string {
@@ -132,6 +157,10 @@ func (d *Dialect) IdentQuote() byte {
 	return '`'
 }
 
+func (d *Dialect) IdentQuoting() dialect.IdentQuoting {
+	return d.identQuoting
+}
+
 func (d *Dialect) AppendTime(b []byte, tm time.Time) []byte {
 	b = append(b, '\'')
 	if d.loc != nil {
@@ -23,8 +23,9 @@ func init() {
 type Dialect struct {
 	schema.BaseDialect
 
-	tables   *schema.Tables
-	features feature.Feature
+	tables       *schema.Tables
+	features     feature.Feature
+	identQuoting dialect.IdentQuoting
 }
 
 func New(opts ...DialectOption) *Dialect {
@@ -41,7 +42,9 @@ func New(opts ...DialectOption) *Dialect {
 		feature.SelectExists |
 		feature.AutoIncrement |
 		feature.CompositeIn |
-		feature.DeleteReturning
+		feature.DeleteReturning |
+		feature.FullJoin |
+		feature.FetchWithTies
 
 	for _, opt := range opts {
 		opt(d)
@@ -58,6 +61,14 @@ func WithoutFeature(other feature.Feature) DialectOption {
 	}
 }
 
+// WithIdentQuoting controls when identifiers are quoted, for example,
+// dialect.QuoteMinimal only quotes reserved words and names with special characters.
+func WithIdentQuoting(quoting dialect.IdentQuoting) DialectOption {
+	return func(d *Dialect) {
+		d.identQuoting = quoting
+	}
+}
+
 func (d *Dialect) Init(*sql.DB) {}
 
 func (d *Dialect) Name() dialect.Name {
@@ -86,6 +97,10 @@ func (d *Dialect) IdentQuote() byte {
 	return '"'
 }
 
+func (d *Dialect) IdentQuoting() dialect.IdentQuoting {
+	return d.identQuoting
+}
+
 func (*Dialect) AppendBytes(b, bs []byte) []byte {
 	if bs == nil {
 		return dialect.AppendNull(b)
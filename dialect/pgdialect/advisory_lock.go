@@ -0,0 +1,89 @@
+package pgdialect
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/uptrace/bun"
+)
+
+// AcquireAdvisoryLock obtains a session-level advisory lock using pg_advisory_lock,
+// waiting until the lock becomes available, for example:
+//
+//	release, err := pgdialect.AcquireAdvisoryLock(ctx, db, jobID)
+//	if err != nil {
+//		return err
+//	}
+//	defer release(ctx)
+//
+// Advisory locks belong to the database session, so the lock is held on a dedicated
+// connection until release is called. release unlocks the lock with pg_advisory_unlock
+// and returns the connection to the pool. It is safe to call release more than once.
+func AcquireAdvisoryLock(
+	ctx context.Context, db *bun.DB, key int64,
+) (release func(context.Context) error, err error) {
+	release, _, err = advisoryLock(ctx, db, key, func(conn bun.Conn) (bool, error) {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(?)", key)
+		return err == nil, err
+	})
+	return release, err
+}
+
+// TryAcquireAdvisoryLock is like AcquireAdvisoryLock, but uses pg_try_advisory_lock
+// and returns immediately with ok set to false if the lock is held by another session.
+func TryAcquireAdvisoryLock(
+	ctx context.Context, db *bun.DB, key int64,
+) (release func(context.Context) error, ok bool, err error) {
+	return advisoryLock(ctx, db, key, func(conn bun.Conn) (ok bool, err error) {
+		err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(?)", key).Scan(&ok)
+		return ok, err
+	})
+}
+
+func advisoryLock(
+	ctx context.Context, db *bun.DB, key int64, lock func(bun.Conn) (bool, error),
+) (release func(context.Context) error, ok bool, err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ok, err = lock(conn)
+	if err != nil {
+		// The lock may have been acquired before the error, e.g. when the context
+		// is canceled, so the connection is discarded instead of being reused.
+		discardConn(conn)
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, conn.Close()
+	}
+
+	var once sync.Once
+	release = func(ctx context.Context) error {
+		var err error
+		once.Do(func() {
+			err = unlockAdvisoryLock(ctx, conn, key)
+		})
+		return err
+	}
+	return release, true, nil
+}
+
+func unlockAdvisoryLock(ctx context.Context, conn bun.Conn, key int64) error {
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(?)", key); err != nil {
+		discardConn(conn)
+		return err
+	}
+	return conn.Close()
+}
+
+// discardConn closes the underlying connection, so the session and the locks
+// it holds are not reused by other queries.
+func discardConn(conn bun.Conn) {
+	_ = conn.Raw(func(interface{}) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
@@ -62,6 +62,10 @@ func (m *migrator) AppendSQL(b []byte, operation interface{}) (_ []byte, err err
 		b, err = m.addForeignKey(fmter, appendAlterTable(b, change.TableName()), change)
 	case *migrate.DropForeignKeyOp:
 		b, err = m.dropConstraint(fmter, appendAlterTable(b, change.TableName()), change.ConstraintName)
+	case *migrate.CreateIndexOp:
+		b, err = m.createIndex(fmter, b, change)
+	case *migrate.DropIndexOp:
+		b, err = m.dropIndex(fmter, b, change)
 	default:
 		return nil, fmt.Errorf("append sql: unknown operation %T", change)
 	}
@@ -71,8 +75,14 @@ func (m *migrator) AppendSQL(b []byte, operation interface{}) (_ []byte, err err
 	return b, nil
 }
 
+// appendFQN qualifies the table name with the migrated schema,
+// unless it is already qualified, like a foreign key reference to a table in another schema.
 func (m *migrator) appendFQN(fmter schema.Formatter, b []byte, tableName string) []byte {
-	return fmter.AppendQuery(b, "?.?", bun.Ident(m.schemaName), bun.Ident(tableName))
+	schemaName := m.schemaName
+	if s, t, ok := strings.Cut(tableName, "."); ok {
+		schemaName, tableName = s, t
+	}
+	return fmter.AppendQuery(b, "?.?", bun.Ident(schemaName), bun.Ident(tableName))
 }
 
 func (m *migrator) renameTable(fmter schema.Formatter, b []byte, rename *migrate.RenameTableOp) (_ []byte, err error) {
@@ -158,6 +168,30 @@ func (m *migrator) dropConstraint(fmter schema.Formatter, b []byte, name string)
 	return b, nil
 }
 
+func (m *migrator) createIndex(fmter schema.Formatter, b []byte, change *migrate.CreateIndexOp) (_ []byte, err error) {
+	b = append(b, "CREATE INDEX "...)
+	b = fmter.AppendName(b, change.Index.GetName(change.TableName))
+	b = append(b, " ON "...)
+	b = m.appendFQN(fmter, b, change.TableName)
+	b = append(b, " ("...)
+	b, _ = change.Index.Columns.AppendQuery(fmter, b)
+	b = append(b, ")"...)
+
+	if change.Index.Where != "" {
+		b = append(b, " WHERE "...)
+		b = append(b, change.Index.Where...)
+	}
+
+	return b, nil
+}
+
+func (m *migrator) dropIndex(fmter schema.Formatter, b []byte, change *migrate.DropIndexOp) (_ []byte, err error) {
+	b = append(b, "DROP INDEX "...)
+	b = m.appendFQN(fmter, b, change.Index.GetName(change.TableName))
+
+	return b, nil
+}
+
 func (m *migrator) addForeignKey(fmter schema.Formatter, b []byte, add *migrate.AddForeignKeyOp) (_ []byte, err error) {
 	b = append(b, "ADD CONSTRAINT "...)
 
@@ -37,3 +37,64 @@ func TestHStoreAppender(t *testing.T) {
 		})
 	}
 }
+
+func TestCompositeAppender(t *testing.T) {
+	type Inner struct {
+		N int
+	}
+	type Item struct {
+		ID    int64
+		Name  string
+		Note  *string
+		Bytes []byte
+		Inner Inner `bun:",composite"`
+	}
+
+	typ := reflect.TypeFor[Item]()
+	appendFunc := pgDialect.compositeAppender(typ)
+	scanFunc := pgDialect.compositeScanner(typ)
+
+	item := Item{ID: 1, Name: `it's "quoted"`, Bytes: []byte{1, 2}, Inner: Inner{N: 3}}
+
+	got := appendFunc(schema.NewFormatter(pgDialect), nil, reflect.ValueOf(item))
+	require.Equal(t, `'(1,"it''s \"quoted\"",,"\\x0102","(3)")'`, string(got))
+
+	var scanned Item
+	err := scanFunc(reflect.ValueOf(&scanned).Elem(), `(1,"it's ""quoted""",,"\\x0102","(3)")`)
+	require.NoError(t, err)
+	require.Equal(t, item, scanned)
+}
+
+func TestMultiDimArray(t *testing.T) {
+	fmter := schema.NewFormatter(pgDialect)
+
+	floats := [][]float64{{1.5, -2}, {3, 4.25}}
+	typ := reflect.TypeOf(floats)
+	got := pgDialect.arrayAppender(typ)(fmter, nil, reflect.ValueOf(floats))
+	require.Equal(t, `'{{1.5,-2},{3,4.25}}'`, string(got))
+
+	var scanned [][]float64
+	err := arrayScanner(typ)(reflect.ValueOf(&scanned).Elem(), []byte(`{{1.5,-2},{3,4.25}}`))
+	require.NoError(t, err)
+	require.Equal(t, floats, scanned)
+
+	strs := [][]string{{"a,b", `"}`}, {"c", "d"}}
+	typ = reflect.TypeOf(strs)
+	got = pgDialect.arrayAppender(typ)(fmter, nil, reflect.ValueOf(strs))
+	require.Equal(t, `'{{"a,b","\"}"},{"c","d"}}'`, string(got))
+
+	var scannedStrs [][]string
+	err = arrayScanner(typ)(reflect.ValueOf(&scannedStrs).Elem(), []byte(`{{"a,b","\"}"},{c,d}}`))
+	require.NoError(t, err)
+	require.Equal(t, strs, scannedStrs)
+
+	decimals := [][]schema.Decimal{{"12345678901234567890.12", "NaN"}}
+	typ = reflect.TypeOf(decimals)
+	got = pgDialect.arrayAppender(typ)(fmter, nil, reflect.ValueOf(decimals))
+	require.Equal(t, `'{{"12345678901234567890.12","NaN"}}'`, string(got))
+
+	var scannedDecimals [][]schema.Decimal
+	err = arrayScanner(typ)(reflect.ValueOf(&scannedDecimals).Elem(), []byte(`{{12345678901234567890.12,NaN}}`))
+	require.NoError(t, err)
+	require.Equal(t, decimals, scannedDecimals)
+}
@@ -142,6 +142,9 @@ func (d *Dialect) arrayElemAppender(typ reflect.Type) schema.AppenderFunc {
 	if typ.Implements(driverValuerType) {
 		return arrayAppendDriverValue
 	}
+	if typ == float64Type {
+		return appendFloat64ElemValue
+	}
 	switch typ.Kind() {
 	case reflect.String:
 		return appendStringElemValue
@@ -149,10 +152,42 @@ func (d *Dialect) arrayElemAppender(typ reflect.Type) schema.AppenderFunc {
 		if typ.Elem().Kind() == reflect.Uint8 {
 			return appendBytesElemValue
 		}
+		return d.subArrayAppender(typ)
+	case reflect.Array:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return d.subArrayAppender(typ)
+		}
 	}
 	return schema.Appender(d, typ)
 }
 
+// subArrayAppender appends an element of a multi-dimensional array, e.g. {1,2} in {{1,2},{3,4}}.
+func (d *Dialect) subArrayAppender(typ reflect.Type) schema.AppenderFunc {
+	appendElem := d.arrayElemAppender(typ.Elem())
+	if appendElem == nil {
+		panic(fmt.Errorf("pgdialect: %s is not supported", typ))
+	}
+
+	return func(fmter schema.Formatter, b []byte, v reflect.Value) []byte {
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, "NULL"...)
+		}
+
+		b = append(b, '{')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendElem(fmter, b, v.Index(i))
+		}
+		return append(b, '}')
+	}
+}
+
+func appendFloat64ElemValue(fmter schema.Formatter, b []byte, v reflect.Value) []byte {
+	return arrayAppendFloat64(b, v.Float())
+}
+
 func appendStringElemValue(fmter schema.Formatter, b []byte, v reflect.Value) []byte {
 	return appendStringElem(b, v.String())
 }
@@ -352,6 +387,13 @@ func arrayScanner(typ reflect.Type) schema.ScannerFunc {
 	}
 
 	scanElem := schema.Scanner(elemType)
+	switch elemType.Kind() {
+	case reflect.Slice, reflect.Array:
+		if elemType.Elem().Kind() != reflect.Uint8 {
+			scanElem = subArrayScanner(elemType)
+		}
+	}
+
 	return func(dest reflect.Value, src interface{}) error {
 		dest = reflect.Indirect(dest)
 		if !dest.CanSet() {
@@ -397,6 +439,17 @@ func arrayScanner(typ reflect.Type) schema.ScannerFunc {
 	}
 }
 
+// subArrayScanner scans an element of a multi-dimensional array, e.g. {1,2} in {{1,2},{3,4}}.
+func subArrayScanner(typ reflect.Type) schema.ScannerFunc {
+	scan := arrayScanner(typ)
+	return func(dest reflect.Value, src interface{}) error {
+		if b, ok := src.([]byte); ok && b == nil {
+			return scan(dest, nil)
+		}
+		return scan(dest, src)
+	}
+}
+
 func scanStringSliceValue(dest reflect.Value, src interface{}) error {
 	dest = reflect.Indirect(dest)
 	if !dest.CanSet() {
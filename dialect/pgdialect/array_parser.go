@@ -65,6 +65,18 @@ func (p *arrayParser) readNext() error {
 
 		p.elem = b
 		return nil
+	case '{':
+		sub, err := p.p.ReadSubArray(ch)
+		if err != nil {
+			return err
+		}
+
+		if p.p.Peek() == ',' {
+			p.p.Advance()
+		}
+
+		p.elem = sub
+		return nil
 	case '[', '(':
 		rng, err := p.p.ReadRange(ch)
 		if err != nil {
@@ -24,6 +24,9 @@ func TestArrayParser(t *testing.T) {
 		{`{"1","2"}`, []string{"1", "2"}},
 		{`{"{1}","{2}"}`, []string{"{1}", "{2}"}},
 		{`{[1,2),[3,4)}`, []string{"[1,2)", "[3,4)"}},
+		{`{{1,2},{3,NULL}}`, []string{"{1,2}", "{3,NULL}"}},
+		{`{{{1}},{{2}}}`, []string{"{{1}}", "{{2}}"}},
+		{`{{"a,b","}"},{"\"{"}}`, []string{`{"a,b","}"}`, `{"\"{"}`}},
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,270 @@
+package pgdialect
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+var (
+	sqlScannerType = reflect.TypeFor[sql.Scanner]()
+	bytesType      = reflect.TypeFor[[]byte]()
+)
+
+// isCompositeStruct reports whether the struct type should be mapped to a PostgreSQL
+// composite type, e.g.
+//
+//	Address  Address `bun:",composite:address_type"`
+func isCompositeStruct(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return false
+	}
+	ptr := reflect.PointerTo(typ)
+	return !ptr.Implements(driverValuerType) && !ptr.Implements(sqlScannerType)
+}
+
+// compositeType formats and parses composite (row) literals like `(1,"foo bar",)`
+// using the struct fields as composite attributes in the struct order.
+type compositeType struct {
+	d   *Dialect
+	typ reflect.Type
+
+	once   sync.Once
+	fields []*schema.Field
+}
+
+func (c *compositeType) init() {
+	// The table is resolved lazily, because fields are processed while
+	// the parent table is still being created.
+	c.once.Do(func() {
+		c.fields = c.d.Tables().Get(c.typ).Fields
+	})
+}
+
+//------------------------------------------------------------------------------
+
+func (d *Dialect) compositeAppender(typ reflect.Type) schema.AppenderFunc {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if fn := d.compositeAppender(typ.Elem()); fn != nil {
+			return schema.PtrAppender(fn)
+		}
+		return nil
+	case reflect.Struct:
+		// continue below
+	default:
+		return nil
+	}
+
+	c := &compositeType{d: d, typ: typ}
+	return func(fmter schema.Formatter, b []byte, v reflect.Value) []byte {
+		row := c.appendRow(fmter, nil, v)
+		return fmter.Dialect().AppendString(b, internal.String(row))
+	}
+}
+
+// appendRow appends the composite literal without SQL quoting.
+func (c *compositeType) appendRow(fmter schema.Formatter, b []byte, strct reflect.Value) []byte {
+	c.init()
+
+	b = append(b, '(')
+	for i, f := range c.fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = c.d.appendCompositeElem(fmter, b, f, f.Value(strct))
+	}
+	b = append(b, ')')
+	return b
+}
+
+func (d *Dialect) appendCompositeElem(
+	fmter schema.Formatter, b []byte, f *schema.Field, v reflect.Value,
+) []byte {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return b // NULL
+		}
+	}
+
+	if v.Type().Implements(driverValuerType) {
+		value, err := v.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return dialect.AppendError(b, err)
+		}
+		return appendCompositeDriverValue(b, value)
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if f.Tag.HasOption("composite") && isCompositeStruct(v.Type()) {
+		c := &compositeType{d: d, typ: v.Type()}
+		row := c.appendRow(fmter, nil, v)
+		return appendCompositeString(b, internal.String(row))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return appendCompositeString(b, v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 't')
+		}
+		return append(b, 'f')
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(b, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return arrayAppendFloat64(b, v.Float())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return appendCompositeBytes(b, v.Bytes())
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return appendCompositeTime(b, v.Interface().(time.Time))
+		}
+	}
+
+	err := fmt.Errorf("pgdialect: can't append composite field %s of type %s", f.Name, v.Type())
+	return dialect.AppendError(b, err)
+}
+
+func appendCompositeDriverValue(b []byte, v driver.Value) []byte {
+	switch v := v.(type) {
+	case nil:
+		return b
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case float64:
+		return arrayAppendFloat64(b, v)
+	case bool:
+		if v {
+			return append(b, 't')
+		}
+		return append(b, 'f')
+	case []byte:
+		return appendCompositeBytes(b, v)
+	case string:
+		return appendCompositeString(b, v)
+	case time.Time:
+		return appendCompositeTime(b, v)
+	default:
+		err := fmt.Errorf("pgdialect: can't append composite value %T", v)
+		return dialect.AppendError(b, err)
+	}
+}
+
+func appendCompositeString(b []byte, s string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			// ignore
+		case '"', '\\':
+			b = append(b, '\\', c)
+		default:
+			b = append(b, c)
+		}
+	}
+	b = append(b, '"')
+	return b
+}
+
+func appendCompositeBytes(b []byte, bs []byte) []byte {
+	if bs == nil {
+		return b
+	}
+
+	b = append(b, `"\\x`...)
+
+	s := len(b)
+	b = append(b, make([]byte, hex.EncodedLen(len(bs)))...)
+	hex.Encode(b[s:], bs)
+
+	b = append(b, '"')
+	return b
+}
+
+func appendCompositeTime(b []byte, tm time.Time) []byte {
+	b = append(b, '"')
+	b = appendTime(b, tm)
+	b = append(b, '"')
+	return b
+}
+
+//------------------------------------------------------------------------------
+
+func (d *Dialect) compositeScanner(typ reflect.Type) schema.ScannerFunc {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if fn := d.compositeScanner(typ.Elem()); fn != nil {
+			return schema.PtrScanner(fn)
+		}
+		return nil
+	case reflect.Struct:
+		// continue below
+	default:
+		return nil
+	}
+
+	c := &compositeType{d: d, typ: typ}
+	return c.scan
+}
+
+func (c *compositeType) scan(dest reflect.Value, src interface{}) error {
+	dest = reflect.Indirect(dest)
+	if !dest.CanSet() {
+		return fmt.Errorf("bun: Scan(non-settable %s)", dest.Type())
+	}
+
+	if src == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+
+	b, err := toBytes(src)
+	if err != nil {
+		return err
+	}
+
+	c.init()
+
+	p := newCompositeParser(b)
+	var i int
+	for p.Next() {
+		if i >= len(c.fields) {
+			return fmt.Errorf("pgdialect: composite %q has more attributes than %s", b, c.typ)
+		}
+
+		var elem interface{}
+		if b := p.Elem(); b != nil {
+			if bytes.HasPrefix(b, []byte("\\x")) && c.fields[i].IndirectType == bytesType {
+				b, err = hex.DecodeString(internal.String(b[2:]))
+				if err != nil {
+					return err
+				}
+			}
+			elem = b
+		}
+		if err := c.fields[i].ScanValue(dest, elem); err != nil {
+			return err
+		}
+		i++
+	}
+	return p.Err()
+}
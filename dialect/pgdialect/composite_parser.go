@@ -0,0 +1,118 @@
+package pgdialect
+
+import (
+	"fmt"
+	"io"
+)
+
+type compositeParser struct {
+	p pgparser
+
+	elem []byte
+	done bool
+	err  error
+}
+
+func newCompositeParser(b []byte) *compositeParser {
+	p := new(compositeParser)
+
+	if len(b) < 2 || b[0] != '(' || b[len(b)-1] != ')' {
+		p.err = fmt.Errorf("pgdialect: can't parse composite: %q", b)
+		return p
+	}
+
+	p.p.Reset(b[1 : len(b)-1])
+	return p
+}
+
+func (p *compositeParser) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	p.err = p.readNext()
+	return p.err == nil
+}
+
+func (p *compositeParser) Err() error {
+	if p.err != io.EOF {
+		return p.err
+	}
+	return nil
+}
+
+// Elem returns the current attribute or nil for NULL.
+func (p *compositeParser) Elem() []byte {
+	return p.elem
+}
+
+func (p *compositeParser) readNext() error {
+	if p.done {
+		return io.EOF
+	}
+
+	switch p.p.Peek() {
+	case ',', 0:
+		// An empty attribute is NULL.
+		p.elem = nil
+	case '"':
+		p.p.Advance()
+		b, err := p.readQuoted()
+		if err != nil {
+			return err
+		}
+		p.elem = b
+	default:
+		p.elem = p.readUnquoted()
+	}
+
+	if !p.p.Valid() {
+		p.done = true
+		return nil
+	}
+	return p.p.Skip(',')
+}
+
+// readQuoted reads a double-quoted attribute where quotes are escaped
+// either by doubling them or with a backslash.
+func (p *compositeParser) readQuoted() ([]byte, error) {
+	b := make([]byte, 0)
+	for {
+		ch, err := p.p.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch ch {
+		case '\\':
+			next, err := p.p.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, next)
+		case '"':
+			if p.p.Peek() != '"' {
+				return b, nil
+			}
+			p.p.Advance()
+			b = append(b, '"')
+		default:
+			b = append(b, ch)
+		}
+	}
+}
+
+func (p *compositeParser) readUnquoted() []byte {
+	b := make([]byte, 0)
+	for p.p.Valid() {
+		ch := p.p.Peek()
+		if ch == ',' {
+			break
+		}
+		p.p.Advance()
+		if ch == '\\' && p.p.Valid() {
+			ch = p.p.Read()
+		}
+		b = append(b, ch)
+	}
+	return b
+}
@@ -0,0 +1,44 @@
+package pgdialect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeParser(t *testing.T) {
+	tests := []struct {
+		s   string
+		els []interface{}
+	}{
+		{`()`, []interface{}{nil}},
+		{`(1)`, []interface{}{"1"}},
+		{`(1,)`, []interface{}{"1", nil}},
+		{`(,1)`, []interface{}{nil, "1"}},
+		{`(1,foo,t)`, []interface{}{"1", "foo", "t"}},
+		{`("")`, []interface{}{""}},
+		{`("foo bar","a,b")`, []interface{}{"foo bar", "a,b"}},
+		{`("a""b","c\\d")`, []interface{}{`a"b`, `c\d`}},
+		{`("a\"b")`, []interface{}{`a"b`}},
+		{`("(1,""foo"")")`, []interface{}{`(1,"foo")`}},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			p := newCompositeParser([]byte(test.s))
+
+			got := make([]interface{}, 0)
+			for p.Next() {
+				if elem := p.Elem(); elem != nil {
+					got = append(got, string(elem))
+				} else {
+					got = append(got, nil)
+				}
+			}
+
+			require.NoError(t, p.Err())
+			require.Equal(t, test.els, got)
+		})
+	}
+}
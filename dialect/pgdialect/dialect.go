@@ -26,9 +26,10 @@ func init() {
 type Dialect struct {
 	schema.BaseDialect
 
-	tables    *schema.Tables
-	features  feature.Feature
-	uintAsInt bool
+	tables       *schema.Tables
+	features     feature.Feature
+	uintAsInt    bool
+	identQuoting dialect.IdentQuoting
 }
 
 var _ schema.Dialect = (*Dialect)(nil)
@@ -56,7 +57,16 @@ func New(opts ...DialectOption) *Dialect {
 		feature.GeneratedIdentity |
 		feature.CompositeIn |
 		feature.DeleteReturning |
-		feature.AlterColumnExists
+		feature.AlterColumnExists |
+		feature.WindowClause |
+		feature.FullTextSearch |
+		feature.FullJoin |
+		feature.DistinctFrom |
+		feature.ILike |
+		feature.RelationJSON |
+		feature.DeferrableFK |
+		feature.MaterializedView |
+		feature.FetchWithTies
 
 	for _, opt := range opts {
 		opt(d)
@@ -73,6 +83,14 @@ func WithoutFeature(other feature.Feature) DialectOption {
 	}
 }
 
+// WithIdentQuoting controls when identifiers are quoted, for example,
+// dialect.QuoteMinimal only quotes reserved words and names with special characters.
+func WithIdentQuoting(quoting dialect.IdentQuoting) DialectOption {
+	return func(d *Dialect) {
+		d.identQuoting = quoting
+	}
+}
+
 func WithAppendUintAsInt(on bool) DialectOption {
 	return func(d *Dialect) {
 		d.uintAsInt = on
@@ -119,6 +137,18 @@ func (d *Dialect) onField(field *schema.Field) {
 		return
 	}
 
+	if field.Tag.HasOption("composite") && isCompositeStruct(field.IndirectType) {
+		field.Append = d.compositeAppender(field.StructField.Type)
+		field.Scan = d.compositeScanner(field.StructField.Type)
+		return
+	}
+
+	if isIntervalField(field) {
+		field.Append = intervalAppender(field.StructField.Type)
+		field.Scan = intervalScanner(field.StructField.Type)
+		return
+	}
+
 	if field.Tag.HasOption("multirange") {
 		field.Append = d.arrayAppender(field.StructField.Type)
 		field.Scan = arrayScanner(field.StructField.Type)
@@ -136,6 +166,10 @@ func (d *Dialect) IdentQuote() byte {
 	return '"'
 }
 
+func (d *Dialect) IdentQuoting() dialect.IdentQuoting {
+	return d.identQuoting
+}
+
 func (d *Dialect) AppendUint32(b []byte, n uint32) []byte {
 	if d.uintAsInt {
 		return strconv.AppendInt(b, int64(int32(n)), 10)
@@ -2,7 +2,6 @@ package pgdialect
 
 import (
 	"context"
-	"strings"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/internal/ordered"
@@ -69,12 +68,12 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 			if c.IsSerial || c.IsIdentity {
 				def = ""
 			} else if !c.IsDefaultLiteral {
-				def = strings.ToLower(def)
+				def = sqlschema.NormalizeDefault(def)
 			}
 
 			colDefs.Store(c.Name, &Column{
 				Name:            c.Name,
-				SQLType:         c.DataType,
+				SQLType:         sqlschema.NormalizeType(c.DataType),
 				VarcharLen:      c.VarcharLen,
 				DefaultValue:    def,
 				IsNullable:      c.IsNullable,
@@ -95,6 +94,20 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 			})
 		}
 
+		var indexes []*Index
+		if err := in.db.NewRaw(sqlInspectIndexes, table.Schema, table.Name).Scan(ctx, &indexes); err != nil {
+			return dbSchema, err
+		}
+
+		var tableIndexes []sqlschema.Index
+		for _, index := range indexes {
+			tableIndexes = append(tableIndexes, sqlschema.Index{
+				Name:    index.Name,
+				Columns: sqlschema.NewOrderedColumns(index.Columns...),
+				Where:   index.Where,
+			})
+		}
+
 		var pk *sqlschema.PrimaryKey
 		if len(table.PrimaryKey.Columns) > 0 {
 			pk = &sqlschema.PrimaryKey{
@@ -109,13 +122,19 @@ func (in *Inspector) Inspect(ctx context.Context) (sqlschema.Database, error) {
 			Columns:           colDefs,
 			PrimaryKey:        pk,
 			UniqueConstraints: unique,
+			Indexes:           tableIndexes,
 		})
 	}
 
 	for _, fk := range fks {
+		// Tables in other schemas are referenced by their qualified name.
+		targetTable := fk.TargetTable
+		if fk.TargetSchema != in.SchemaName {
+			targetTable = fk.TargetSchema + "." + targetTable
+		}
 		dbSchema.ForeignKeys[sqlschema.ForeignKey{
 			From: sqlschema.NewColumnReference(fk.SourceTable, fk.SourceColumns...),
-			To:   sqlschema.NewColumnReference(fk.TargetTable, fk.TargetColumns...),
+			To:   sqlschema.NewColumnReference(targetTable, fk.TargetColumns...),
 		}] = fk.ConstraintName
 	}
 	return dbSchema, nil
@@ -156,6 +175,12 @@ type ForeignKey struct {
 	TargetColumns  []string `bun:"target_columns,array"`
 }
 
+type Index struct {
+	Name    string   `bun:"name"`
+	Columns []string `bun:"columns,array"`
+	Where   string   `bun:"where"`
+}
+
 type PrimaryKey struct {
 	ConstraintName string   `bun:"name"`
 	Columns        []string `bun:"columns,array"`
@@ -253,6 +278,33 @@ FROM (
 	) "c"
 WHERE "table_schema" = ? AND "table_name" = ?
 ORDER BY "table_schema", "table_name", "column_name"
+`
+
+	// sqlInspectIndexes retrieves ordinary indexes defined on the specified table.
+	// Index columns are listed in index order and partial indexes include their predicate.
+	// Unique indexes, indexes that back a constraint, and expression indexes are excluded.
+	// Like sqlInspectColumnsQuery, it should be passed to bun.NewRaw with args for table_schema and table_name.
+	sqlInspectIndexes = `
+SELECT
+	"idx".relname AS "name",
+	ARRAY(
+		SELECT "a".attname::text
+		FROM pg_attribute "a"
+		WHERE "a".attrelid = i.indrelid AND "a".attnum = ANY(i.indkey)
+		ORDER BY array_position(i.indkey::int2[], "a".attnum)
+	) AS "columns",
+	COALESCE(pg_get_expr(i.indpred, i.indrelid), '') AS "where"
+FROM pg_index i
+	JOIN pg_class "idx" ON "idx".oid = i.indexrelid
+	JOIN pg_class "t" ON "t".oid = i.indrelid
+	JOIN pg_namespace s ON s.oid = "t".relnamespace
+WHERE NOT i.indisprimary
+	AND NOT i.indisunique
+	AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = i.indexrelid)
+	AND i.indexprs IS NULL
+	AND s.nspname = ?
+	AND "t".relname = ?
+ORDER BY "idx".relname
 `
 
 	// sqlInspectForeignKeys get FK definitions for user-defined tables.
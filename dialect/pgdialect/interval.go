@@ -0,0 +1,217 @@
+package pgdialect
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+const (
+	intervalDay   = 24 * time.Hour
+	intervalMonth = 30 * intervalDay
+	// intervalYear matches the number of days per year used by PostgreSQL
+	// when extracting the epoch from an interval.
+	intervalYear = 365*intervalDay + intervalDay/4
+)
+
+// isIntervalField reports whether the time.Duration field is stored as an interval, e.g.
+//
+//	Timeout time.Duration `bun:",type:interval"`
+//
+// Other duration fields are stored as int64 nanoseconds.
+func isIntervalField(field *schema.Field) bool {
+	return field.IndirectType == durationType &&
+		strings.EqualFold(field.UserSQLType, pgTypeInterval)
+}
+
+func intervalAppender(typ reflect.Type) schema.AppenderFunc {
+	if typ.Kind() == reflect.Ptr {
+		return schema.PtrAppender(appendIntervalValue)
+	}
+	return appendIntervalValue
+}
+
+func appendIntervalValue(fmter schema.Formatter, b []byte, v reflect.Value) []byte {
+	b = append(b, '\'')
+	b = appendInterval(b, time.Duration(v.Int()))
+	b = append(b, '\'')
+	return b
+}
+
+// appendInterval appends the duration as `[-]HH:MM:SS[.ffffff]`.
+// PostgreSQL intervals have microsecond precision, so nanoseconds are truncated.
+func appendInterval(b []byte, d time.Duration) []byte {
+	u := uint64(d)
+	if d < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	u /= uint64(time.Microsecond)
+
+	usec := u % 1e6
+	u /= 1e6
+	sec := u % 60
+	u /= 60
+	min := u % 60
+	hour := u / 60
+
+	if hour < 10 {
+		b = append(b, '0')
+	}
+	b = strconv.AppendUint(b, hour, 10)
+	b = append(b, ':')
+	b = appendTwoDigits(b, min)
+	b = append(b, ':')
+	b = appendTwoDigits(b, sec)
+
+	if usec > 0 {
+		b = append(b, '.')
+		frac := strconv.AppendUint(nil, usec+1e6, 10)[1:]
+		b = append(b, bytes.TrimRight(frac, "0")...)
+	}
+	return b
+}
+
+func appendTwoDigits(b []byte, n uint64) []byte {
+	return append(b, byte('0'+n/10), byte('0'+n%10))
+}
+
+//------------------------------------------------------------------------------
+
+func intervalScanner(typ reflect.Type) schema.ScannerFunc {
+	if typ.Kind() == reflect.Ptr {
+		return schema.PtrScanner(scanInterval)
+	}
+	return scanInterval
+}
+
+func scanInterval(dest reflect.Value, src interface{}) error {
+	dest = reflect.Indirect(dest)
+	if !dest.CanSet() {
+		return fmt.Errorf("bun: Scan(non-settable %s)", dest.Type())
+	}
+
+	if src == nil {
+		dest.SetInt(0)
+		return nil
+	}
+
+	b, err := toBytes(src)
+	if err != nil {
+		return err
+	}
+
+	d, err := parseInterval(internal.String(b))
+	if err != nil {
+		return err
+	}
+	dest.SetInt(int64(d))
+	return nil
+}
+
+// parseInterval parses intervals in the default `postgres` output style,
+// for example, `1 year 2 mons 3 days 04:05:06.789`. Years and months
+// are converted to 365.25 and 30 days respectively.
+func parseInterval(s string) (time.Duration, error) {
+	var d time.Duration
+
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		if strings.IndexByte(field, ':') >= 0 {
+			t, err := parseIntervalTime(field)
+			if err != nil {
+				return 0, fmt.Errorf("pgdialect: can't parse interval %q: %w", s, err)
+			}
+			d += t
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("pgdialect: can't parse interval %q", s)
+		}
+
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("pgdialect: can't parse interval %q: %w", s, err)
+		}
+
+		i++
+		switch unit := strings.TrimSuffix(fields[i], "s"); unit {
+		case "year":
+			d += time.Duration(n) * intervalYear
+		case "mon":
+			d += time.Duration(n) * intervalMonth
+		case "day":
+			d += time.Duration(n) * intervalDay
+		default:
+			return 0, fmt.Errorf("pgdialect: can't parse interval %q: unknown unit %q", s, fields[i])
+		}
+	}
+
+	return d, nil
+}
+
+// parseIntervalTime parses the time part of an interval, i.e. `[-]HH:MM:SS[.ffffff]`.
+func parseIntervalTime(s string) (time.Duration, error) {
+	var neg bool
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	sec, frac, _ := strings.Cut(parts[2], ".")
+	seconds, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	d := time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second
+
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		nsec, err := strconv.ParseInt(frac, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		for i := len(frac); i < 9; i++ {
+			nsec *= 10
+		}
+		d += time.Duration(nsec)
+	}
+
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
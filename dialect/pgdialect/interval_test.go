@@ -0,0 +1,62 @@
+package pgdialect
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendInterval(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00:00"},
+		{time.Second, "00:00:01"},
+		{90 * time.Minute, "01:30:00"},
+		{26*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond, "26:03:04.5"},
+		{time.Microsecond, "00:00:00.000001"},
+		{time.Nanosecond, "00:00:00"},
+		{-(time.Hour + time.Millisecond), "-01:00:00.001"},
+		{123 * time.Hour, "123:00:00"},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			got := appendInterval(nil, test.d)
+			require.Equal(t, test.want, string(got))
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+	}{
+		{"00:00:00", 0},
+		{"26:03:04.5", 26*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond},
+		{"-01:00:00.001", -(time.Hour + time.Millisecond)},
+		{"00:00:00.000001", time.Microsecond},
+		{"1 day", 24 * time.Hour},
+		{"3 days 04:05:06", 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second},
+		{"-1 days +02:00:00", -22 * time.Hour},
+		{"1 mon", 30 * 24 * time.Hour},
+		{"1 year 2 mons", intervalYear + 2*intervalMonth},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			got, err := parseInterval(test.s)
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+
+	for _, s := range []string{"1", "1 week", "P1D", "01:02"} {
+		_, err := parseInterval(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,140 @@
+package pgdialect
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	invWrite = 0x20000 // INV_WRITE from libpq-fs.h
+	invRead  = 0x40000 // INV_READ from libpq-fs.h
+
+	largeObjectChunkSize = 256 << 10
+)
+
+// WriteLargeObject creates a PostgreSQL large object, copies r into it in chunks,
+// and returns the object OID, which is usually stored in an `oid` column:
+//
+//	oid, err := pgdialect.WriteLargeObject(ctx, db, file)
+//
+// Large object descriptors only exist inside a transaction, so the object is written
+// in a transaction, or in a savepoint when db is a bun.Tx.
+//
+// Other databases don't have large objects; store big values in chunks instead,
+// e.g. in a table with (file_id, seq, data) columns, and read them back ordered by seq.
+func WriteLargeObject(ctx context.Context, db bun.IDB, r io.Reader) (oid uint32, err error) {
+	err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.QueryRowContext(ctx, "SELECT lo_create(0)").Scan(&oid); err != nil {
+			return err
+		}
+
+		var fd int32
+		if err := tx.QueryRowContext(
+			ctx, "SELECT lo_open(?, ?)", oid, invWrite,
+		).Scan(&fd); err != nil {
+			return err
+		}
+
+		buf := make([]byte, largeObjectChunkSize)
+		for {
+			n, err := io.ReadFull(r, buf)
+			if n > 0 {
+				if _, err := tx.ExecContext(ctx, "SELECT lowrite(?, ?)", fd, buf[:n]); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, "SELECT lo_close(?)", fd)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return oid, nil
+}
+
+// ReadLargeObject opens the large object for reading. The reader holds a transaction
+// (or a savepoint when db is a bun.Tx) until it is closed, so always close it:
+//
+//	rd, err := pgdialect.ReadLargeObject(ctx, db, oid)
+//	if err != nil {
+//		return err
+//	}
+//	defer rd.Close()
+//
+//	_, err = io.Copy(w, rd)
+func ReadLargeObject(ctx context.Context, db bun.IDB, oid uint32) (*LargeObjectReader, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var fd int32
+	if err := tx.QueryRowContext(ctx, "SELECT lo_open(?, ?)", oid, invRead).Scan(&fd); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return &LargeObjectReader{
+		ctx: ctx,
+		tx:  tx,
+		fd:  fd,
+	}, nil
+}
+
+// DeleteLargeObject removes the large object using lo_unlink.
+func DeleteLargeObject(ctx context.Context, db bun.IDB, oid uint32) error {
+	_, err := db.ExecContext(ctx, "SELECT lo_unlink(?)", oid)
+	return err
+}
+
+// LargeObjectReader reads a PostgreSQL large object in chunks using loread.
+type LargeObjectReader struct {
+	ctx context.Context
+	tx  bun.Tx
+	fd  int32
+
+	closed bool
+}
+
+var _ io.ReadCloser = (*LargeObjectReader)(nil)
+
+func (r *LargeObjectReader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, errors.New("pgdialect: read from closed LargeObjectReader")
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	var b []byte
+	if err := r.tx.QueryRowContext(
+		r.ctx, "SELECT loread(?, ?)", r.fd, min(len(p), largeObjectChunkSize),
+	).Scan(&b); err != nil {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, io.EOF
+	}
+	return copy(p, b), nil
+}
+
+// Close closes the large object descriptor and ends the transaction.
+func (r *LargeObjectReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	// Rolling back closes the descriptor, and the reader does not change any data.
+	return r.tx.Rollback()
+}
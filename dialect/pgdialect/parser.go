@@ -3,6 +3,7 @@ package pgdialect
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/uptrace/bun/internal/parser"
 )
@@ -91,6 +92,38 @@ func (p *pgparser) readSubstring(ch byte, escaped bool) ([]byte, error) {
 	return p.buf, nil
 }
 
+// ReadSubArray reads a nested array, e.g. {1,2} in {{1,2},{3,4}}, as is.
+func (p *pgparser) ReadSubArray(ch byte) ([]byte, error) {
+	p.buf = p.buf[:0]
+	p.buf = append(p.buf, ch)
+
+	depth := 1
+	var quoted bool
+	for depth > 0 {
+		if !p.Valid() {
+			return nil, fmt.Errorf("pgdialect: can't parse array: unterminated %q", p.buf)
+		}
+
+		ch = p.Read()
+		p.buf = append(p.buf, ch)
+
+		switch {
+		case quoted && ch == '\\':
+			if p.Valid() {
+				p.buf = append(p.buf, p.Read())
+			}
+		case ch == '"':
+			quoted = !quoted
+		case !quoted && ch == '{':
+			depth++
+		case !quoted && ch == '}':
+			depth--
+		}
+	}
+
+	return p.buf, nil
+}
+
 func (p *pgparser) ReadRange(ch byte) ([]byte, error) {
 	p.buf = p.buf[:0]
 	p.buf = append(p.buf, ch)
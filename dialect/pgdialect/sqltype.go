@@ -41,6 +41,9 @@ const (
 
 	// Binary Data Types
 	pgTypeBytea = "BYTEA" // binary string
+
+	// Text Search Types
+	pgTypeTSVector = "TSVECTOR" // document optimized for full-text search
 )
 
 var (
@@ -63,7 +66,7 @@ func fieldSQLType(field *schema.Field) string {
 		return field.UserSQLType
 	}
 
-	if v, ok := field.Tag.Option("composite"); ok {
+	if v, ok := field.Tag.Option("composite"); ok && v != "" {
 		return v
 	}
 	if field.Tag.HasOption("hstore") {
@@ -73,8 +76,7 @@ func fieldSQLType(field *schema.Field) string {
 	if field.Tag.HasOption("array") {
 		switch field.IndirectType.Kind() {
 		case reflect.Slice, reflect.Array:
-			sqlType := sqlType(field.IndirectType.Elem())
-			return sqlType + "[]"
+			return arraySQLType(field.IndirectType.Elem())
 		}
 	}
 
@@ -85,6 +87,18 @@ func fieldSQLType(field *schema.Field) string {
 	return sqlType(field.IndirectType)
 }
 
+// arraySQLType returns the type of the array with the elements of elemType,
+// e.g. float8[][] for [][]float64.
+func arraySQLType(elemType reflect.Type) string {
+	switch elemType.Kind() {
+	case reflect.Slice, reflect.Array:
+		if elemType.Elem().Kind() != reflect.Uint8 {
+			return arraySQLType(elemType.Elem()) + "[]"
+		}
+	}
+	return sqlType(elemType) + "[]"
+}
+
 func sqlType(typ reflect.Type) string {
 	switch typ {
 	case nullStringType: // typ.Kind() == reflect.Struct, test for exact match
@@ -95,6 +109,8 @@ func sqlType(typ reflect.Type) string {
 		return pgTypeCidr
 	case jsonRawMessageType:
 		return sqltype.JSONB
+	case tsvectorType:
+		return pgTypeTSVector
 	}
 
 	sqlType := schema.DiscoverSQLType(typ)
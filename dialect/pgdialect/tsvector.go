@@ -0,0 +1,42 @@
+package pgdialect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+var tsvectorType = reflect.TypeFor[TSVector]()
+
+// TSVector represents PostgreSQL tsvector data type using its text representation,
+// for example, 'fat':2 'rat':3. Usually the value is computed by the database
+// using to_tsvector and is only scanned by the application.
+//
+// Use SelectQuery.WhereTSMatch and SelectQuery.OrderByRank to search the column.
+type TSVector string
+
+var (
+	_ driver.Valuer = (*TSVector)(nil)
+	_ sql.Scanner   = (*TSVector)(nil)
+)
+
+func (v TSVector) Value() (driver.Value, error) {
+	return string(v), nil
+}
+
+func (v *TSVector) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*v = ""
+		return nil
+	case []byte:
+		*v = TSVector(src)
+		return nil
+	case string:
+		*v = TSVector(src)
+		return nil
+	default:
+		return fmt.Errorf("bun: TSVector can't scan %T", src)
+	}
+}
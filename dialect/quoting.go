@@ -0,0 +1,120 @@
+package dialect
+
+import "strings"
+
+// IdentQuoting controls when identifiers are quoted.
+type IdentQuoting int
+
+const (
+	// QuoteAlways quotes all identifiers. This is the default.
+	QuoteAlways IdentQuoting = iota
+
+	// QuoteMinimal only quotes identifiers that can't be written unquoted, i.e. reserved
+	// words and names that are not made of ASCII letters, digits, and underscores.
+	// Unquoted identifiers are case-folded by the database, for example, PostgreSQL
+	// folds them to lower case and Oracle to upper case.
+	QuoteMinimal
+)
+
+// AppendIdentMinimal is like AppendIdent, but only quotes the parts of the name
+// that require quoting, see QuoteMinimal.
+func AppendIdentMinimal(b []byte, name string, quote byte) []byte {
+	for i := 0; ; i++ {
+		part, rest, found := strings.Cut(name, ".")
+		if i > 0 {
+			b = append(b, '.')
+		}
+
+		switch {
+		case part == "*":
+			b = append(b, '*')
+		case strings.IndexByte(part, '*') >= 0:
+			b = AppendIdent(b, part, quote)
+		case NeedsQuoting(part):
+			b = AppendName(b, part, quote)
+		default:
+			b = append(b, part...)
+		}
+
+		if !found {
+			return b
+		}
+		name = rest
+	}
+}
+
+// AppendNameMinimal is like AppendName, but only quotes the name when it
+// requires quoting, see QuoteMinimal.
+func AppendNameMinimal(b []byte, name string, quote byte) []byte {
+	if NeedsQuoting(name) {
+		return AppendName(b, name, quote)
+	}
+	return append(b, name...)
+}
+
+// NeedsQuoting reports whether the identifier must be quoted, because it is empty,
+// is a reserved word, or contains characters other than ASCII letters, digits,
+// and underscores, or starts with a digit.
+func NeedsQuoting(ident string) bool {
+	if ident == "" {
+		return true
+	}
+	for i := 0; i < len(ident); i++ {
+		c := ident[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case c >= '0' && c <= '9':
+			if i == 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return IsReservedWord(ident)
+}
+
+// IsReservedWord reports whether the word is reserved by the SQL standard or
+// one of the supported databases and can't be used as an unquoted identifier.
+func IsReservedWord(word string) bool {
+	var buf [32]byte
+	if len(word) > len(buf) {
+		return false
+	}
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		buf[i] = c
+	}
+	_, ok := reservedWords[string(buf[:len(word)])]
+	return ok
+}
+
+var reservedWords = makeWordSet(
+	"add", "all", "alter", "analyse", "analyze", "and", "any", "array", "as", "asc",
+	"asymmetric", "authorization", "between", "binary", "both", "by", "case", "cast",
+	"check", "collate", "collation", "column", "comment", "concurrently", "constraint",
+	"create", "cross", "current_catalog", "current_date", "current_role", "current_schema",
+	"current_time", "current_timestamp", "current_user", "database", "default",
+	"deferrable", "delete", "desc", "distinct", "do", "drop", "else", "end", "escape",
+	"except", "exists", "false", "fetch", "for", "foreign", "freeze", "from", "full",
+	"grant", "group", "having", "ilike", "in", "index", "initially", "inner", "insert",
+	"intersect", "into", "is", "isnull", "join", "key", "lateral", "leading", "left",
+	"level", "like", "limit", "localtime", "localtimestamp", "match", "merge", "natural",
+	"not", "notnull", "null", "number", "offset", "on", "only", "option", "or", "order",
+	"outer", "over", "overlaps", "partition", "placing", "primary", "range", "references",
+	"rename", "returning", "right", "row", "rows", "select", "session_user", "set",
+	"similar", "size", "some", "symmetric", "system_user", "table", "tablesample", "then",
+	"to", "top", "trailing", "true", "union", "unique", "update", "user", "using",
+	"values", "variadic", "verbose", "when", "where", "window", "with",
+)
+
+func makeWordSet(words ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		set[word] = struct{}{}
+	}
+	return set
+}
@@ -7,6 +7,7 @@ const (
 	BigInt          = "BIGINT"
 	Real            = "REAL"
 	DoublePrecision = "DOUBLE PRECISION"
+	Numeric         = "NUMERIC"
 	VarChar         = "VARCHAR"
 	Blob            = "BLOB"
 	Timestamp       = "TIMESTAMP"
@@ -45,6 +45,10 @@ type Config struct {
 	// ResetSessionFunc is called prior to executing a query on a connection
 	// that has been used before.
 	ResetSessionFunc func(context.Context, *Conn) error
+
+	// NoticeHandler is called with the notices and warnings sent by the server,
+	// for example, by RAISE NOTICE. By default notices are discarded.
+	NoticeHandler func(Notice)
 }
 
 func newDefaultConfig() *Config {
@@ -190,6 +194,17 @@ func WithResetSessionFunc(fn func(context.Context, *Conn) error) Option {
 	}
 }
 
+// WithNoticeHandler configures a function that is called when the server sends
+// a notice or a warning, for example, using RAISE NOTICE in a PL/pgSQL function.
+// Notices received by a Listener while it waits for notifications are passed to it as well.
+// The function is called synchronously from the goroutine that uses the connection,
+// so it should not block or use the same connection.
+func WithNoticeHandler(fn func(Notice)) Option {
+	return func(conf *Config) {
+		conf.NoticeHandler = fn
+	}
+}
+
 func WithDSN(dsn string) Option {
 	return func(conf *Config) {
 		opts, err := parseDSN(dsn)
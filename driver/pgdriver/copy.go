@@ -72,7 +72,11 @@ func readCopyIn(ctx context.Context, cn *Conn) error {
 				return err
 			}
 			return firstErr
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return err
 			}
@@ -172,7 +176,11 @@ func readCopyOut(ctx context.Context, cn *Conn) error {
 				return err
 			}
 			return nil
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return err
 			}
@@ -238,7 +246,11 @@ func readCopyData(ctx context.Context, cn *Conn, w io.Writer) (res sql.Result, e
 				return nil, err
 			}
 			return res, firstErr
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
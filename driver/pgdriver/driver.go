@@ -489,7 +489,11 @@ func (r *rows) next(dest []driver.Value) (eof bool, _ error) {
 				return false, firstErr
 			}
 			return true, nil
-		case parameterStatusMsg, noticeResponseMsg:
+		case noticeResponseMsg:
+			if err := readNotice(r.cn, rd, msgLen); err != nil {
+				return false, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return false, err
 			}
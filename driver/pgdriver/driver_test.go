@@ -80,6 +80,27 @@ func TestConnector_WithResetSessionFunc(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestConnector_WithNoticeHandler(t *testing.T) {
+	var notices []pgdriver.Notice
+
+	db := sql.OpenDB(pgdriver.NewConnector(
+		pgdriver.WithDSN(dsn()),
+		pgdriver.WithNoticeHandler(func(notice pgdriver.Notice) {
+			notices = append(notices, notice)
+		}),
+	))
+	defer db.Close()
+
+	_, err := db.Exec(`DO $$ BEGIN RAISE WARNING 'custom warning' USING HINT = 'some hint'; END $$`)
+	require.NoError(t, err)
+
+	require.Len(t, notices, 1)
+	require.Equal(t, "WARNING", notices[0].Severity)
+	require.Equal(t, "01000", notices[0].Code)
+	require.Equal(t, "custom warning", notices[0].Message)
+	require.Equal(t, "some hint", notices[0].Hint)
+}
+
 func TestStmtSelect(t *testing.T) {
 	ctx := context.Background()
 	db := sqlDB()
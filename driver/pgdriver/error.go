@@ -21,6 +21,11 @@ func (err Error) Field(k byte) string {
 	return err.m[k]
 }
 
+// SQLState returns the SQLSTATE error code, e.g. 40001 for serialization failures.
+func (err Error) SQLState() string {
+	return err.Field('C')
+}
+
 // IntegrityViolation reports whether the error is a part of
 // Integrity Constraint Violation class of errors.
 //
@@ -44,6 +49,24 @@ func (err Error) Error() string {
 		err.Field('S'), err.Field('M'), err.Field('C'))
 }
 
+// Notice represents a notice or a warning sent by PostgreSQL server
+// using PostgreSQL NoticeResponse protocol.
+//
+// https://www.postgresql.org/docs/current/static/protocol-message-formats.html
+type Notice struct {
+	// Severity is one of WARNING, NOTICE, DEBUG, INFO, or LOG.
+	Severity string
+	// Code is the SQLSTATE code, e.g. 01000 for warnings.
+	Code    string
+	Message string
+	Detail  string
+	Hint    string
+}
+
+func (n Notice) String() string {
+	return fmt.Sprintf("%s: %s (SQLSTATE=%s)", n.Severity, n.Message, n.Code)
+}
+
 func isBadConn(err error, allowTimeout bool) bool {
 	switch err {
 	case nil:
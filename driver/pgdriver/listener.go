@@ -209,7 +209,7 @@ func (ln *Listener) ReceiveTimeout(
 	}
 
 	rd := cn.reader(ctx, timeout)
-	channel, payload, err = readNotification(ctx, rd)
+	channel, payload, err = readNotification(ctx, cn, rd)
 	if err != nil {
 		ln.checkConn(ctx, cn, err, timeout > 0)
 		return "", "", err
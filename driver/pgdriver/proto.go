@@ -177,7 +177,11 @@ func startup(ctx context.Context, cn *Conn) error {
 			}
 		case readyForQueryMsg:
 			return rd.Discard(msgLen)
-		case parameterStatusMsg, noticeResponseMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return err
 			}
@@ -515,11 +519,14 @@ func readQuery(ctx context.Context, cn *Conn) (sql.Result, error) {
 			}
 		case describeMsg,
 			rowDescriptionMsg,
-			noticeResponseMsg,
 			parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
 		case readyForQueryMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
@@ -571,7 +578,11 @@ func readQueryData(ctx context.Context, cn *Conn) (*rows, error) {
 			if firstErr == nil {
 				firstErr = errEmptyQuery
 			}
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
@@ -665,7 +676,7 @@ func readRowDescription(rd *reader) (*rowDescription, error) {
 
 //------------------------------------------------------------------------------
 
-func readNotification(ctx context.Context, rd *reader) (channel, payload string, err error) {
+func readNotification(ctx context.Context, cn *Conn, rd *reader) (channel, payload string, err error) {
 	for {
 		c, msgLen, err := readMessageType(rd)
 		if err != nil {
@@ -673,10 +684,14 @@ func readNotification(ctx context.Context, rd *reader) (channel, payload string,
 		}
 
 		switch c {
-		case commandCompleteMsg, readyForQueryMsg, noticeResponseMsg:
+		case commandCompleteMsg, readyForQueryMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return "", "", err
 			}
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return "", "", err
+			}
 		case errorResponseMsg:
 			e, err := readError(rd)
 			if err != nil {
@@ -778,7 +793,11 @@ func readParseDescribeSync(ctx context.Context, cn *Conn) (*rowDescription, erro
 			if firstErr == nil {
 				firstErr = e
 			}
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
@@ -873,7 +892,11 @@ func readExtQuery(ctx context.Context, cn *Conn) (driver.Result, error) {
 			if firstErr == nil {
 				firstErr = errEmptyQuery
 			}
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
@@ -922,7 +945,11 @@ func readExtQueryData(ctx context.Context, cn *Conn, rowDesc *rowDescription) (*
 			if firstErr == nil {
 				firstErr = errEmptyQuery
 			}
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return nil, err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return nil, err
 			}
@@ -964,7 +991,11 @@ func readCloseStmtComplete(ctx context.Context, cn *Conn) error {
 				return err
 			}
 			return e
-		case noticeResponseMsg, parameterStatusMsg:
+		case noticeResponseMsg:
+			if err := readNotice(cn, rd, msgLen); err != nil {
+				return err
+			}
+		case parameterStatusMsg:
 			if err := rd.Discard(msgLen); err != nil {
 				return err
 			}
@@ -1013,6 +1044,47 @@ func readString(rd *reader) (string, error) {
 }
 
 func readError(rd *reader) (error, error) {
+	m, err := readFields(rd)
+	if err != nil {
+		return nil, err
+	}
+	switch err := (Error{m: m}); err.Field('V') {
+	case "FATAL", "PANIC":
+		// Return this as an error and stop processing.
+		return nil, err
+	default:
+		// Return this as an error message and continue processing.
+		return err, nil
+	}
+}
+
+func readNotice(cn *Conn, rd *reader, msgLen int) error {
+	if cn.conf.NoticeHandler == nil {
+		return rd.Discard(msgLen)
+	}
+
+	m, err := readFields(rd)
+	if err != nil {
+		return err
+	}
+
+	severity := m['V'] // non-localized, PostgreSQL 9.6+
+	if severity == "" {
+		severity = m['S']
+	}
+
+	cn.conf.NoticeHandler(Notice{
+		Severity: severity,
+		Code:     m['C'],
+		Message:  m['M'],
+		Detail:   m['D'],
+		Hint:     m['H'],
+	})
+	return nil
+}
+
+// readFields reads the fields of ErrorResponse and NoticeResponse messages.
+func readFields(rd *reader) (map[byte]string, error) {
 	m := make(map[byte]string)
 	for {
 		c, err := rd.ReadByte()
@@ -1028,14 +1100,7 @@ func readError(rd *reader) (error, error) {
 		}
 		m[c] = s
 	}
-	switch err := (Error{m: m}); err.Field('V') {
-	case "FATAL", "PANIC":
-		// Return this as an error and stop processing.
-		return nil, err
-	default:
-		// Return this as an error message and continue processing.
-		return err, nil
-	}
+	return m, nil
 }
 
 //------------------------------------------------------------------------------
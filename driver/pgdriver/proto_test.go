@@ -0,0 +1,39 @@
+package pgdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNotificationNotice(t *testing.T) {
+	var buf bytes.Buffer
+	writeMsg := func(c byte, body []byte) {
+		buf.WriteByte(c)
+		_ = binary.Write(&buf, binary.BigEndian, int32(len(body)+4))
+		buf.Write(body)
+	}
+
+	writeMsg(noticeResponseMsg, []byte("SWARNING\x00VWARNING\x00C01000\x00Mcustom warning\x00\x00"))
+	writeMsg(notificationResponseMsg, []byte("\x00\x00\x00\x01test_channel\x00test_payload\x00"))
+
+	var notices []Notice
+	cn := &Conn{conf: &Config{
+		NoticeHandler: func(notice Notice) {
+			notices = append(notices, notice)
+		},
+	}}
+
+	channel, payload, err := readNotification(context.Background(), cn, newReader(&buf))
+	require.NoError(t, err)
+	require.Equal(t, "test_channel", channel)
+	require.Equal(t, "test_payload", payload)
+
+	require.Len(t, notices, 1)
+	require.Equal(t, "WARNING", notices[0].Severity)
+	require.Equal(t, "01000", notices[0].Code)
+	require.Equal(t, "custom warning", notices[0].Message)
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"database/sql"
 	"fmt"
-	"strings"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
@@ -11,7 +10,8 @@ import (
 )
 
 type Item struct {
-	ID int64 `bun:",pk,autoincrement"`
+	ID   int64 `bun:",pk,autoincrement"`
+	Name string
 }
 
 func main() {
@@ -23,26 +23,18 @@ func main() {
 	db := bun.NewDB(sqldb, sqlitedialect.New())
 	defer db.Close()
 
-	q := db.NewSelect().Model((*Item)(nil)).Where("id > ?", 0).Where("id < ?", 10)
+	q := db.NewSelect().Model((*Item)(nil)).WhereILike("name", "a%").WhereDistinctFrom("id", 0)
+	fmt.Println(GetWhereFields(q))
 
-	fmt.Println(GetWhereFields(q.String()))
+	// Columns used in raw SQL expressions are not reported.
+	q = db.NewSelect().Model((*Item)(nil)).Where("id > ?", 0)
+	fmt.Println(GetWhereFields(q))
 }
 
-func GetWhereFields(query string) []string {
-	q := strings.Split(query, "WHERE ")
-	if len(q) == 1 {
-		return nil
-	}
-
-	whereFields := strings.Split(q[1], " AND ")
-
-	fields := make([]string, len(whereFields))
-
-	for i := range whereFields {
-		fields[i] = strings.TrimFunc(whereFields[i], func(r rune) bool {
-			return r == '(' || r == ')'
-		})
+func GetWhereFields(q *bun.SelectQuery) ([]string, bool) {
+	md, err := q.Metadata()
+	if err != nil {
+		panic(err)
 	}
-
-	return fields
+	return md.WhereColumns, md.WhereOpaque
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+func main() {
+	ctx := context.Background()
+
+	sqlite, err := sql.Open(sqliteshim.ShimName, "file::memory:?cache=shared")
+	if err != nil {
+		panic(err)
+	}
+	sqlite.SetMaxOpenConns(1)
+
+	db := bun.NewDB(sqlite, sqlitedialect.New())
+	db.AddQueryHook(&QueryHook{
+		logger: slog.New(slog.NewJSONHandler(os.Stdout, nil)),
+		redact: map[int]bool{1: true}, // don't log passwords
+	})
+
+	if err := db.ResetModel(ctx, (*User)(nil)); err != nil {
+		panic(err)
+	}
+
+	if _, err := db.NewRaw(
+		"INSERT INTO users (name, password) VALUES (?, ?)", "admin", "secret",
+	).Exec(ctx); err != nil {
+		panic(err)
+	}
+
+	user := new(User)
+	if err := db.NewSelect().Model(user).Where("name = ?", "admin").Scan(ctx); err != nil {
+		panic(err)
+	}
+}
+
+type User struct {
+	ID       int64 `bun:",pk,autoincrement"`
+	Name     string
+	Password string
+}
+
+// QueryHook logs queries as JSON with the query template and the args in separate fields.
+type QueryHook struct {
+	logger *slog.Logger
+	// redact contains the indexes of the args that must not be logged.
+	redact map[int]bool
+}
+
+var _ bun.QueryHook = (*QueryHook)(nil)
+
+func (h *QueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *QueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	args := make([]interface{}, len(event.QueryArgs))
+	for i, arg := range event.QueryArgs {
+		if h.redact[i] {
+			arg = "[REDACTED]"
+		}
+		args[i] = arg
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", event.Operation()),
+		slog.Duration("duration", time.Since(event.StartTime)),
+		slog.String("query", event.QueryTemplate),
+		slog.Any("args", args),
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.String("error", event.Err.Error()))
+	}
+
+	h.logger.LogAttrs(ctx, slog.LevelInfo, "query", attrs...)
+}
@@ -24,3 +24,30 @@ func (StdProvider) NewEncoder(w io.Writer) Encoder {
 func (StdProvider) NewDecoder(r io.Reader) Decoder {
 	return json.NewDecoder(r)
 }
+
+//------------------------------------------------------------------------------
+
+var _ Provider = (*FuncProvider)(nil)
+
+// FuncProvider is a Provider that uses custom marshal and unmarshal functions,
+// for example, from jsoniter or sonic. Encoders and decoders fall back to encoding/json.
+type FuncProvider struct {
+	StdProvider
+
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+func (p FuncProvider) Marshal(v interface{}) ([]byte, error) {
+	if p.MarshalFunc == nil {
+		return p.StdProvider.Marshal(v)
+	}
+	return p.MarshalFunc(v)
+}
+
+func (p FuncProvider) Unmarshal(data []byte, v interface{}) error {
+	if p.UnmarshalFunc == nil {
+		return p.StdProvider.Unmarshal(data, v)
+	}
+	return p.UnmarshalFunc(data, v)
+}
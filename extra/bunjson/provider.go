@@ -6,10 +6,21 @@ import (
 
 var provider Provider = StdProvider{}
 
+// SetProvider replaces the JSON implementation used by bun to marshal and scan JSON values.
+// It is not safe to call SetProvider concurrently with queries, so call it once
+// on program start before opening a DB.
 func SetProvider(p Provider) {
 	provider = p
 }
 
+// Provider is a JSON implementation compatible with encoding/json.
+//
+// Implementations must behave like encoding/json: Marshal must call
+// json.Marshaler and encoding.TextMarshaler on values that implement them
+// (including pointer receivers on addressable values), Unmarshal must call
+// json.Unmarshaler and encoding.TextUnmarshaler, and Decoder.UseNumber must
+// decode numbers into interface{} as json.Number. All methods must be safe
+// for concurrent use.
 type Provider interface {
 	Marshal(v interface{}) ([]byte, error)
 	Unmarshal(data []byte, v interface{}) error
@@ -3,6 +3,7 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -16,7 +17,13 @@ type QueryEvent struct {
 
 	QueryAppender schema.QueryAppender // DEPRECATED: use IQuery instead
 	IQuery        Query
-	Query         string
+	// Query is the query with the args interpolated, as it is sent to the database.
+	Query string
+	// QueryTemplate and QueryArgs are the query and the args before interpolation,
+	// for example, `SELECT * FROM users WHERE id = ?` and `[1]`, which structured loggers
+	// can emit separately to redact the values. They are set for the DB, Conn, and Tx
+	// methods and for raw queries. Query builders interpolate the args while building
+	// the query, so QueryTemplate is the same as Query and QueryArgs is nil.
 	QueryTemplate string
 	QueryArgs     []interface{}
 	Model         Model
@@ -61,10 +68,32 @@ func (db *DB) beforeQuery(
 	model Model,
 ) (context.Context, *QueryEvent) {
 	atomic.AddUint32(&db.stats.Queries, 1)
+	switch iquery.(type) {
+	case *SelectQuery:
+		atomic.AddUint32(&db.stats.Selects, 1)
+	case *InsertQuery:
+		atomic.AddUint32(&db.stats.Inserts, 1)
+	case *UpdateQuery:
+		atomic.AddUint32(&db.stats.Updates, 1)
+	case *DeleteQuery:
+		atomic.AddUint32(&db.stats.Deletes, 1)
+	}
+
+	if q, ok := iquery.(interface{ setCompiledQuery(string) }); ok {
+		q.setCompiledQuery(query)
+	}
 
 	if len(db.queryHooks) == 0 {
 		return ctx, nil
 	}
+	if q, ok := iquery.(interface{ hooksDisabled() bool }); ok && q.hooksDisabled() {
+		return ctx, nil
+	}
+	if q, ok := iquery.(interface {
+		queryTemplate() (string, []interface{})
+	}); ok {
+		queryTemplate, queryArgs = q.queryTemplate()
+	}
 
 	event := &QueryEvent{
 		DB: db,
@@ -86,27 +115,82 @@ func (db *DB) beforeQuery(
 	return ctx, event
 }
 
+// afterQuery updates the stats, calls the query hooks, and returns the query error,
+// which is wrapped with TimeoutError or CanceledError when the context interrupted the query.
 func (db *DB) afterQuery(
 	ctx context.Context,
 	event *QueryEvent,
 	res sql.Result,
 	err error,
-) {
+) error {
 	switch err {
 	case nil, sql.ErrNoRows:
 		// nothing
 	default:
 		atomic.AddUint32(&db.stats.Errors, 1)
+		err = db.wrapContextError(ctx, err)
 	}
 
 	if event == nil {
-		return
+		return err
 	}
 
 	event.Result = res
 	event.Err = err
 
 	db.afterQueryFromIndex(ctx, event, len(db.queryHooks)-1)
+	return err
+}
+
+// wrapContextError wraps the error with TimeoutError or CanceledError when the query
+// was interrupted by the context. Besides the context errors, it recognizes the errors
+// drivers return when they cancel the query on the server while the context is done.
+func (db *DB) wrapContextError(ctx context.Context, err error) error {
+	var timeoutErr *TimeoutError
+	var canceledErr *CanceledError
+	if errors.As(err, &timeoutErr) || errors.As(err, &canceledErr) {
+		return err
+	}
+
+	cause := err
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+	case ctx.Err() != nil && isQueryCanceledError(err):
+		cause = ctx.Err()
+	default:
+		return err
+	}
+
+	if errors.Is(cause, context.DeadlineExceeded) {
+		atomic.AddUint32(&db.stats.Timeouts, 1)
+		return &TimeoutError{Err: err}
+	}
+	atomic.AddUint32(&db.stats.Cancellations, 1)
+	return &CanceledError{Err: err}
+}
+
+// isQueryCanceledError reports whether the driver returned the error
+// because the query was canceled on the server.
+func isQueryCanceledError(err error) bool {
+	// pgdriver and pgx errors.
+	var sqlStateErr interface{ SQLState() string }
+	if errors.As(err, &sqlStateErr) {
+		return sqlStateErr.SQLState() == "57014" // query_canceled
+	}
+
+	// modernc.org/sqlite returns SQLITE_INTERRUPT.
+	var codeErr interface{ Code() int }
+	if errors.As(err, &codeErr) {
+		return codeErr.Code() == 9
+	}
+
+	// go-sql-driver/mysql formats errors as "Error 1317 (70100): Query execution was interrupted".
+	for ; err != nil; err = errors.Unwrap(err) {
+		if strings.HasPrefix(err.Error(), "Error 1317") {
+			return true
+		}
+	}
+	return false
 }
 
 func (db *DB) afterQueryFromIndex(ctx context.Context, event *QueryEvent, hookIndex int) {
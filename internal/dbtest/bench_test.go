@@ -101,3 +101,71 @@ func resetBenchSchema(tb testing.TB, db *bun.DB) error {
 
 	return nil
 }
+
+type WideBench struct {
+	ID    int64 `bun:",pk,autoincrement"`
+	Str1  string
+	Str2  string
+	Str3  string
+	Str4  string
+	Str5  string
+	Str6  string
+	Str7  string
+	Str8  string
+	Int1  int64
+	Int2  int64
+	Int3  int64
+	Int4  int64
+	Int5  int64
+	Int6  int64
+	Int7  int64
+	Int8  int64
+	Time1 time.Time
+	Time2 time.Time
+}
+
+func BenchmarkSelectWideSlice(b *testing.B) {
+	for name, newDB := range allDBs {
+		b.Run(name, func(b *testing.B) {
+			db := newDB(b)
+
+			err := resetWideBenchSchema(b, db)
+			require.NoError(b, err)
+
+			b.Run("Scan", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					var bs []WideBench
+					if err := db.NewSelect().Model(&bs).Scan(ctx); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			b.Run("ScanPositional", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					var bs []WideBench
+					if err := db.NewSelect().Model(&bs).ScanPositional(ctx); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func resetWideBenchSchema(tb testing.TB, db *bun.DB) error {
+	mustResetModel(tb, ctx, db, (*WideBench)(nil))
+
+	models := make([]WideBench, 1000)
+	for i := range models {
+		models[i] = WideBench{
+			Str1: gofakeit.Name(), Str2: gofakeit.Name(), Str3: gofakeit.Name(), Str4: gofakeit.Name(),
+			Str5: gofakeit.Name(), Str6: gofakeit.Name(), Str7: gofakeit.Name(), Str8: gofakeit.Name(),
+			Int1: int64(i), Int2: int64(i), Int3: int64(i), Int4: int64(i),
+			Int5: int64(i), Int6: int64(i), Int7: int64(i), Int8: int64(i),
+			Time1: time.Now(), Time2: time.Now(),
+		}
+	}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	return err
+}
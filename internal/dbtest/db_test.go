@@ -6,15 +6,18 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dbfixture"
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/dialect/mssqldialect"
@@ -255,6 +258,39 @@ func TestDB(t *testing.T) {
 		{testSelectCount},
 		{testSelectMap},
 		{testSelectMapSlice},
+		{testScanColumns},
+		{testRawColumns},
+		{testQueryMetadata},
+		{testValuesColumnNames},
+		{testScanPositional},
+		{testInsertDefaultValues},
+		{testSelectWindow},
+		{testSelectMaxRows},
+		{testInsertBatchSize},
+		{testInsertSelectReturning},
+		{testCompiledQuery},
+		{testQueryReset},
+		{testRawScanMulti},
+		{testScanNestedColumnExpr},
+		{testInsertOnConflictDoUpdateWhere},
+		{testScanChan},
+		{testWhereDistinctFrom},
+		{testWhereILikeEscape},
+		{testContextErrors},
+		{testSelectKeyValueMap},
+		{testUpdateVersion},
+		{testUpdateFromReturning},
+		{testWithoutServerPrepare},
+		{testCreateTemporaryTable},
+		{testAlterColumn},
+		{testTimestamps},
+		{testSelectDiscardUnknownColumns},
+		{testOperationStats},
+		{testDeleteChunks},
+		{testValuesArg},
+		{testScanPolymorphic},
+		{testSetConstraintsDeferred},
+		{testWarmup},
 		{testSelectStruct},
 		{testSelectNestedStructValue},
 		{testSelectNestedStructPtr},
@@ -266,10 +302,12 @@ func TestDB(t *testing.T) {
 		{testJSONSpecialChars},
 		{testSelectRawMessage},
 		{testScanNullVar},
+		{testStrictNullScan},
 		{testScanSingleRow},
 		{testScanSingleRowByRow},
 		{testScanRows},
 		{testRunInTx},
+		{testRunInTxRetry},
 		{testJSONInterface},
 		{testJSONValuer},
 		{testSelectBool},
@@ -278,6 +316,8 @@ func TestDB(t *testing.T) {
 		{testWithForeignKeysAndRules},
 		{testWithForeignKeys},
 		{testWithForeignKeysHasMany},
+		{testFixtureTruncateTables},
+		{testFixtureErrors},
 		{testWithPointerForeignKeysHasMany},
 		{testWithPointerForeignKeysHasManyWithDriverValuer},
 		{testInterfaceAny},
@@ -291,14 +331,18 @@ func TestDB(t *testing.T) {
 		{testUpsert},
 		{testMultiUpdate},
 		{testUpdateWithSkipupdateTag},
+		{testUpdateReturningSlice},
+		{testUpdateSetMap},
 		{testScanAndCount},
 		{testEmbedModelValue},
 		{testEmbedModelPointer},
 		{testJSONMarshaler},
 		{testNilDriverValue},
 		{testRunInTxAndSavepoint},
+		{testConnSessionVar},
 		{testDriverValuerReturnsItself},
 		{testNoPanicWhenReturningNullColumns},
+		{testDropTableDisableForeignKeyChecks},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -319,95 +363,1190 @@ func testNilModel(t *testing.T, db *bun.DB) {
 	err := db.NewSelect().ColumnExpr("1").Scan(ctx)
 	require.Error(t, err)
 	require.Equal(t, "bun: Model(nil)", err.Error())
+	require.ErrorIs(t, err, bun.ErrNilModel)
+
+	_, err = db.NewInsert().Model(nil).Exec(ctx)
+	require.ErrorIs(t, err, bun.ErrNilModel)
+}
+
+func testSelectScan(t *testing.T, db *bun.DB) {
+	var num int
+	err := db.NewSelect().ColumnExpr("10").Scan(ctx, &num)
+	require.NoError(t, err)
+	require.Equal(t, 10, num)
+
+	err = db.NewSelect().
+		ColumnExpr("t.num").
+		TableExpr("(SELECT 10 AS num) AS t").
+		Where("1 = 2").
+		Scan(ctx, &num)
+	require.Equal(t, sql.ErrNoRows, err)
+
+	var str string
+	err = db.NewSelect().ColumnExpr("?", "\\\"'hello\n%_").Scan(ctx, &str)
+	require.NoError(t, err)
+	require.Equal(t, "\\\"'hello\n%_", str)
+}
+
+func testSelectCount(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.CTE) {
+		t.Skip()
+		return
+	}
+
+	values := db.NewValues(&[]map[string]interface{}{
+		{"num": 1},
+		{"num": 2},
+		{"num": 3},
+	})
+
+	q := db.NewSelect().
+		With("t", values).
+		Column("t.num").
+		TableExpr("t").
+		OrderExpr("t.num DESC").
+		Limit(1)
+
+	var num int
+	err := q.Scan(ctx, &num)
+	require.NoError(t, err)
+	require.Equal(t, 3, num)
+
+	count, err := q.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func testSelectMap(t *testing.T, db *bun.DB) {
+	var m map[string]interface{}
+	err := db.NewSelect().
+		ColumnExpr("10 AS num").
+		Scan(ctx, &m)
+	require.NoError(t, err)
+	switch v := m["num"]; v.(type) {
+	case int32:
+		require.Equal(t, int32(10), v)
+	case int64:
+		require.Equal(t, int64(10), v)
+	default:
+		t.Fail()
+	}
+}
+
+func testSelectMapSlice(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.CTE) {
+		t.Skip()
+	}
+
+	values := db.NewValues(&[]map[string]interface{}{
+		{"column1": 1},
+		{"column1": 2},
+		{"column1": 3},
+	})
+
+	var ms []map[string]interface{}
+	err := db.NewSelect().
+		With("t", values).
+		TableExpr("t").
+		Scan(ctx, &ms)
+	require.NoError(t, err)
+	require.Len(t, ms, 3)
+	for i, m := range ms {
+		require.Equal(t, map[string]interface{}{
+			"column1": int64(i + 1),
+		}, m)
+	}
+}
+
+func testSelectKeyValueMap(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.CTE) {
+		t.Skip()
+	}
+
+	values := db.NewValues(&[]map[string]interface{}{
+		{"category": "a", "num": 1},
+		{"category": "a", "num": 2},
+		{"category": "b", "num": 3},
+	})
+
+	var counts map[string]int
+	err := db.NewSelect().
+		With("t", values).
+		TableExpr("t").
+		ColumnExpr("category, count(*)").
+		Group("category").
+		Scan(ctx, &counts)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 2, "b": 1}, counts)
+
+	var categories map[int64]string
+	err = db.NewSelect().
+		With("t", values).
+		TableExpr("t").
+		ColumnExpr("num, category").
+		Scan(ctx, &categories)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]string{1: "a", 2: "a", 3: "b"}, categories)
+
+	err = db.NewSelect().
+		With("t", values).
+		TableExpr("t").
+		ColumnExpr("num, category, num").
+		Scan(ctx, &categories)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "requires 2 columns")
+}
+
+func testUpdateVersion(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID      int64 `bun:",pk,autoincrement"`
+		Name    string
+		Version int64 `bun:",version"`
+	}
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	model := &Model{Name: "hello", Version: 1}
+	_, err := db.NewInsert().Model(model).Exec(ctx)
+	require.NoError(t, err)
+
+	stale := *model
+
+	model.Name = "world"
+	_, err = db.NewUpdate().Model(model).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), model.Version)
+
+	stale.Name = "stale"
+	_, err = db.NewUpdate().Model(&stale).WherePK().Exec(ctx)
+	require.ErrorIs(t, err, bun.ErrVersionConflict)
+	require.Equal(t, int64(1), stale.Version)
+
+	got := new(Model)
+	err = db.NewSelect().Model(got).Where("id = ?", model.ID).Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, model, got)
+}
+
+func testWithoutServerPrepare(t *testing.T, db *bun.DB) {
+	db = bun.NewDB(db.DB, db.Dialect(), bun.WithoutServerPrepare())
+
+	var num int
+	err := db.NewSelect().ColumnExpr("?", 42).Scan(ctx, &num)
+	require.NoError(t, err)
+	require.Equal(t, 42, num)
+
+	_, err = db.ExecContext(ctx, "SELECT ?", 1)
+	require.NoError(t, err)
+
+	_, err = db.Prepare("SELECT 1")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "WithoutServerPrepare")
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.PrepareContext(ctx, "SELECT 1")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "WithoutServerPrepare")
+
+	err = conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.Prepare("SELECT 1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "WithoutServerPrepare")
+
+		_, err = tx.PrepareContext(ctx, "SELECT 1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "WithoutServerPrepare")
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func testSetConstraintsDeferred(t *testing.T, db *bun.DB) {
+	if !db.HasFeature(feature.DeferrableFK) {
+		t.Skip("DeferrableFK is not supported")
+	}
+
+	type Node struct {
+		ID     int64 `bun:",pk"`
+		NextID int64
+		Next   *Node `bun:"rel:belongs-to,join:next_id=id,deferrable:immediate"`
+	}
+
+	_, err := db.NewDropTable().Model((*Node)(nil)).IfExists().Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewCreateTable().Model((*Node)(nil)).WithForeignKeys().Exec(ctx)
+	require.NoError(t, err)
+	mustDropTableOnCleanup(t, ctx, db, (*Node)(nil))
+
+	// The transactions use the same connection, so the pragma is set
+	// only for them and not for the rest of the pool.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	if db.Dialect().Name() == dialect.SQLite {
+		_, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+		require.NoError(t, err)
+		defer func() {
+			_, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+			require.NoError(t, err)
+		}()
+	}
+
+	// Immediate constraints are checked at the end of each statement,
+	// so the rows are inserted one by one.
+	insertNodes := func(ctx context.Context, tx bun.Tx) error {
+		for _, node := range []*Node{{ID: 1, NextID: 2}, {ID: 2, NextID: 1}} {
+			if _, err := tx.NewInsert().Model(node).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = conn.RunInTx(ctx, nil, insertNodes)
+	require.Error(t, err)
+
+	err = conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.SetConstraintsDeferred(ctx); err != nil {
+			return err
+		}
+		return insertNodes(ctx, tx)
+	})
+	require.NoError(t, err)
+
+	count, err := db.NewSelect().Model((*Node)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func testWarmup(t *testing.T, db *bun.DB) {
+	maxOpen := db.DB.Stats().MaxOpenConnections
+	db.DB.SetMaxOpenConns(2)
+	db.DB.SetMaxIdleConns(2)
+	t.Cleanup(func() {
+		db.DB.SetMaxOpenConns(maxOpen)
+		// The test DBs use the database/sql default of 2 idle connections.
+		db.DB.SetMaxIdleConns(2)
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, db.Warmup(ctx, 0))
+	require.NoError(t, db.Warmup(ctx, -1))
+
+	// Asking for more connections than the pool allows must not block.
+	err := db.Warmup(ctx, 5)
+	require.NoError(t, err)
+
+	stats := db.DB.Stats()
+	require.Equal(t, 2, stats.OpenConnections)
+	require.Equal(t, 2, stats.Idle)
+}
+
+func testTimestamps(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID        int64 `bun:",pk,autoincrement"`
+		Name      string
+		CreatedAt time.Time    `bun:",created_at"`
+		UpdatedAt sql.NullTime `bun:",updated_at"`
+	}
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	model := &Model{Name: "hello"}
+	_, err := db.NewInsert().Model(model).Exec(ctx)
+	require.NoError(t, err)
+	require.False(t, model.CreatedAt.IsZero())
+	require.True(t, model.UpdatedAt.Valid)
+	require.Equal(t, model.CreatedAt, model.UpdatedAt.Time)
+
+	createdAt := model.CreatedAt
+	time.Sleep(10 * time.Millisecond)
+
+	model.Name = "world"
+	_, err = db.NewUpdate().Model(model).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, createdAt, model.CreatedAt)
+	require.True(t, model.UpdatedAt.Time.After(createdAt))
+
+	explicit := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	models := []Model{
+		{Name: "explicit", CreatedAt: explicit, UpdatedAt: sql.NullTime{Time: explicit, Valid: true}},
+		{Name: "auto"},
+	}
+	_, err = db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, explicit, models[0].CreatedAt)
+	require.Equal(t, explicit, models[0].UpdatedAt.Time)
+	require.True(t, models[1].CreatedAt.After(explicit))
+
+	got := new(Model)
+	err = db.NewSelect().Model(got).Where("name = ?", "explicit").Scan(ctx)
+	require.NoError(t, err)
+	require.True(t, got.CreatedAt.Equal(explicit))
+}
+
+func testSelectDiscardUnknownColumns(t *testing.T, db *bun.DB) {
+	type Model struct {
+		Num int
+	}
+
+	model := new(Model)
+	err := db.NewSelect().
+		ColumnExpr("10 AS num, 'hello' AS unknown_column").
+		DiscardUnknownColumns().
+		Scan(ctx, model)
+	require.NoError(t, err)
+	require.Equal(t, 10, model.Num)
+
+	var models []Model
+	err = db.NewSelect().
+		ColumnExpr("20 AS num, 'hello' AS unknown_column").
+		DiscardUnknownColumns().
+		Scan(ctx, &models)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{Num: 20}}, models)
+
+	err = db.NewSelect().ColumnExpr("30 AS num, 'hello' AS unknown_column").Scan(ctx, model)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Model does not have column")
+}
+
+func testCreateTemporaryTable(t *testing.T, db *bun.DB) {
+	if db.Dialect().Name() == dialect.MSSQL || db.Dialect().Name() == dialect.Oracle {
+		t.Skip()
+	}
+
+	type TempModel struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	// Temporary tables are only visible in the session that created them.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.NewCreateTable().Model((*TempModel)(nil)).Temporary().Exec(ctx)
+	require.NoError(t, err)
+	defer func() {
+		_, err := conn.NewDropTable().Model((*TempModel)(nil)).Exec(ctx)
+		require.NoError(t, err)
+	}()
+
+	_, err = conn.NewInsert().Model(&TempModel{Name: "hello"}).Exec(ctx)
+	require.NoError(t, err)
+
+	var models []TempModel
+	err = conn.NewSelect().Model(&models).Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	require.Equal(t, "hello", models[0].Name)
+}
+
+func testAlterColumn(t *testing.T, db *bun.DB) {
+	if db.Dialect().Name() == dialect.SQLite {
+		t.Skip()
+	}
+
+	type AlterColumnModel struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Num int
+		Str *string
+	}
+
+	mustResetModel(t, ctx, db, (*AlterColumnModel)(nil))
+	mustDropTableOnCleanup(t, ctx, db, (*AlterColumnModel)(nil))
+
+	alter := func() *bun.AlterColumnQuery {
+		return db.NewAlterColumn().Model((*AlterColumnModel)(nil)).Column("str")
+	}
+	insert := func(model *AlterColumnModel, columns ...string) error {
+		_, err := db.NewInsert().Model(model).Column(columns...).Exec(ctx)
+		return err
+	}
+
+	_, err := alter().SetDefault("?", "hello").Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, insert(&AlterColumnModel{Num: 1}, "num"))
+	model := new(AlterColumnModel)
+	err = db.NewSelect().Model(model).Where("num = 1").Scan(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, model.Str)
+	require.Equal(t, "hello", *model.Str)
+
+	_, err = alter().SetNotNull().Exec(ctx)
+	require.NoError(t, err)
+	require.Error(t, insert(&AlterColumnModel{Num: 2}, "num", "str"))
+
+	_, err = alter().DropNotNull().Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, insert(&AlterColumnModel{Num: 3}, "num", "str"))
+
+	if db.Dialect().Name() == dialect.MSSQL {
+		// MSSQL can only drop a default by the name of its constraint.
+		return
+	}
+
+	_, err = alter().DropDefault().Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, insert(&AlterColumnModel{Num: 4}, "num"))
+	model = new(AlterColumnModel)
+	err = db.NewSelect().Model(model).Where("num = 4").Scan(ctx)
+	require.NoError(t, err)
+	require.Nil(t, model.Str)
+}
+
+func testScanColumns(t *testing.T, db *bun.DB) {
+	columns, rows, err := db.NewSelect().
+		ColumnExpr("3 AS c").
+		ColumnExpr("1 AS a").
+		ColumnExpr("2 AS b").
+		ScanColumns(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "a", "b"}, columns)
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0], 3)
+	for i, want := range []int64{3, 1, 2} {
+		require.EqualValues(t, want, rows[0][i])
+	}
+
+	columns, rows, err = db.NewRaw("SELECT 2 AS b, 1 AS a").ScanColumns(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, columns)
+	require.Len(t, rows, 1)
+	require.EqualValues(t, 2, rows[0][0])
+	require.EqualValues(t, 1, rows[0][1])
+}
+
+func testRawColumns(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	columns, err := db.NewRaw("SELECT id, str FROM ?", bun.Ident("models")).Columns(ctx)
+	require.NoError(t, err)
+	require.Len(t, columns, 2)
+	require.Equal(t, "id", columns[0].Name)
+	require.Equal(t, "str", columns[1].Name)
+	require.NotEmpty(t, columns[0].DatabaseType)
+}
+
+func testQueryMetadata(t *testing.T, db *bun.DB) {
+	type Profile struct {
+		ID     int64 `bun:",pk"`
+		UserID int64
+	}
+
+	type User struct {
+		ID      int64 `bun:",pk"`
+		Name    string
+		Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+	}
+
+	md, err := db.NewSelect().
+		Model((*User)(nil)).
+		Column("id", "name").
+		ColumnExpr("lower(name) AS lower_name").
+		Relation("Profile").
+		Where("length(name) > ?", 3).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("id > ?", 1).WhereOr("id < ?", 0)
+		}).
+		Metadata()
+	require.NoError(t, err)
+	require.Equal(t, "users", md.Table.Name)
+	require.Equal(t, []string{"id", "name", "lower(name) AS lower_name"}, md.Columns)
+	require.Equal(t, []string{"Profile"}, md.Relations)
+	require.Equal(t, []bun.WhereCondition{
+		{Query: "length(name) > 3", Opaque: true},
+		{Sep: "AND", Group: []bun.WhereCondition{
+			{Query: "id > 1", Opaque: true},
+			{Sep: "OR", Query: "id < 0", Opaque: true},
+		}},
+	}, md.Where)
+	require.Empty(t, md.WhereColumns)
+	require.True(t, md.WhereOpaque)
+
+	md, err = db.NewSelect().
+		Model((*User)(nil)).
+		WhereDistinctFrom("name", "x").
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.WhereILike("email", "a%").
+				WhereGroup(" AND NOT ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.Where("deleted = ?", true)
+				})
+		}).
+		Metadata()
+	require.NoError(t, err)
+	require.Len(t, md.Where, 2)
+	require.Equal(t, []string{"name"}, md.Where[0].Columns)
+	require.False(t, md.Where[0].Opaque)
+	require.Equal(t, "OR", md.Where[1].Sep)
+	require.Len(t, md.Where[1].Group, 2)
+	require.Equal(t, []string{"email"}, md.Where[1].Group[0].Columns)
+	require.Equal(t, "AND NOT", md.Where[1].Group[1].Sep)
+	require.True(t, md.Where[1].Group[1].Group[0].Opaque)
+	require.Empty(t, md.Where[1].Group[1].Group[0].Columns)
+	require.Equal(t, []string{"name", "email"}, md.WhereColumns)
+	require.True(t, md.WhereOpaque)
+
+	user := &User{ID: 1}
+	md, err = db.NewDelete().Model(user).WherePK().Metadata()
+	require.NoError(t, err)
+	require.Equal(t, []string{"id"}, md.WhereColumns)
+	require.False(t, md.WhereOpaque)
+	require.Empty(t, md.Where)
+
+	md, err = db.NewSelect().Table("users").Metadata()
+	require.NoError(t, err)
+	require.Nil(t, md.Table)
+	require.Equal(t, []string{"users"}, md.Tables)
+}
+
+func testValuesColumnNames(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.CTE) {
+		t.Skip()
+	}
+
+	type Model struct {
+		ID  int64
+		Str string
+	}
+
+	values := db.NewValues(&[]Model{{1, "one"}, {2, "two"}}).
+		ColumnNames("id", "str").
+		TableAlias("t").
+		WithOrder()
+
+	var models []Model
+	err := db.NewSelect().
+		TableExpr("?", values).
+		Column("t.id", "t.str").
+		OrderExpr("t._order DESC").
+		Scan(ctx, &models)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{2, "two"}, {1, "one"}}, models)
+}
+
+func testValuesArg(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.CTE) || db.Dialect().Name() == dialect.MSSQL {
+		t.Skip()
+	}
+
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{1, "one"}, {2, "two"}, {3, "three"}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	filter := []Model{{2, "two"}, {3, "three"}, {4, "four"}}
+
+	var got []Model
+	err = db.NewSelect().
+		Model(&got).
+		Where("(id, str) IN ?", bun.Values(&filter)).
+		Where("id < ?", 4).
+		OrderExpr("id ASC").
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{2, "two"}, {3, "three"}}, got)
+}
+
+func testScanPositional(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+		Num int
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{Str: "one", Num: 1}, {Str: "two", Num: 2}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	var got []Model
+	err = db.NewSelect().Model(&got).OrderExpr("id ASC").ScanPositional(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{1, "one", 1}, {2, "two", 2}}, got)
+
+	model := new(Model)
+	err = db.NewSelect().Model(model).Where("id = ?", 2).ScanPositional(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Model{2, "two", 2}, model)
+
+	err = db.NewSelect().Model(model).Column("id").Where("id = ?", 2).ScanPositional(ctx)
+	require.Error(t, err)
+
+	var num int
+	err = db.NewSelect().Model((*Model)(nil)).Column("num").Limit(1).ScanPositional(ctx, &num)
+	require.Error(t, err)
+}
+
+func testInsertDefaultValues(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64  `bun:",pk,autoincrement"`
+		Str string `bun:",default:'hello'"`
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	for i := 0; i < 2; i++ {
+		model := &Model{Str: "ignored"}
+		_, err := db.NewInsert().Model(model).DefaultValues().Exec(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(i+1), model.ID)
+	}
+
+	var models []Model
+	err := db.NewSelect().Model(&models).OrderExpr("id ASC").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{1, "hello"}, {2, "hello"}}, models)
+}
+
+func testSelectWindow(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.WindowClause) {
+		t.Skip()
+	}
+
+	type Model struct {
+		ID       int64 `bun:",pk,autoincrement"`
+		Category string
+		Price    int
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{
+		{Category: "a", Price: 10},
+		{Category: "a", Price: 20},
+		{Category: "b", Price: 30},
+	}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	var rows []struct {
+		ID    int64
+		Rank  int
+		Total int
+	}
+	err = db.NewSelect().
+		Model((*Model)(nil)).
+		Column("id").
+		ColumnExpr("row_number() OVER w AS rank").
+		ColumnExpr("sum(price) OVER w AS total").
+		Window("w", "PARTITION BY category ORDER BY price").
+		OrderExpr("id ASC").
+		Scan(ctx, &rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, []int{1, 2, 1}, []int{rows[0].Rank, rows[1].Rank, rows[2].Rank})
+	require.Equal(t, []int{10, 30, 30}, []int{rows[0].Total, rows[1].Total, rows[2].Total})
+}
+
+func testSelectMaxRows(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{Str: "one"}, {Str: "two"}, {Str: "three"}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	var got []Model
+	err = db.NewSelect().Model(&got).MaxRows(2).Scan(ctx)
+	require.Error(t, err)
+	require.Equal(t, "bun: query returned more than 2 rows (MaxRows)", err.Error())
+
+	var ids []int64
+	err = db.NewSelect().Model((*Model)(nil)).Column("id").MaxRows(2).Scan(ctx, &ids)
+	require.Error(t, err)
+
+	var maps []map[string]interface{}
+	err = db.NewSelect().Model((*Model)(nil)).MaxRows(2).Scan(ctx, &maps)
+	require.Error(t, err)
+
+	err = db.NewSelect().Model(&got).MaxRows(3).Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+}
+
+func testInsertBatchSize(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{Str: "1"}, {Str: "2"}, {Str: "3"}, {Str: "4"}, {Str: "5"}}
+	res, err := db.NewInsert().Model(&models).BatchSize(2).Exec(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 5)
+
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), n)
+
+	var got []Model
+	err = db.NewSelect().Model(&got).Order("id").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, models, got)
+
+	ptrs := []*Model{{Str: "6"}, {Str: "7"}, {Str: "8"}}
+	_, err = db.NewInsert().Model(&ptrs).BatchSize(2).Exec(ctx)
+	require.NoError(t, err)
+	for _, m := range ptrs {
+		require.NotZero(t, m.ID)
+	}
+
+	count, err := db.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 8, count)
+
+	// Batches inserted on a connection are rolled back together, too.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dups := []Model{{ID: 100, Str: "9"}, {ID: 101, Str: "10"}, {ID: 102, Str: "11"}, {ID: 102, Str: "12"}}
+	_, err = conn.NewInsert().Model(&dups).BatchSize(2).Exec(ctx)
+	require.Error(t, err, "the second batch violates the primary key")
+
+	count, err = conn.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 8, count)
+}
+
+func testInsertSelectReturning(t *testing.T, db *bun.DB) {
+	if db.Dialect().Name() != dialect.MySQL || db.HasFeature(feature.InsertReturning) {
+		t.Skip("RETURNING is emulated only on MySQL")
+	}
+
+	type Model struct {
+		ID     int64 `bun:",pk,autoincrement"`
+		Name   string
+		Status string `bun:",notnull,default:'new'"`
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	model := &Model{Name: "hello"}
+	_, err := db.NewInsert().Model(model).Returning("status").Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), model.ID)
+	require.Equal(t, "new", model.Status)
+
+	model = &Model{Name: "world"}
+	_, err = db.NewInsert().Model(model).Returning("NULL").Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), model.ID)
+	require.Equal(t, "", model.Status)
+
+	models := []Model{{Name: "foo"}, {Name: "bar"}}
+	_, err = db.NewInsert().Model(&models).Returning("status").Exec(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "supports Returning only when inserting a single struct")
+
+	count, err := db.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func testCompiledQuery(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	model := &Model{ID: 1, Name: "hello"}
+	insert := db.NewInsert().Model(model)
+	require.Empty(t, insert.CompiledQuery())
+	want := insert.String()
+	_, err := insert.Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, want, insert.CompiledQuery())
+
+	var name string
+	sel := db.NewSelect().Model((*Model)(nil)).Column("name").Where("id = ?", 1)
+	err = sel.Scan(ctx, &name)
+	require.NoError(t, err)
+	require.Equal(t, "hello", name)
+	require.Equal(t, sel.String(), sel.CompiledQuery())
+
+	count, err := sel.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Contains(t, sel.CompiledQuery(), "count(*)")
+}
+
+func testQueryReset(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// Reset keeps the query bound to the transaction.
+	insert := tx.NewInsert().Model(&Model{Name: "discarded"}).Reset()
+	_, err = insert.Model(&Model{Name: "in tx"}).Exec(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, insert.CompiledQuery())
+
+	var names []string
+	sel := tx.NewSelect().Model((*Model)(nil)).Column("name")
+	require.NoError(t, sel.Scan(ctx, &names))
+	require.Equal(t, []string{"in tx"}, names)
+
+	sel.Reset()
+	require.Empty(t, sel.CompiledQuery())
+
+	require.NoError(t, tx.Rollback())
+
+	count, err := db.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func testRawScanMulti(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk"`
+		Name string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{ID: 1, Name: "hello"}, {ID: 2, Name: "world"}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	var query string
+	switch db.Dialect().Name() {
+	case dialect.MySQL:
+		_, err = db.ExecContext(ctx, "DROP PROCEDURE IF EXISTS scan_multi")
+		require.NoError(t, err)
+		_, err = db.ExecContext(ctx, "CREATE PROCEDURE scan_multi() "+
+			"BEGIN SELECT id, name FROM models ORDER BY id; SELECT count(*) FROM models; END")
+		require.NoError(t, err)
+		query = "CALL scan_multi()"
+		t.Cleanup(func() { _, _ = db.ExecContext(ctx, "DROP PROCEDURE scan_multi") })
+	case dialect.MSSQL:
+		_, err = db.ExecContext(ctx, "CREATE OR ALTER PROCEDURE scan_multi AS "+
+			"BEGIN SELECT id, name FROM models ORDER BY id; SELECT count(*) FROM models; END")
+		require.NoError(t, err)
+		query = "EXEC scan_multi"
+		t.Cleanup(func() { _, _ = db.ExecContext(ctx, "DROP PROCEDURE scan_multi") })
+	default:
+		var got []Model
+		err := db.NewRaw("SELECT id, name FROM models ORDER BY id").ScanMulti(ctx, &got)
+		require.NoError(t, err)
+		require.Equal(t, models, got)
+
+		var count int
+		err = db.NewRaw("SELECT id, name FROM models ORDER BY id").ScanMulti(ctx, &got, &count)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "returned 1 result sets")
+		return
+	}
+
+	var got []Model
+	var count int
+	err = db.NewRaw(query).ScanMulti(ctx, &got, &count)
+	require.NoError(t, err)
+	require.Equal(t, models, got)
+	require.Equal(t, 2, count)
 }
 
-func testSelectScan(t *testing.T, db *bun.DB) {
-	var num int
-	err := db.NewSelect().ColumnExpr("10").Scan(ctx, &num)
+func testWhereDistinctFrom(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk"`
+		Name *string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	a, b := "a", "b"
+	models := []Model{{ID: 1, Name: &a}, {ID: 2, Name: &b}, {ID: 3}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
 	require.NoError(t, err)
-	require.Equal(t, 10, num)
 
-	err = db.NewSelect().
-		ColumnExpr("t.num").
-		TableExpr("(SELECT 10 AS num) AS t").
-		Where("1 = 2").
-		Scan(ctx, &num)
-	require.Equal(t, sql.ErrNoRows, err)
+	selectIDs := func(q *bun.SelectQuery) []int64 {
+		var ids []int64
+		err := q.Model((*Model)(nil)).Column("id").Order("id").Scan(ctx, &ids)
+		require.NoError(t, err)
+		return ids
+	}
 
-	var str string
-	err = db.NewSelect().ColumnExpr("?", "\\\"'hello\n%_").Scan(ctx, &str)
+	require.Equal(t, []int64{2, 3}, selectIDs(db.NewSelect().WhereDistinctFrom("name", "a")))
+	require.Equal(t, []int64{1, 2}, selectIDs(db.NewSelect().WhereDistinctFrom("name", nil)))
+	require.Equal(t, []int64{1}, selectIDs(db.NewSelect().WhereNotDistinctFrom("name", "a")))
+	require.Equal(t, []int64{3}, selectIDs(db.NewSelect().WhereNotDistinctFrom("name", nil)))
+
+	res, err := db.NewUpdate().
+		Model((*Model)(nil)).
+		Set("name = ?", "a").
+		WhereDistinctFrom("name", "a").
+		Exec(ctx)
 	require.NoError(t, err)
-	require.Equal(t, "\\\"'hello\n%_", str)
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
 }
 
-func testSelectCount(t *testing.T, db *bun.DB) {
-	if !db.Dialect().Features().Has(feature.CTE) {
-		t.Skip()
-		return
+func testWhereILikeEscape(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID  int64 `bun:",pk"`
+		Str string
 	}
 
-	values := db.NewValues(&[]map[string]interface{}{
-		{"num": 1},
-		{"num": 2},
-		{"num": 3},
-	})
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
 
-	q := db.NewSelect().
-		With("t", values).
-		Column("t.num").
-		TableExpr("t").
-		OrderExpr("t.num DESC").
-		Limit(1)
+	models := []Model{
+		{ID: 1, Str: `50%_OFF\[x]`},
+		{ID: 2, Str: "500 off x"},
+		{ID: 3, Str: "50%_off\\y"},
+	}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
 
-	var num int
-	err := q.Scan(ctx, &num)
+	var ids []int64
+	err = db.NewSelect().
+		Model((*Model)(nil)).
+		Column("id").
+		WhereILike("str", "%"+bun.EscapeLike(`50%_off\[x]`)+"%").
+		Scan(ctx, &ids)
 	require.NoError(t, err)
-	require.Equal(t, 3, num)
+	require.Equal(t, []int64{1}, ids)
+}
 
-	count, err := q.Count(ctx)
+func testUpdateFromReturning(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.Returning) {
+		t.Skip("dialect does not support RETURNING")
+	}
+
+	type Price struct {
+		ID    int64 `bun:",pk"`
+		Price float64
+	}
+	type Product struct {
+		ID    int64 `bun:",pk"`
+		Price float64
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Product)(nil), (*Price)(nil))
+
+	_, err := db.NewInsert().Model(&[]Product{{ID: 1}, {ID: 2}}).Exec(ctx)
 	require.NoError(t, err)
-	require.Equal(t, 3, count)
+	_, err = db.NewInsert().Model(&Price{ID: 1, Price: 9.5}).Exec(ctx)
+	require.NoError(t, err)
+
+	// Both tables have the id and price columns.
+	var products []Product
+	err = db.NewUpdate().
+		Model((*Product)(nil)).
+		From("prices AS p").
+		Set("price = p.price").
+		Where("p.id = ?TableAlias.id").
+		ReturningModel().
+		Scan(ctx, &products)
+	require.NoError(t, err)
+	require.Equal(t, []Product{{ID: 1, Price: 9.5}}, products)
 }
 
-func testSelectMap(t *testing.T, db *bun.DB) {
-	var m map[string]interface{}
-	err := db.NewSelect().
-		ColumnExpr("10 AS num").
-		Scan(ctx, &m)
+func testScanChan(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk"`
+		Name string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
 	require.NoError(t, err)
-	switch v := m["num"]; v.(type) {
-	case int32:
-		require.Equal(t, int32(10), v)
-	case int64:
-		require.Equal(t, int64(10), v)
-	default:
-		t.Fail()
+
+	ch, errc := bun.ScanChan[Model](ctx, db.NewSelect().Model((*Model)(nil)).Order("id"))
+	var got []Model
+	for model := range ch {
+		got = append(got, *model)
+	}
+	require.NoError(t, <-errc)
+	require.Equal(t, models, got)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	ch, errc = bun.ScanChan[Model](cancelCtx, db.NewSelect().Model((*Model)(nil)).Order("id"))
+	require.Equal(t, &models[0], <-ch)
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+	for range ch {
 	}
+
+	_, errc = bun.ScanChan[Model](ctx, db.NewSelect().Model((*Model)(nil)).Where("unknown_column = 1"))
+	require.Error(t, <-errc)
 }
 
-func testSelectMapSlice(t *testing.T, db *bun.DB) {
-	if !db.Dialect().Features().Has(feature.CTE) {
-		t.Skip()
+func testScanNestedColumnExpr(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk"`
+		Name string
 	}
 
-	values := db.NewValues(&[]map[string]interface{}{
-		{"column1": 1},
-		{"column1": 2},
-		{"column1": 3},
-	})
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
 
-	var ms []map[string]interface{}
-	err := db.NewSelect().
-		With("t", values).
-		TableExpr("t").
-		Scan(ctx, &ms)
+	models := []Model{{ID: 1, Name: "a"}, {ID: 2, Name: "a"}, {ID: 3, Name: "b"}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
 	require.NoError(t, err)
-	require.Len(t, ms, 3)
-	for i, m := range ms {
-		require.Equal(t, map[string]interface{}{
-			"column1": int64(i + 1),
-		}, m)
+
+	type Last struct {
+		ID int64
+	}
+	type Stats struct {
+		Total int
+		Last  Last
+		Max   *Last
+	}
+
+	var dest struct {
+		Stats Stats
+		Ptr   *Stats
+	}
+	err = db.NewSelect().
+		Model((*Model)(nil)).
+		ColumnExpr("count(*) AS stats__total").
+		ColumnExpr("max(id) AS stats__last__id").
+		ColumnExpr("max(id) AS stats__max__id").
+		ColumnExpr("min(id) AS ptr__last__id").
+		ColumnExpr("NULL AS ptr__max__id").
+		Scan(ctx, &dest)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Total: 3, Last: Last{ID: 3}, Max: &Last{ID: 3}}, dest.Stats)
+	require.Equal(t, &Stats{Last: Last{ID: 1}}, dest.Ptr)
+
+	var groups []struct {
+		Name  string
+		Stats struct {
+			Total int
+			Last  Last
+		}
+	}
+	err = db.NewSelect().
+		Model((*Model)(nil)).
+		Column("name").
+		ColumnExpr("count(*) AS stats__total").
+		ColumnExpr("max(id) AS stats__last__id").
+		Group("name").
+		Order("name").
+		Scan(ctx, &groups)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, "a", groups[0].Name)
+	require.Equal(t, 2, groups[0].Stats.Total)
+	require.Equal(t, int64(2), groups[0].Stats.Last.ID)
+	require.Equal(t, "b", groups[1].Name)
+	require.Equal(t, 1, groups[1].Stats.Total)
+	require.Equal(t, int64(3), groups[1].Stats.Last.ID)
+}
+
+func testInsertOnConflictDoUpdateWhere(t *testing.T, db *bun.DB) {
+	if !db.HasFeature(feature.InsertOnConflict) {
+		t.Skip("ON CONFLICT is not supported")
 	}
+
+	type Model struct {
+		ID   int64  `bun:",pk"`
+		Name string `bun:",nullzero"`
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := []Model{{ID: 1, Name: "hello"}, {ID: 2}}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	models = []Model{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}
+	_, err = db.NewInsert().
+		Model(&models).
+		OnConflict("id").
+		DoUpdate().
+		Set("name = EXCLUDED.name").
+		Where("model.name IS NULL").
+		Exec(ctx)
+	require.NoError(t, err)
+
+	var got []Model
+	err = db.NewSelect().Model(&got).Order("id").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Model{{ID: 1, Name: "hello"}, {ID: 2, Name: "bar"}}, got)
+}
+
+func testUpdateSetMap(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID    int64 `bun:",pk,autoincrement"`
+		Name  string
+		Count int
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	model := &Model{Name: "hello", Count: 1}
+	_, err := db.NewInsert().Model(model).Exec(ctx)
+	require.NoError(t, err)
+
+	changes := map[string]interface{}{"count": 42}
+	_, err = db.NewUpdate().Model(model).SetMap(changes).WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	got := &Model{ID: model.ID}
+	err = db.NewSelect().Model(got).WherePK().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Model{ID: model.ID, Name: "hello", Count: 42}, got)
+
+	changes = map[string]interface{}{"unknown": 1}
+	_, err = db.NewUpdate().Model(model).SetMap(changes).WherePK().Exec(ctx)
+	require.Error(t, err)
 }
 
 func testSelectStruct(t *testing.T, db *bun.DB) {
@@ -621,6 +1760,49 @@ func testScanNullVar(t *testing.T, db *bun.DB) {
 	require.Zero(t, num)
 }
 
+func testStrictNullScan(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID    int64 `bun:",pk,autoincrement"`
+		Name  string
+		Count int `bun:",nullzero"`
+		Note  *string
+	}
+
+	ctx := context.Background()
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+	_, err := db.NewInsert().Model(&Model{}).Value("name", "NULL").Exec(ctx)
+	require.NoError(t, err)
+
+	// NULLs are scanned as zero values by default.
+	model := &Model{Name: "hello"}
+	err = db.NewSelect().Model(model).Limit(1).Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "", model.Name)
+
+	strict := bun.NewDB(db.DB, db.Dialect(), bun.WithStrictNullScan())
+
+	err = strict.NewSelect().Model(new(Model)).Limit(1).Scan(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't scan NULL into Model.Name")
+
+	model = new(Model)
+	err = strict.NewSelect().Model(model).Column("id", "count", "note").Limit(1).Scan(ctx)
+	require.NoError(t, err)
+	require.Zero(t, model.Count)
+	require.Nil(t, model.Note)
+
+	num := 42
+	err = strict.NewSelect().ColumnExpr("NULL").Scan(ctx, &num)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't scan NULL into int")
+
+	var nullNum sql.NullInt64
+	err = strict.NewSelect().ColumnExpr("NULL").Scan(ctx, &nullNum)
+	require.NoError(t, err)
+	require.False(t, nullNum.Valid)
+}
+
 func testScanSingleRow(t *testing.T, db *bun.DB) {
 	rows, err := db.QueryContext(ctx, "SELECT 42")
 	require.NoError(t, err)
@@ -831,6 +2013,64 @@ func testJSONValuer(t *testing.T, db *bun.DB) {
 	require.Equal(t, `"driver.Value"`, model2.Value.str)
 }
 
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	R float64
+}
+
+func (c *Circle) Area() float64 { return 3 * c.R * c.R }
+
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+func testScanPolymorphic(t *testing.T, db *bun.DB) {
+	type Figure struct {
+		ID    int64 `bun:",pk,autoincrement"`
+		Kind  string
+		Shape Shape `bun:"type:json,discriminator:kind"`
+	}
+
+	ctx := context.Background()
+
+	// Register the types on a separate DB so they don't leak into the other tests.
+	db = bun.NewDB(db.DB, db.Dialect())
+	db.RegisterPolymorphic("kind", map[string]reflect.Type{
+		"circle": reflect.TypeFor[*Circle](),
+		"square": reflect.TypeFor[Square](),
+	})
+	mustResetModel(t, ctx, db, (*Figure)(nil))
+
+	figures := []Figure{
+		{Kind: "circle", Shape: &Circle{R: 2}},
+		{Kind: "square", Shape: Square{Side: 3}},
+		{Kind: "circle"},
+	}
+	_, err := db.NewInsert().Model(&figures).Exec(ctx)
+	require.NoError(t, err)
+
+	var got []Figure
+	err = db.NewSelect().Model(&got).Order("id").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, &Circle{R: 2}, got[0].Shape)
+	require.Equal(t, Square{Side: 3}, got[1].Shape)
+	require.Nil(t, got[2].Shape)
+
+	_, err = db.NewInsert().Model(&Figure{Kind: "triangle", Shape: Square{Side: 1}}).Exec(ctx)
+	require.NoError(t, err)
+
+	figure := new(Figure)
+	err = db.NewSelect().Model(figure).Where("kind = ?", "triangle").Scan(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no type is registered for kind="triangle"`)
+}
+
 func testSelectBool(t *testing.T, db *bun.DB) {
 	var flag bool
 	err := db.NewSelect().ColumnExpr("1").Scan(ctx, &flag)
@@ -959,42 +2199,153 @@ func testWithForeignKeysAndRules(t *testing.T, db *bun.DB) {
 	_, err = db.NewInsert().Model(&decks).Exec(ctx)
 	require.Error(t, err)
 
-	n, err := db.NewSelect().Model((*Deck)(nil)).Count(ctx)
-	require.NoError(t, err)
-	require.Equal(t, 0, n)
+	n, err := db.NewSelect().Model((*Deck)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	_, err = db.NewInsert().Model(&User{ID: 1, Type: "admin", Name: "root"}).Exec(ctx)
+	require.NoError(t, err)
+	res, err := db.NewInsert().Model(&Deck{UserID: 1, UserType: "admin"}).Exec(ctx)
+	require.NoError(t, err)
+
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), affected)
+
+	// Update User ID and check for FK update
+	res, err = db.NewUpdate().Model(&User{}).Where("id = ?", 1).Where("type = ?", "admin").Set("id = ?", 2).Exec(ctx)
+	require.NoError(t, err)
+
+	affected, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), affected)
+
+	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 1").Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 2").Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	// Delete user and check for FK delete
+	_, err = db.NewDelete().Model(&User{}).Where("id = ?", 2).Exec(ctx)
+	require.NoError(t, err)
+
+	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 2").Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
+
+func testFixtureTruncateTables(t *testing.T, db *bun.DB) {
+	type FixtureAuthor struct {
+		ID   int64 `bun:",pk"`
+		Name string
+	}
+	type FixtureArticle struct {
+		ID       int64 `bun:",pk"`
+		Title    string
+		AuthorID int64
+		Author   *FixtureAuthor `bun:"rel:belongs-to,join:author_id=id"`
+	}
+
+	if db.Dialect().Name() == dialect.SQLite {
+		_, err := db.Exec("PRAGMA foreign_keys = ON;")
+		require.NoError(t, err)
+	}
+
+	for _, model := range []interface{}{(*FixtureArticle)(nil), (*FixtureAuthor)(nil)} {
+		_, err := db.NewDropTable().Model(model).IfExists().Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	mustResetModel(t, ctx, db, (*FixtureAuthor)(nil))
 
-	_, err = db.NewInsert().Model(&User{ID: 1, Type: "admin", Name: "root"}).Exec(ctx)
-	require.NoError(t, err)
-	res, err := db.NewInsert().Model(&Deck{UserID: 1, UserType: "admin"}).Exec(ctx)
+	_, err := db.NewCreateTable().
+		Model((*FixtureArticle)(nil)).
+		WithForeignKeys().
+		Exec(ctx)
 	require.NoError(t, err)
+	mustDropTableOnCleanup(t, ctx, db, (*FixtureArticle)(nil))
 
-	affected, err := res.RowsAffected()
-	require.NoError(t, err)
-	require.Equal(t, int64(1), affected)
+	db.RegisterModel((*FixtureAuthor)(nil), (*FixtureArticle)(nil))
 
-	// Update User ID and check for FK update
-	res, err = db.NewUpdate().Model(&User{}).Where("id = ?", 1).Where("type = ?", "admin").Set("id = ?", 2).Exec(ctx)
-	require.NoError(t, err)
+	fsys := fstest.MapFS{
+		"fixture.yaml": {Data: []byte(`
+- model: FixtureAuthor
+  rows:
+    - id: 1
+      name: author 1
+- model: FixtureArticle
+  rows:
+    - id: 1
+      title: article 1
+      author_id: 1
+`)},
+	}
 
-	affected, err = res.RowsAffected()
-	require.NoError(t, err)
-	require.Equal(t, int64(1), affected)
+	// Loading twice requires truncating articles before authors.
+	for i := 0; i < 2; i++ {
+		fixture := dbfixture.New(db, dbfixture.WithTruncateTables())
+		err := fixture.Load(ctx, fsys, "fixture.yaml")
+		require.NoError(t, err)
+	}
 
-	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 1").Count(ctx)
+	n, err := db.NewSelect().Model((*FixtureAuthor)(nil)).Count(ctx)
 	require.NoError(t, err)
-	require.Equal(t, 0, n)
+	require.Equal(t, 1, n)
 
-	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 2").Count(ctx)
+	n, err = db.NewSelect().Model((*FixtureArticle)(nil)).Count(ctx)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
+}
 
-	// Delete user and check for FK delete
-	_, err = db.NewDelete().Model(&User{}).Where("id = ?", 2).Exec(ctx)
-	require.NoError(t, err)
+func testFixtureErrors(t *testing.T, db *bun.DB) {
+	type FixtureUser struct {
+		ID   int64 `bun:",pk"`
+		Name string
+	}
 
-	n, err = db.NewSelect().Model(&Deck{}).Where("user_id = 2").Count(ctx)
-	require.NoError(t, err)
-	require.Equal(t, 0, n)
+	mustResetModel(t, ctx, db, (*FixtureUser)(nil))
+	mustDropTableOnCleanup(t, ctx, db, (*FixtureUser)(nil))
+	db.RegisterModel((*FixtureUser)(nil))
+
+	fsys := fstest.MapFS{
+		"fixture.yaml": {Data: []byte(`
+- model: FixtureUser
+  rows:
+    - _id: first
+      id: 1
+      name: first
+    - _id: duplicate
+      id: 1
+      name: duplicate
+`)},
+		"unknown.yaml": {Data: []byte(`
+- model: FixtureUser
+  rows:
+    - id: 2
+    - id: 3
+      email: user@example.com
+`)},
+	}
+
+	err := dbfixture.New(db).Load(ctx, fsys, "fixture.yaml")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `dbfixture: model=FixtureUser row="duplicate": `)
+
+	err = dbfixture.New(db).Load(ctx, fsys, "unknown.yaml")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `dbfixture: model=FixtureUser row=#2: `)
+
+	err = dbfixture.New(db, dbfixture.WithTruncateTables(), dbfixture.WithTimeout(time.Nanosecond)).
+		Load(ctx, fsys, "fixture.yaml")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = dbfixture.New(db, dbfixture.WithTruncateTables(), dbfixture.WithMaxRows(3)).
+		Load(ctx, fsys, "fixture.yaml", "unknown.yaml")
+	require.Error(t, err)
+	require.Equal(t, "dbfixture: fixtures have 4 rows, which exceeds the limit of 3", err.Error())
 }
 
 func testWithForeignKeys(t *testing.T, db *bun.DB) {
@@ -1352,6 +2703,11 @@ func testModelNonPointer(t *testing.T, db *bun.DB) {
 	_, err := db.NewInsert().Model(Model{}).ExcludeColumn("id").Returning("id").Exec(ctx)
 	require.Error(t, err)
 	require.Equal(t, "bun: Model(non-pointer dbtest_test.Model)", err.Error())
+	require.ErrorIs(t, err, bun.ErrModelNotPointer)
+
+	var num int
+	err = db.NewSelect().ColumnExpr("1").Scan(ctx, num, &num)
+	require.ErrorIs(t, err, bun.ErrModelNotPointer)
 }
 
 func testBinaryData(t *testing.T, db *bun.DB) {
@@ -1477,6 +2833,54 @@ func testUpdateWithSkipupdateTag(t *testing.T, db *bun.DB) {
 	require.NotEqual(t, model.CreatedAt.UTC(), model_.CreatedAt.UTC())
 }
 
+func testUpdateReturningSlice(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.Returning) {
+		t.Skip()
+		return
+	}
+
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	_, err := db.NewInsert().
+		Model(&[]Model{{Str: "a"}, {Str: "b"}, {Str: "c"}}).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	var models []Model
+	res, err := db.NewUpdate().
+		Model(&models).
+		Set("str = ?", "updated").
+		Where("id > ?", 1).
+		Returning("*").
+		Exec(ctx)
+	require.NoError(t, err)
+
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.ElementsMatch(t, []Model{{ID: 2, Str: "updated"}, {ID: 3, Str: "updated"}}, models)
+
+	var dest []Model
+	res, err = db.NewUpdate().
+		Model((*Model)(nil)).
+		Set("str = ?", "dest").
+		Where("id < ?", 3).
+		Returning("*").
+		Exec(ctx, &dest)
+	require.NoError(t, err)
+
+	n, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.ElementsMatch(t, []Model{{ID: 1, Str: "dest"}, {ID: 2, Str: "dest"}}, dest)
+}
+
 func testScanAndCount(t *testing.T, db *bun.DB) {
 	type Model struct {
 		ID  int64 `bun:",pk,autoincrement"`
@@ -1695,6 +3099,220 @@ func testNilDriverValue(t *testing.T, db *bun.DB) {
 	require.NoError(t, err)
 }
 
+type sqlStateError string
+
+func (err sqlStateError) Error() string    { return "SQLSTATE " + string(err) }
+func (err sqlStateError) SQLState() string { return string(err) }
+
+func testRunInTxRetry(t *testing.T, db *bun.DB) {
+	var attempts int
+	err := db.RunInTxRetry(ctx, nil, 3, func(ctx context.Context, tx bun.Tx) error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("update failed: %w", sqlStateError("40001"))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = db.RunInTxRetry(ctx, nil, 2, func(ctx context.Context, tx bun.Tx) error {
+		attempts++
+		return sqlStateError("40P01")
+	})
+	require.Equal(t, sqlStateError("40P01"), err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = db.RunInTxRetry(ctx, nil, 3, func(ctx context.Context, tx bun.Tx) error {
+		attempts++
+		return sqlStateError("23505")
+	})
+	require.Equal(t, sqlStateError("23505"), err)
+	require.Equal(t, 1, attempts)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	attempts = 0
+	err = db.RunInTxRetry(cancelCtx, nil, 3, func(ctx context.Context, tx bun.Tx) error {
+		attempts++
+		cancel()
+		return sqlStateError("40001")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, attempts)
+}
+
+func testContextErrors(t *testing.T, db *bun.DB) {
+	stats := db.DBStats()
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err := db.NewSelect().ColumnExpr("1").Exec(canceledCtx)
+	var canceledErr *bun.CanceledError
+	require.ErrorAs(t, err, &canceledErr)
+	require.ErrorIs(t, err, context.Canceled)
+
+	timeoutCtx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	var num int
+	err = db.NewSelect().ColumnExpr("1").Scan(timeoutCtx, &num)
+	var timeoutErr *bun.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = db.ExecContext(timeoutCtx, "SELECT 1")
+	require.ErrorAs(t, err, &timeoutErr)
+
+	// Errors that are not caused by the context are returned as is.
+	_, err = db.ExecContext(canceledErrContext{ctx}, "SELECT * FROM missing_table")
+	require.Error(t, err)
+	require.False(t, errors.As(err, &canceledErr))
+
+	got := db.DBStats()
+	require.Equal(t, stats.Cancellations+1, got.Cancellations)
+	require.Equal(t, stats.Timeouts+2, got.Timeouts)
+	require.Equal(t, stats.Errors+4, got.Errors)
+}
+
+// canceledErrContext reports context.Canceled, but never closes Done,
+// so the query still runs.
+type canceledErrContext struct {
+	context.Context
+}
+
+func (canceledErrContext) Err() error {
+	return context.Canceled
+}
+
+func testOperationStats(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+	stats := db.DBStats()
+
+	model := &Model{Name: "hello"}
+	_, err := db.NewInsert().Model(model).Exec(ctx)
+	require.NoError(t, err)
+
+	err = db.NewSelect().Model(model).WherePK().Scan(ctx)
+	require.NoError(t, err)
+
+	err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model(model).Set("name = ?", "world").WherePK().Exec(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = db.NewDelete().Model(model).WherePK().Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+
+	got := db.DBStats()
+	require.Equal(t, stats.Inserts+1, got.Inserts)
+	require.Equal(t, stats.Selects+1, got.Selects)
+	require.Equal(t, stats.Updates+1, got.Updates)
+	require.Equal(t, stats.Deletes+1, got.Deletes)
+	require.Equal(t, stats.Transactions+1, got.Transactions)
+	require.Equal(t, stats.Queries+7, got.Queries)
+}
+
+func testDeleteChunks(t *testing.T, db *bun.DB) {
+	type Model struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+	}
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	models := make([]Model, 25)
+	for i := range models {
+		models[i].Name = fmt.Sprint(i)
+	}
+	_, err := db.NewInsert().Model(&models).Exec(ctx)
+	require.NoError(t, err)
+
+	deletes := db.DBStats().Deletes
+
+	res, err := db.NewDelete().
+		Model((*Model)(nil)).
+		Where("id > ?", 5).
+		ChunkSize(10).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(20), n)
+	require.Equal(t, deletes+3, db.DBStats().Deletes)
+
+	count, err := db.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+
+	_, err = db.NewDelete().Model((*Model)(nil)).ChunkSize(10).Exec(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "require at least one Where")
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = db.NewDelete().Model((*Model)(nil)).Where("id > 0").ChunkSize(2).Exec(canceledCtx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	count, err = db.NewSelect().Model((*Model)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+}
+
+func testConnSessionVar(t *testing.T, db *bun.DB) {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var name, query string
+	var value interface{}
+	switch db.Dialect().Name() {
+	case dialect.PG:
+		name, value, query = "app.current_user", 42, "SELECT current_setting('app.current_user', true)"
+	case dialect.MySQL:
+		name, value, query = "sql_select_limit", 42, "SELECT @@SESSION.sql_select_limit"
+	default:
+		err := conn.SetSessionVar(ctx, "foo", "bar")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support session variables")
+		return
+	}
+
+	err = conn.SetSessionVar(ctx, "foo; DROP TABLE users", "bar")
+	require.Error(t, err)
+
+	err = conn.SetSessionVar(ctx, name, value)
+	require.NoError(t, err)
+
+	var got string
+	err = conn.QueryRowContext(ctx, query).Scan(&got)
+	require.NoError(t, err)
+	require.Equal(t, "42", got)
+
+	err = conn.ResetSession(ctx)
+	require.NoError(t, err)
+
+	var reset sql.NullString
+	err = conn.QueryRowContext(ctx, query).Scan(&reset)
+	require.NoError(t, err)
+	require.NotEqual(t, "42", reset.String)
+}
+
 func testRunInTxAndSavepoint(t *testing.T, db *bun.DB) {
 	type Counter struct {
 		Count int64
@@ -1830,6 +3448,70 @@ func testNoPanicWhenReturningNullColumns(t *testing.T, db *bun.DB) {
 	})
 }
 
+func testDropTableDisableForeignKeyChecks(t *testing.T, db *bun.DB) {
+	if !db.Dialect().Features().Has(feature.ForeignKeyChecks) {
+		t.Skip()
+		return
+	}
+
+	type Parent struct {
+		ID int64 `bun:",pk,autoincrement"`
+	}
+
+	type Child struct {
+		ID       int64 `bun:",pk,autoincrement"`
+		ParentID int64
+		Parent   *Parent `bun:"rel:belongs-to,join:parent_id=id"`
+	}
+
+	ctx := context.Background()
+
+	mustResetModel(t, ctx, db, (*Parent)(nil))
+	_, err := db.NewCreateTable().
+		Model((*Child)(nil)).
+		WithForeignKeys().
+		Exec(ctx)
+	require.NoError(t, err)
+	mustDropTableOnCleanup(t, ctx, db, (*Child)(nil))
+
+	_, err = db.NewDropTable().Model((*Parent)(nil)).Exec(ctx)
+	require.Error(t, err, "parent table is referenced by a foreign key")
+
+	// FOREIGN_KEY_CHECKS is a session variable, so check it on the same connection.
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.NewDropTable().
+		Model((*Parent)(nil)).
+		DisableForeignKeyChecks().
+		Exec(ctx)
+	require.NoError(t, err)
+
+	// Foreign key checks are enabled again.
+	var checks int
+	err = conn.NewRaw("SELECT @@FOREIGN_KEY_CHECKS").Scan(ctx, &checks)
+	require.NoError(t, err)
+	require.Equal(t, 1, checks)
+
+	// The previous value is restored, so checks disabled by the caller stay disabled.
+	_, err = conn.NewCreateTable().Model((*Parent)(nil)).Exec(ctx)
+	require.NoError(t, err)
+	_, err = conn.NewRaw("SET FOREIGN_KEY_CHECKS = 0").Exec(ctx)
+	require.NoError(t, err)
+	defer conn.NewRaw("SET FOREIGN_KEY_CHECKS = 1").Exec(ctx)
+
+	_, err = conn.NewDropTable().
+		Model((*Parent)(nil)).
+		DisableForeignKeyChecks().
+		Exec(ctx)
+	require.NoError(t, err)
+
+	err = conn.NewRaw("SELECT @@FOREIGN_KEY_CHECKS").Scan(ctx, &checks)
+	require.NoError(t, err)
+	require.Equal(t, 0, checks)
+}
+
 func mustResetModel(tb testing.TB, ctx context.Context, db *bun.DB, models ...interface{}) {
 	err := db.ResetModel(ctx, models...)
 	require.NoError(tb, err, "must reset model")
@@ -1846,6 +3528,71 @@ func mustDropTableOnCleanup(tb testing.TB, ctx context.Context, db *bun.DB, mode
 	})
 }
 
+func TestSetDefaultOptions(t *testing.T) {
+	ctx := context.Background()
+
+	sqldb, err := sql.Open(sqliteshim.DriverName(), filepath.Join(t.TempDir(), "sqlite.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqldb.Close() })
+
+	var dest struct {
+		ID int
+	}
+	scan := func(db *bun.DB) error {
+		return db.NewSelect().ColumnExpr("1 AS id, 2 AS unknown").Scan(ctx, &dest)
+	}
+
+	bun.SetDefaultOptions(bun.WithDiscardUnknownColumns())
+	t.Cleanup(func() { bun.SetDefaultOptions() })
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	require.NoError(t, scan(db))
+	require.Equal(t, 1, dest.ID)
+
+	var applied []string
+	bun.SetDefaultOptions(
+		func(*bun.DB) { applied = append(applied, "default") },
+	)
+	db = bun.NewDB(sqldb, sqlitedialect.New(), func(*bun.DB) { applied = append(applied, "explicit") })
+	require.Equal(t, []string{"default", "explicit"}, applied)
+	require.Error(t, scan(db), "replaced defaults must not be applied")
+}
+
+func TestIdentQuoting(t *testing.T) {
+	type Model struct {
+		bun.BaseModel `bun:"table:quoting_models,alias:m"`
+
+		ID       int64 `bun:",pk,autoincrement"`
+		Name     string
+		Order    int
+		FullName string `bun:"full name"`
+	}
+
+	ctx := context.Background()
+
+	sqldb, err := sql.Open(sqliteshim.DriverName(), filepath.Join(t.TempDir(), "sqlite.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, sqlitedialect.New(sqlitedialect.WithIdentQuoting(dialect.QuoteMinimal)))
+
+	q := db.NewSelect().Model((*Model)(nil)).Where("? = ?", bun.Ident("m.name"), "foo")
+	require.Equal(t,
+		`SELECT m.id, m.name, m."order", m."full name" FROM quoting_models AS m WHERE (m.name = 'foo')`,
+		q.String())
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	in := &Model{Name: "foo", Order: 1, FullName: "Foo Bar"}
+	_, err = db.NewInsert().Model(in).Exec(ctx)
+	require.NoError(t, err)
+
+	out := new(Model)
+	err = db.NewSelect().Model(out).Where("? = ?", bun.Ident("order"), 1).Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+}
+
 func TestConnResolver(t *testing.T) {
 	dsn := os.Getenv("PG")
 	if dsn == "" {
@@ -1877,3 +3624,47 @@ func TestConnResolver(t *testing.T) {
 	require.GreaterOrEqual(t, rodb.Stats().OpenConnections, 1)
 	require.Equal(t, 0, rwdb.Stats().OpenConnections)
 }
+
+type staticConnResolver struct {
+	conn bun.IConn
+}
+
+func (r staticConnResolver) ResolveConn(query bun.Query) bun.IConn {
+	return r.conn
+}
+
+func (r staticConnResolver) Close() error {
+	return nil
+}
+
+func TestConnResolverInsertBatches(t *testing.T) {
+	ctx := context.Background()
+
+	primary, err := sql.Open(sqliteshim.DriverName(), filepath.Join(t.TempDir(), "primary.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { primary.Close() })
+
+	resolved, err := sql.Open(sqliteshim.DriverName(), filepath.Join(t.TempDir(), "resolved.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { resolved.Close() })
+
+	db := bun.NewDB(primary, sqlitedialect.New(), bun.WithConnResolver(staticConnResolver{conn: resolved}))
+
+	type Model struct {
+		ID  int64 `bun:",pk,autoincrement"`
+		Str string
+	}
+
+	_, err = db.NewCreateTable().Model((*Model)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	// The batches are inserted in a transaction on the resolved database.
+	models := []Model{{Str: "1"}, {Str: "2"}, {Str: "3"}}
+	_, err = db.NewInsert().Model(&models).BatchSize(2).Exec(ctx)
+	require.NoError(t, err)
+
+	var count int
+	err = resolved.QueryRowContext(ctx, "SELECT count(*) FROM models").Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
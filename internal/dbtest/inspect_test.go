@@ -495,6 +495,16 @@ func cmpConstraints(tb testing.TB, want, got *sqlschema.BaseTable) {
 		return
 	}
 	require.ElementsMatch(tb, stripNames(want.UniqueConstraints), stripNames(got.UniqueConstraints), "table %q does not have expected unique constraints (listA=want, listB=got)", want.Name)
+
+	// Likewise, only compare columns of each index.
+	var wantIndexes, gotIndexes []string
+	for _, idx := range want.Indexes {
+		wantIndexes = append(wantIndexes, idx.Columns.String())
+	}
+	for _, idx := range got.Indexes {
+		gotIndexes = append(gotIndexes, idx.Columns.String())
+	}
+	require.ElementsMatch(tb, wantIndexes, gotIndexes, "table %q does not have expected indexes (listA=want, listB=got)", want.Name)
 }
 
 func tableNames(tables *ordered.Map[string, sqlschema.Table]) []string {
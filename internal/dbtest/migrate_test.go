@@ -7,10 +7,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/sqltype"
 	"github.com/uptrace/bun/internal/ordered"
 	"github.com/uptrace/bun/migrate"
@@ -55,6 +57,10 @@ func TestMigrate(t *testing.T) {
 	tests := []Test{
 		{run: testMigrateUpAndDown},
 		{run: testMigrateUpError},
+		{run: testMigrateLockTTL},
+		{run: testMigrateLockTTLOldLocksTable},
+		{run: testMigrateCurrentVersion},
+		{run: testMigrateExecSQLFile},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -180,6 +186,181 @@ func testMigrateUpError(t *testing.T, db *bun.DB) {
 	require.Equal(t, []string{"down2", "down1"}, history)
 }
 
+func testMigrateCurrentVersion(t *testing.T, db *bun.DB) {
+	ctx := context.Background()
+
+	noop := func(ctx context.Context, db *bun.DB) error { return nil }
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{Name: "20060102150405", Up: noop, Down: noop})
+	migrations.Add(migrate.Migration{Name: "20060102160405", Up: noop, Down: noop})
+
+	m := migrate.NewMigrator(db, migrations,
+		migrate.WithTableName(migrationsTable),
+		migrate.WithLocksTableName(migrationLocksTable),
+	)
+	err := m.Reset(ctx)
+	require.NoError(t, err)
+
+	version, err := m.CurrentVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "", version)
+
+	_, err = m.Migrate(ctx)
+	require.NoError(t, err)
+
+	version, err = m.CurrentVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "20060102160405", version)
+
+	_, err = m.Rollback(ctx)
+	require.NoError(t, err)
+
+	version, err = m.CurrentVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "", version)
+}
+
+func testMigrateExecSQLFile(t *testing.T, db *bun.DB) {
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{
+		"create.sql": {Data: []byte(`
+-- create the table; the separator in this comment is ignored
+CREATE TABLE exec_sql_file (id int, name varchar(100));
+
+/* insert rows; with separators inside literals */
+INSERT INTO exec_sql_file (id, name) VALUES (1, 'a;b');
+INSERT INTO exec_sql_file (id, name) VALUES (2, 'it''s;');
+`)},
+		"drop.sql": {Data: []byte(`
+DROP TABLE exec_sql_file
+GO
+-- nothing to execute
+`)},
+		"func.sql": {Data: []byte(`
+CREATE FUNCTION exec_sql_file_fn() RETURNS int AS $body$
+BEGIN
+	RETURN 42;
+END;
+$body$ LANGUAGE plpgsql;
+
+DROP FUNCTION exec_sql_file_fn();
+`)},
+	}
+
+	err := migrate.ExecSQLFile(ctx, db, fsys, "create.sql")
+	require.NoError(t, err)
+
+	var names []string
+	err = db.NewSelect().
+		Table("exec_sql_file").
+		Column("name").
+		Order("id").
+		Scan(ctx, &names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a;b", "it's;"}, names)
+
+	err = migrate.ExecSQLFile(ctx, db, fsys, "drop.sql", migrate.WithStatementSeparator("\nGO\n"))
+	require.NoError(t, err)
+
+	if db.Dialect().Name() == dialect.PG {
+		err = migrate.ExecSQLFile(ctx, db, fsys, "func.sql")
+		require.NoError(t, err)
+	}
+
+	err = migrate.ExecSQLFile(ctx, db, fsys, "missing.sql")
+	require.Error(t, err)
+}
+
+func testMigrateLockTTL(t *testing.T, db *bun.DB) {
+	ctx := context.Background()
+
+	if db.Dialect().Name() == dialect.SQLite {
+		// The lock renewal must not fail concurrent writes with SQLITE_BUSY.
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { db.SetMaxOpenConns(0) })
+	}
+
+	newMigrator := func(owner string, ttl time.Duration) *migrate.Migrator {
+		return migrate.NewMigrator(db, migrate.NewMigrations(),
+			migrate.WithTableName(migrationsTable),
+			migrate.WithLocksTableName(migrationLocksTable),
+			migrate.WithLockOwner(owner),
+			migrate.WithLockTTL(ttl),
+		)
+	}
+
+	m1 := newMigrator("m1", time.Hour)
+	require.NoError(t, m1.Reset(ctx))
+
+	// A killed migrator leaves behind a lock that has already expired.
+	_, err := db.NewInsert().
+		Model(&map[string]interface{}{
+			"table_name": migrationsTable,
+			"owner":      "killed",
+			"expires_at": time.Now().Add(-time.Minute),
+		}).
+		TableExpr(migrationLocksTable).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, m1.Lock(ctx), "expired lock must be acquired")
+
+	m2 := newMigrator("m2", time.Hour)
+	require.Error(t, m2.Lock(ctx), "lock must not be acquired before it expires")
+	require.NoError(t, m1.Unlock(ctx))
+
+	// The lock is renewed while it is held, so it outlives the TTL.
+	m3 := newMigrator("m3", time.Second)
+	require.NoError(t, m3.Lock(ctx))
+	time.Sleep(2 * time.Second)
+	require.Error(t, m2.Lock(ctx), "renewed lock must not expire")
+
+	// Unlocking a lock that is not held must not release the lock of another owner.
+	require.NoError(t, m1.Unlock(ctx))
+	require.Error(t, m2.Lock(ctx))
+
+	require.NoError(t, m3.Unlock(ctx))
+	require.NoError(t, m2.Lock(ctx))
+	require.NoError(t, m2.Unlock(ctx))
+
+	// Short TTLs are rounded up and the renewal stops when the Lock context is done.
+	m4 := newMigrator("m4", time.Nanosecond)
+	lockCtx, cancel := context.WithCancel(ctx)
+	require.NoError(t, m4.Lock(lockCtx))
+	cancel()
+	require.NoError(t, m4.Unlock(ctx))
+}
+
+func testMigrateLockTTLOldLocksTable(t *testing.T, db *bun.DB) {
+	type OldMigrationLock struct {
+		ID        int64  `bun:",pk,autoincrement"`
+		TableName string `bun:",unique"`
+	}
+
+	ctx := context.Background()
+
+	m := migrate.NewMigrator(db, migrate.NewMigrations(),
+		migrate.WithTableName(migrationsTable),
+		migrate.WithLocksTableName(migrationLocksTable),
+		migrate.WithLockTTL(time.Hour),
+	)
+	require.NoError(t, m.Reset(ctx))
+
+	// The locks table created before the lock TTL was added.
+	_, err := db.NewDropTable().Model((*OldMigrationLock)(nil)).ModelTableExpr(migrationLocksTable).Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewCreateTable().Model((*OldMigrationLock)(nil)).ModelTableExpr(migrationLocksTable).Exec(ctx)
+	require.NoError(t, err)
+
+	err = m.Lock(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must have owner and expires_at columns")
+
+	require.NoError(t, m.Reset(ctx))
+}
+
 // newAutoMigratorOrSkip creates an AutoMigrator configured to use test migratins/locks
 // tables and dedicated migrations directory. If an AutoMigrator cannob be created because
 // the dialect doesn't support either schema inspections or migrations, the test will be *skipped*
@@ -291,6 +472,26 @@ func checkMigrationFileContains(t *testing.T, fileSuffix string, snippets ...str
 	t.Errorf("no *%s file in migrations directory (%s)", fileSuffix, migrationsDir)
 }
 
+// checkMigrationFileNotContains checks that the migration file does not contain the SQL snippets.
+func checkMigrationFileNotContains(t *testing.T, fileSuffix string, snippets ...string) {
+	t.Helper()
+
+	files, err := os.ReadDir(migrationsDir)
+	require.NoErrorf(t, err, "list files in %s", migrationsDir)
+
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), fileSuffix) {
+			b, err := os.ReadFile(filepath.Join(migrationsDir, f.Name()))
+			require.NoError(t, err)
+			for _, content := range snippets {
+				require.NotContainsf(t, string(b), content, "expected %s file not to contain string", f.Name())
+			}
+			return
+		}
+	}
+	t.Errorf("no *%s file in migrations directory (%s)", fileSuffix, migrationsDir)
+}
+
 // checkMigrationFilesExist checks both up- and down- SQL migration files were created.
 func checkMigrationFilesExist(t *testing.T) {
 	t.Helper()
@@ -338,8 +539,12 @@ func TestAutoMigrator_Migrate(t *testing.T) {
 		{testAddDropColumn},
 		{testUnique},
 		{testUniqueRenamedTable},
+		{testIndexes},
+		{testRenameIndexedColumn},
 		{testUpdatePrimaryKeys},
 		{testNothingToMigrate},
+		{testMultipleSchemas},
+		{testMultipleSchemasForeignKey},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -356,6 +561,90 @@ func TestAutoMigrator_Migrate(t *testing.T) {
 	})
 }
 
+func testMultipleSchemas(t *testing.T, db *bun.DB) {
+	type Author struct {
+		bun.BaseModel `bun:"table:authors"`
+		ID            int64  `bun:"id,pk"`
+		Name          string `bun:"name"`
+	}
+
+	type Movie struct {
+		bun.BaseModel `bun:"table:hobbies.movies"`
+		ID            int64  `bun:"id,pk"`
+		Title         string `bun:"title"`
+	}
+
+	// Not used by the application.
+	type Book struct {
+		bun.BaseModel `bun:"table:hobbies.books"`
+		ID            int64 `bun:"id,pk"`
+	}
+
+	// Arrange
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	inspectHobbies := inspectDbOrSkip(t, db, "hobbies")
+	mustCreateSchema(t, ctx, db, "hobbies")
+	mustResetModel(t, ctx, db, (*Book)(nil))
+	mustDropTableOnCleanup(t, ctx, db, (*Author)(nil), (*Movie)(nil))
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*Author)(nil), (*Movie)(nil)))
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	state := inspect(ctx)
+	_, found := state.Tables.Load("authors")
+	require.True(t, found, "authors table is created in the default schema")
+	_, found = state.Tables.Load("movies")
+	require.False(t, found, "movies table is not created in the default schema")
+
+	state = inspectHobbies(ctx)
+	require.Equal(t, 2, state.Tables.Len())
+	_, found = state.Tables.Load("movies")
+	require.True(t, found, "movies table is created in the hobbies schema")
+	_, found = state.Tables.Load("books")
+	require.True(t, found, "books table is not dropped, because only declared tables are managed in other schemas")
+}
+
+func testMultipleSchemasForeignKey(t *testing.T, db *bun.DB) {
+	type Movie struct {
+		bun.BaseModel `bun:"table:hobbies.movies"`
+		ID            int64  `bun:"id,pk"`
+		Title         string `bun:"title"`
+	}
+
+	type Fan struct {
+		bun.BaseModel `bun:"table:fans"`
+		ID            int64  `bun:"id,pk"`
+		MovieID       int64  `bun:"movie_id"`
+		Movie         *Movie `bun:"rel:belongs-to,join:movie_id=id"`
+	}
+
+	// Arrange
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustCreateSchema(t, ctx, db, "hobbies")
+	mustDropTableOnCleanup(t, ctx, db, (*Fan)(nil), (*Movie)(nil))
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*Fan)(nil), (*Movie)(nil)))
+
+	// Act
+	// The default schema is migrated first, so "hobbies"."movies" must be created
+	// before the foreign key that references it.
+	runMigrations(t, m)
+
+	// Assert
+	state := inspect(ctx)
+	require.Contains(t, state.ForeignKeys, sqlschema.ForeignKey{
+		From: sqlschema.NewColumnReference("fans", "movie_id"),
+		To:   sqlschema.NewColumnReference("hobbies.movies", "id"),
+	})
+
+	files, err := m.CreateSQLMigrations(ctx)
+	require.NoError(t, err)
+	require.Empty(t, files, "the foreign key is not recreated")
+}
+
 func testRenameTable(t *testing.T, db *bun.DB) {
 	type initial struct {
 		bun.BaseModel `bun:"table:initial"`
@@ -596,8 +885,8 @@ func testChangeColumnType_AutoCast(t *testing.T, db *bun.DB) {
 					ordered.Pair[string, sqlschema.Column]{
 						Key: "ts",
 						Value: &sqlschema.BaseColumn{
-							SQLType:      "timestamp",         // FIXME(dyma): convert "timestamp with time zone" to sqltype.Timestamp
-							DefaultValue: "current_timestamp", // FIXME(dyma): Convert driver-specific value to common "expressions" (e.g. CURRENT_TIMESTAMP == current_timestamp) OR lowercase all types.
+							SQLType:      "timestamptz",
+							DefaultValue: "current_timestamp",
 							IsNullable:   true,
 						},
 					},
@@ -956,6 +1245,171 @@ func testUniqueRenamedTable(t *testing.T, db *bun.DB) {
 	cmpTables(t, db.Dialect().(sqlschema.InspectorDialect), wantTables, state.Tables)
 }
 
+func testIndexes(t *testing.T, db *bun.DB) {
+	type TableBefore struct {
+		bun.BaseModel `bun:"table:indexed_books"`
+		ID            int64  `bun:"id,pk"`
+		Title         string `bun:"title"`
+		ISBN          string `bun:"isbn,index"`
+		AuthorID      int64  `bun:"author_id,index:author_genre"`
+	}
+
+	type TableAfter struct {
+		bun.BaseModel `bun:"table:indexed_books"`
+		ID            int64  `bun:"id,pk"`
+		Title         string `bun:"title,index"`                  // new index
+		ISBN          string `bun:"isbn"`                         // index no longer declared, dropped
+		Genre         string `bun:"genre,index:author_genre"`     // new column, leads the "author_genre" index
+		AuthorID      int64  `bun:"author_id,index:author_genre"` // extend "author_genre" index
+	}
+
+	type NewTable struct {
+		bun.BaseModel `bun:"table:indexed_authors"`
+		ID            int64  `bun:"id,pk"`
+		Name          string `bun:"name,index"`
+	}
+
+	wantTables := ordered.NewMap[string, sqlschema.Table](
+		ordered.Pair[string, sqlschema.Table]{
+			Key: "indexed_books",
+			Value: &sqlschema.BaseTable{
+				Schema: db.Dialect().DefaultSchema(),
+				Name:   "indexed_books",
+				Columns: ordered.NewMap[string, sqlschema.Column](
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "id",
+						Value: &sqlschema.BaseColumn{
+							SQLType: sqltype.BigInt,
+						},
+					},
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "title",
+						Value: &sqlschema.BaseColumn{
+							SQLType:    sqltype.VarChar,
+							IsNullable: true,
+						},
+					},
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "isbn",
+						Value: &sqlschema.BaseColumn{
+							SQLType:    sqltype.VarChar,
+							IsNullable: true,
+						},
+					},
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "author_id",
+						Value: &sqlschema.BaseColumn{
+							SQLType:    sqltype.BigInt,
+							IsNullable: true,
+						},
+					},
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "genre",
+						Value: &sqlschema.BaseColumn{
+							SQLType:    sqltype.VarChar,
+							IsNullable: true,
+						},
+					},
+				),
+				PrimaryKey: &sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+				Indexes: []sqlschema.Index{
+					{Columns: sqlschema.NewOrderedColumns("title")},
+					{Name: "indexed_books_manual_idx", Columns: sqlschema.NewOrderedColumns("isbn", "title")},
+					{Name: "author_genre", Columns: sqlschema.NewOrderedColumns("genre", "author_id")},
+				},
+			},
+		},
+		ordered.Pair[string, sqlschema.Table]{
+			Key: "indexed_authors",
+			Value: &sqlschema.BaseTable{
+				Schema: db.Dialect().DefaultSchema(),
+				Name:   "indexed_authors",
+				Columns: ordered.NewMap[string, sqlschema.Column](
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "id",
+						Value: &sqlschema.BaseColumn{
+							SQLType: sqltype.BigInt,
+						},
+					},
+					ordered.Pair[string, sqlschema.Column]{
+						Key: "name",
+						Value: &sqlschema.BaseColumn{
+							SQLType:    sqltype.VarChar,
+							IsNullable: true,
+						},
+					},
+				),
+				PrimaryKey: &sqlschema.PrimaryKey{Columns: sqlschema.NewColumns("id")},
+				Indexes: []sqlschema.Index{
+					{Columns: sqlschema.NewOrderedColumns("name")},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustResetModel(t, ctx, db, (*TableBefore)(nil))
+	mustDropTableOnCleanup(t, ctx, db, (*NewTable)(nil))
+
+	// CREATE TABLE does not create indexes, so we create them the way a previous migration would.
+	_, err := db.NewCreateIndex().Model((*TableBefore)(nil)).Index("indexed_books_isbn_idx").Column("isbn").Exec(ctx)
+	require.NoError(t, err)
+	_, err = db.NewCreateIndex().Model((*TableBefore)(nil)).Index("author_genre").Column("author_id").Exec(ctx)
+	require.NoError(t, err)
+	// Indexes that no model declares are dropped unless they are excluded.
+	_, err = db.NewCreateIndex().Model((*TableBefore)(nil)).Index("indexed_books_manual_idx").Column("isbn", "title").Exec(ctx)
+	require.NoError(t, err)
+
+	m := newAutoMigratorOrSkip(t, db,
+		migrate.WithModel((*TableAfter)(nil), (*NewTable)(nil)),
+		migrate.WithExcludeIndex("indexed_books_manual_idx"),
+	)
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	state := inspect(ctx)
+	cmpTables(t, db.Dialect().(sqlschema.InspectorDialect), wantTables, state.GetTables())
+}
+
+func testRenameIndexedColumn(t *testing.T, db *bun.DB) {
+	type TableBefore struct {
+		bun.BaseModel `bun:"table:indexed_posts"`
+		ID            int64  `bun:"id,pk"`
+		Title         string `bun:"title,index:title_author"`
+		AuthorID      int64  `bun:"author_id,index:title_author"`
+	}
+
+	type TableAfter struct {
+		bun.BaseModel `bun:"table:indexed_posts"`
+		ID            int64  `bun:"id,pk"`
+		Name          string `bun:"name,index:title_author"` // renamed column, still leads the index
+		AuthorID      int64  `bun:"author_id,index:title_author"`
+	}
+
+	ctx := context.Background()
+	inspect := inspectDbOrSkip(t, db)
+	mustResetModel(t, ctx, db, (*TableBefore)(nil))
+	_, err := db.NewCreateIndex().Model((*TableBefore)(nil)).Index("title_author").Column("title", "author_id").Exec(ctx)
+	require.NoError(t, err)
+
+	m := newAutoMigratorOrSkip(t, db, migrate.WithModel((*TableAfter)(nil)))
+
+	// Act
+	runMigrations(t, m)
+
+	// Assert
+	checkMigrationFileNotContains(t, ".up.sql", "DROP INDEX", "CREATE INDEX")
+	state := inspect(ctx)
+	table, ok := state.Tables.Load("indexed_posts")
+	require.True(t, ok)
+	require.Equal(t, []sqlschema.Index{
+		{Name: "title_author", Columns: sqlschema.NewOrderedColumns("name", "author_id")},
+	}, table.GetIndexes())
+}
+
 func testUpdatePrimaryKeys(t *testing.T, db *bun.DB) {
 	// Has a composite primary key.
 	type DropPKBefore struct {
@@ -2,6 +2,7 @@ package dbtest_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -216,3 +217,61 @@ func assertQueryModel(query interface{ GetModel() bun.Model }) {
 		panic(fmt.Errorf("unexpected: %T", value))
 	}
 }
+
+func TestValidateHook(t *testing.T) {
+	testEachDB(t, testValidateHook)
+}
+
+func testValidateHook(t *testing.T, dbName string, db *bun.DB) {
+	mustResetModel(t, ctx, db, (*ValidateHookTest)(nil))
+
+	{
+		model := &ValidateHookTest{ID: 1}
+		_, err := db.NewInsert().Model(model).Exec(ctx)
+		require.Error(t, err)
+		require.Equal(t, "name is required", err.Error())
+	}
+
+	t.Run("insertSlice", func(t *testing.T) {
+		models := []ValidateHookTest{{ID: 1, Name: "one"}, {ID: 2}}
+		_, err := db.NewInsert().Model(&models).Exec(ctx)
+		require.Error(t, err)
+
+		var validationErr *bun.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, 1, validationErr.Index)
+
+		count, err := db.NewSelect().Model((*ValidateHookTest)(nil)).Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		model := &ValidateHookTest{ID: 1, Name: "one"}
+		_, err := db.NewInsert().Model(model).Exec(ctx)
+		require.NoError(t, err)
+
+		model.Name = ""
+		_, err = db.NewUpdate().Model(model).WherePK().Exec(ctx)
+		require.Error(t, err)
+		require.Equal(t, "name is required", err.Error())
+
+		err = db.NewSelect().Model(model).WherePK().Scan(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "one", model.Name)
+	})
+}
+
+type ValidateHookTest struct {
+	ID   int `bun:",pk"`
+	Name string
+}
+
+var _ bun.ValidateHook = (*ValidateHookTest)(nil)
+
+func (t *ValidateHookTest) Validate(ctx context.Context, query bun.Query) error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
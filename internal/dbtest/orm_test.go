@@ -30,13 +30,17 @@ func TestORM(t *testing.T) {
 		{testTranslationRelations},
 		{testBulkUpdate},
 		{testRelationColumn},
+		{testRelationColumns},
 		{testRelationExcludeAll},
+		{testRelationExcludeColumns},
 		{testM2MRelationExcludeColumn},
 		{testRelationBelongsToSelf},
 		{testCompositeHasMany},
 		{testCompositeM2M},
+		{testM2MColumns},
 		{testHasOneRelationWithOpts},
 		{testHasManyRelationWithOpts},
+		{testRelationLoadWarning},
 	}
 
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -291,6 +295,34 @@ func testRelationColumn(t *testing.T, db *bun.DB) {
 	}, book)
 }
 
+func testRelationColumns(t *testing.T, db *bun.DB) {
+	author := new(Author)
+	err := db.NewSelect().
+		Model(author).
+		RelationColumns("Books", "title").
+		Where("author.id = ?", 10).
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 10, author.ID)
+	require.Len(t, author.Books, 2)
+	for _, book := range author.Books {
+		require.Zero(t, book.ID)
+		require.NotEmpty(t, book.Title)
+		require.Equal(t, 10, book.AuthorID)
+	}
+
+	book := new(Book)
+	err = db.NewSelect().
+		Model(book).
+		ExcludeColumn("created_at").
+		RelationColumns("Author", "name").
+		OrderExpr("book.id").
+		Limit(1).
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Author{ID: 10, Name: "author 1"}, book.Author)
+}
+
 func testRelationExcludeAll(t *testing.T, db *bun.DB) {
 	book := new(Book)
 	err := db.NewSelect().
@@ -351,6 +383,94 @@ func testRelationExcludeAll(t *testing.T, db *bun.DB) {
 	}, book)
 }
 
+func testRelationLoadWarning(t *testing.T, db *bun.DB) {
+	logger := new(recordingLogger)
+	bun.SetLogger(logger)
+	defer bun.SetLogger(nil)
+
+	db = db.WithRelationLoadWarning(3)
+	loads := db.DBStats().RelationLoads
+
+	// Contexts without the counter are not tracked.
+	for i := 0; i < 4; i++ {
+		err := db.NewSelect().Model(new(Author)).Relation("Books").Where("author.id = ?", 10).Scan(ctx)
+		require.NoError(t, err)
+	}
+	require.Empty(t, logger.lines)
+
+	ctx := bun.RelationLoadContext(ctx)
+
+	for _, id := range []int{10, 11, 12} {
+		// Derived contexts share the count.
+		ctx, cancel := context.WithCancel(ctx)
+		author := new(Author)
+		err := db.NewSelect().
+			Model(author).
+			Preload("Books").
+			Where("author.id = ?", id).
+			Scan(ctx)
+		cancel()
+		require.NoError(t, err)
+		require.Empty(t, logger.lines, "threshold is not exceeded yet")
+	}
+
+	author := new(Author)
+	err := db.NewSelect().Model(author).Relation("Books").Where("author.id = ?", 10).Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, logger.lines, 1)
+	require.Contains(t, logger.lines[0], `model=Author relation="Books"`)
+	require.Equal(t, loads+8, db.DBStats().RelationLoads)
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func testRelationExcludeColumns(t *testing.T, db *bun.DB) {
+	author := new(Author)
+	err := db.NewSelect().
+		Model(author).
+		RelationExcludeColumns("Books", "*").
+		Where("author.id = ?", 10).
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, author.Books, 2)
+	for _, book := range author.Books {
+		require.Equal(t, &Book{AuthorID: 10}, book)
+	}
+
+	author = new(Author)
+	err = db.NewSelect().
+		Model(author).
+		RelationExcludeColumns("Books", "author_id", "title", "created_at").
+		Where("author.id = ?", 10).
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, author.Books, 2)
+	for _, book := range author.Books {
+		require.NotZero(t, book.ID)
+		require.Empty(t, book.Title)
+		require.Equal(t, 10, book.AuthorID)
+		require.Zero(t, book.CreatedAt)
+	}
+
+	// Belongs-to relations are joined in the main query, so the excluded key stays excluded.
+	book := new(Book)
+	err = db.NewSelect().
+		Model(book).
+		ExcludeColumn("created_at").
+		RelationExcludeColumns("Author", "id").
+		OrderExpr("book.id").
+		Limit(1).
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Author{Name: "author 1", AvatarID: 1}, book.Author)
+}
+
 func testRelationBelongsToSelf(t *testing.T, db *bun.DB) {
 	type Model struct {
 		bun.BaseModel `bun:"alias:m"`
@@ -522,6 +642,60 @@ func testCompositeM2M(t *testing.T, db *bun.DB) {
 	require.Equal(t, 1, len(ordersOut2[0].Items))
 }
 
+func testM2MColumns(t *testing.T, db *bun.DB) {
+	type Item struct {
+		bun.BaseModel `bun:"table:legacy_items,alias:i"`
+
+		ID int64 `bun:",pk"`
+	}
+
+	type Order struct {
+		bun.BaseModel `bun:"table:legacy_orders,alias:o"`
+
+		ID    int64  `bun:",pk"`
+		Items []Item `bun:"m2m:legacy_order_items,join:Order=Item,columns:order_ref=id;item_ref=id"`
+	}
+
+	type OrderItem struct {
+		bun.BaseModel `bun:"table:legacy_order_items"`
+
+		OrderRef int64 `bun:",pk"`
+		ItemRef  int64 `bun:",pk"`
+	}
+
+	db.RegisterModel((*OrderItem)(nil))
+	mustResetModel(t, ctx, db, (*Order)(nil), (*Item)(nil), (*OrderItem)(nil))
+
+	items := []Item{{ID: 1}, {ID: 2}, {ID: 3}}
+	_, err := db.NewInsert().Model(&items).Exec(ctx)
+	require.NoError(t, err)
+
+	orders := []Order{{ID: 1}, {ID: 2}}
+	_, err = db.NewInsert().Model(&orders).Exec(ctx)
+	require.NoError(t, err)
+
+	orderItems := []OrderItem{
+		{OrderRef: 1, ItemRef: 1},
+		{OrderRef: 1, ItemRef: 2},
+		{OrderRef: 2, ItemRef: 3},
+	}
+	_, err = db.NewInsert().Model(&orderItems).Exec(ctx)
+	require.NoError(t, err)
+
+	var ordersOut []Order
+	err = db.NewSelect().
+		Model(&ordersOut).
+		Relation("Items", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("i.id ASC")
+		}).
+		OrderExpr("o.id ASC").
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, ordersOut, 2)
+	require.Equal(t, []Item{{ID: 1}, {ID: 2}}, ordersOut[0].Items)
+	require.Equal(t, []Item{{ID: 3}}, ordersOut[1].Items)
+}
+
 func testHasOneRelationWithOpts(t *testing.T, db *bun.DB) {
 	type Profile struct {
 		ID     int64 `bun:",pk"`
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"io"
 	"net"
 	"reflect"
 	"testing"
@@ -734,6 +735,77 @@ func TestPostgresHStore(t *testing.T) {
 	require.Nil(t, attrs3)
 }
 
+func TestPostgresTSVector(t *testing.T) {
+	type Book struct {
+		ID   int64 `bun:",pk,autoincrement"`
+		Name string
+		TSV  pgdialect.TSVector `bun:"tsv"`
+	}
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+	mustResetModel(t, ctx, db, (*Book)(nil))
+
+	for _, name := range []string{"The fat cat", "A rat and a cat ate a cat", "The dog"} {
+		_, err := db.NewInsert().
+			Model(&Book{Name: name}).
+			Value("tsv", "to_tsvector(?)", name).
+			Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	var books []Book
+	err := db.NewSelect().
+		Model(&books).
+		WhereTSMatch("tsv", "cat").
+		OrderByRank("tsv", "cat").
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, books, 2)
+	require.Equal(t, "A rat and a cat ate a cat", books[0].Name)
+	require.Equal(t, pgdialect.TSVector("'ate':5 'cat':4,7 'rat':2"), books[0].TSV)
+	require.Equal(t, "The fat cat", books[1].Name)
+}
+
+func TestPostgresComposite(t *testing.T) {
+	type Dimensions struct {
+		Width  int
+		Height int
+		Unit   string
+	}
+	type Product struct {
+		ID   int64       `bun:",pk,autoincrement"`
+		Size Dimensions  `bun:",composite:dimensions"`
+		Box  *Dimensions `bun:",composite:dimensions"`
+	}
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	_, err := db.Exec(`DROP TABLE IF EXISTS products`)
+	require.NoError(t, err)
+	_, err = db.Exec(`DROP TYPE IF EXISTS dimensions`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TYPE dimensions AS (width int, height int, unit text)`)
+	require.NoError(t, err)
+	mustResetModel(t, ctx, db, (*Product)(nil))
+
+	in := &Product{Size: Dimensions{Width: 10, Height: 20, Unit: `in, "x"`}}
+	_, err = db.NewInsert().Model(in).Exec(ctx)
+	require.NoError(t, err)
+
+	out := new(Product)
+	err = db.NewSelect().Model(out).Where("id = ?", in.ID).Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, in.Size, out.Size)
+	require.Nil(t, out.Box)
+
+	var unit string
+	err = db.NewSelect().Model((*Product)(nil)).ColumnExpr("(size).unit").Scan(ctx, &unit)
+	require.NoError(t, err)
+	require.Equal(t, `in, "x"`, unit)
+}
+
 func TestPostgresHStoreQuote(t *testing.T) {
 	db := pg(t)
 	t.Cleanup(func() { db.Close() })
@@ -877,3 +949,344 @@ func TestPostgresMultiRange(t *testing.T) {
 	err = db.NewSelect().Model(out).Scan(ctx)
 	require.NoError(t, err)
 }
+
+func TestPostgresInterval(t *testing.T) {
+	type Model struct {
+		ID       int64          `bun:",pk,autoincrement"`
+		Timeout  time.Duration  `bun:",type:interval"`
+		Deadline *time.Duration `bun:",type:interval"`
+		Nanos    time.Duration
+	}
+
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	deadline := -(26*time.Hour + 1500*time.Microsecond)
+	in := &Model{
+		Timeout:  90*time.Minute + 30*time.Second,
+		Deadline: &deadline,
+		Nanos:    time.Nanosecond,
+	}
+	_, err := db.NewInsert().Model(in).Exec(ctx)
+	require.NoError(t, err)
+
+	out := &Model{ID: in.ID}
+	err = db.NewSelect().Model(out).WherePK().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, in.Timeout, out.Timeout)
+	require.Equal(t, deadline, *out.Deadline)
+	require.Equal(t, in.Nanos, out.Nanos)
+
+	var typ string
+	err = db.NewSelect().Model((*Model)(nil)).ColumnExpr("pg_typeof(timeout)::text").Limit(1).Scan(ctx, &typ)
+	require.NoError(t, err)
+	require.Equal(t, "interval", typ)
+}
+
+func TestPostgresAdvisoryLock(t *testing.T) {
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	const key = 42
+
+	release, err := pgdialect.AcquireAdvisoryLock(ctx, db, key)
+	require.NoError(t, err)
+
+	_, ok, err := pgdialect.TryAcquireAdvisoryLock(ctx, db, key)
+	require.NoError(t, err)
+	require.False(t, ok, "lock must be held by the first session")
+
+	require.NoError(t, release(ctx))
+	require.NoError(t, release(ctx), "release must be idempotent")
+
+	release, ok, err = pgdialect.TryAcquireAdvisoryLock(ctx, db, key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, release(ctx))
+}
+
+func TestPostgresLargeObject(t *testing.T) {
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), 64<<10) // 1MB
+	oid, err := pgdialect.WriteLargeObject(ctx, db, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.NotZero(t, oid)
+	t.Cleanup(func() {
+		_ = pgdialect.DeleteLargeObject(ctx, db, oid)
+	})
+
+	rd, err := pgdialect.ReadLargeObject(ctx, db, oid)
+	require.NoError(t, err)
+
+	got, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	require.NoError(t, rd.Close())
+	require.Equal(t, data, got)
+
+	err = db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		oid, err := pgdialect.WriteLargeObject(ctx, tx, bytes.NewReader(nil))
+		require.NoError(t, err)
+
+		rd, err := pgdialect.ReadLargeObject(ctx, tx, oid)
+		require.NoError(t, err)
+
+		got, err := io.ReadAll(rd)
+		require.NoError(t, err)
+		require.Empty(t, got)
+		require.NoError(t, rd.Close())
+
+		return pgdialect.DeleteLargeObject(ctx, tx, oid)
+	})
+	require.NoError(t, err)
+
+	err = pgdialect.DeleteLargeObject(ctx, db, oid)
+	require.NoError(t, err)
+
+	_, err = pgdialect.ReadLargeObject(ctx, db, oid)
+	require.Error(t, err)
+}
+
+func TestPostgresRelationJSON(t *testing.T) {
+	type Comment struct {
+		bun.BaseModel `bun:"table:relation_json_comments"`
+
+		ID     int64 `bun:",pk"`
+		PostID int64
+		Text   string
+	}
+	type Post struct {
+		bun.BaseModel `bun:"table:relation_json_posts"`
+
+		ID        int64 `bun:",pk"`
+		UserID    int64
+		Title     string
+		CreatedAt time.Time
+		Comments  []Comment `bun:"rel:has-many,join:id=post_id"`
+	}
+	type User struct {
+		bun.BaseModel `bun:"table:relation_json_users"`
+
+		ID    int64 `bun:",pk"`
+		Name  string
+		Posts []*Post `bun:"rel:has-many,join:id=user_id"`
+	}
+	type PostWithAuthor struct {
+		bun.BaseModel `bun:"table:relation_json_posts"`
+
+		ID     int64 `bun:",pk"`
+		UserID int64
+		Title  string
+		Author *User `bun:"rel:belongs-to,join:user_id=id"`
+	}
+
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	mustResetModel(t, ctx, db, (*User)(nil), (*Post)(nil), (*Comment)(nil))
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	users := []User{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}}
+	posts := []Post{
+		{ID: 1, UserID: 1, Title: "first", CreatedAt: createdAt},
+		{ID: 2, UserID: 1, Title: "second", CreatedAt: createdAt},
+	}
+	comments := []Comment{{ID: 1, PostID: 1, Text: `with "quotes"`}, {ID: 2, PostID: 1, Text: "b"}}
+	for _, model := range []interface{}{&users, &posts, &comments} {
+		_, err := db.NewInsert().Model(model).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	var gotUsers []User
+	err := db.NewSelect().
+		Model(&gotUsers).
+		RelationJSON("Posts.Comments").
+		OrderExpr("id").
+		Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, gotUsers, 2)
+	require.Len(t, gotUsers[0].Posts, 2)
+	require.Equal(t, "first", gotUsers[0].Posts[0].Title)
+	require.True(t, createdAt.Equal(gotUsers[0].Posts[0].CreatedAt))
+	require.Equal(t, comments, gotUsers[0].Posts[0].Comments)
+	require.Empty(t, gotUsers[0].Posts[1].Comments)
+	require.Empty(t, gotUsers[1].Posts)
+
+	post := new(PostWithAuthor)
+	err = db.NewSelect().Model(post).RelationJSON("Author").Where("id = 2").Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "second", post.Title)
+	require.Equal(t, "alice", post.Author.Name)
+}
+
+// compositePoint scans a whole row that is selected as a composite value, e.g. ROW(x, y).
+type compositePoint struct {
+	X, Y int
+}
+
+func (p *compositePoint) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("unsupported data type: %T", src)
+	}
+	_, err := fmt.Sscanf(s, "(%d,%d)", &p.X, &p.Y)
+	return err
+}
+
+func TestPostgresScanCompositeRow(t *testing.T) {
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	point := new(compositePoint)
+	err := db.NewSelect().ColumnExpr("ROW(1, 2)").Scan(ctx, point)
+	require.NoError(t, err)
+	require.Equal(t, &compositePoint{X: 1, Y: 2}, point)
+
+	var points []compositePoint
+	err = db.NewSelect().
+		ColumnExpr("ROW(n, n * 10)").
+		TableExpr("generate_series(1, 3) AS n").
+		Scan(ctx, &points)
+	require.NoError(t, err)
+	require.Equal(t, []compositePoint{{1, 10}, {2, 20}, {3, 30}}, points)
+
+	// Slice models whose elements implement sql.Scanner are still table models.
+	query := db.NewSelect().Model(&points).String()
+	require.Equal(t, `SELECT "composite_point"."x", "composite_point"."y" FROM "composite_points" AS "composite_point"`, query)
+}
+
+func TestPostgresDecimal(t *testing.T) {
+	type Model struct {
+		ID     int64 `bun:",pk,autoincrement"`
+		Amount bun.Decimal
+		Price  bun.Decimal `bun:",type:money"`
+		Empty  bun.Decimal
+	}
+
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	in := &Model{
+		Amount: "12345678901234567890.123456789012345678",
+		Price:  "-1234.56",
+	}
+	_, err := db.NewInsert().Model(in).Exec(ctx)
+	require.NoError(t, err)
+
+	out := &Model{ID: in.ID}
+	err = db.NewSelect().Model(out).WherePK().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, in.Amount, out.Amount)
+	require.Equal(t, in.Price, out.Price)
+	require.Equal(t, bun.Decimal(""), out.Empty)
+
+	var sum bun.Decimal
+	err = db.NewSelect().Model((*Model)(nil)).ColumnExpr("amount + ?", bun.Decimal("0.000000000000000001")).
+		Scan(ctx, &sum)
+	require.NoError(t, err)
+	require.Equal(t, bun.Decimal("12345678901234567890.123456789012345679"), sum)
+}
+
+func TestPostgresMultiDimArray(t *testing.T) {
+	type Model struct {
+		ID       int64           `bun:",pk,autoincrement"`
+		Matrix   [][]float64     `bun:",array"`
+		Words    [][]string      `bun:",array"`
+		Amounts  []bun.Decimal   `bun:",array"`
+		Cube     [][][]int64     `bun:",array"`
+		Decimals [][]bun.Decimal `bun:",type:numeric[][],array"`
+	}
+
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	mustResetModel(t, ctx, db, (*Model)(nil))
+
+	in := &Model{
+		Matrix:   [][]float64{{1.5, -2}, {3, 4.25}},
+		Words:    [][]string{{"a,b", `"}`}, {"c", "NULL"}},
+		Amounts:  []bun.Decimal{"12345678901234567890.123456789", "-0.000000001"},
+		Cube:     [][][]int64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}},
+		Decimals: [][]bun.Decimal{{"0.1", "0.2"}, {"0.3", "NaN"}},
+	}
+	_, err := db.NewInsert().Model(in).Exec(ctx)
+	require.NoError(t, err)
+
+	out := &Model{ID: in.ID}
+	err = db.NewSelect().Model(out).WherePK().Scan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+
+	var typ string
+	err = db.NewSelect().Model((*Model)(nil)).ColumnExpr("pg_typeof(matrix)::text").Limit(1).Scan(ctx, &typ)
+	require.NoError(t, err)
+	require.Equal(t, "double precision[]", typ) // Postgres does not track the number of dimensions.
+}
+
+type Reservation struct {
+	ID     int64  `bun:",pk,autoincrement"`
+	Room   string `bun:",notnull"`
+	During string `bun:",type:tstzrange,notnull"`
+}
+
+var _ bun.BeforeCreateTableHook = (*Reservation)(nil)
+
+func (*Reservation) BeforeCreateTable(ctx context.Context, query *bun.CreateTableQuery) error {
+	query.Exclude("USING gist (room WITH =, during WITH &&)")
+	return nil
+}
+
+func TestPostgresExcludeConstraint(t *testing.T) {
+	ctx := context.Background()
+
+	db := pg(t)
+	t.Cleanup(func() { db.Close() })
+
+	_, err := db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS btree_gist")
+	require.NoError(t, err)
+
+	mustResetModel(t, ctx, db, (*Reservation)(nil))
+
+	_, err = db.NewInsert().Model(&Reservation{
+		Room:   "a",
+		During: "[2020-01-01 10:00, 2020-01-01 11:00)",
+	}).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&Reservation{
+		Room:   "b",
+		During: "[2020-01-01 10:30, 2020-01-01 11:30)",
+	}).Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&Reservation{
+		Room:   "a",
+		During: "[2020-01-01 10:30, 2020-01-01 11:30)",
+	}).Exec(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exclusion constraint")
+}
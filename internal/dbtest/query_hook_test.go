@@ -76,6 +76,44 @@ func testQueryHook(t *testing.T, dbName string, db *bun.DB) {
 		require.Equal(t, 1, num)
 		hook.require(t)
 	}
+
+	{
+		hook.reset()
+		hook.beforeQuery = func(
+			ctx context.Context, event *bun.QueryEvent,
+		) context.Context {
+			require.Equal(t, "SELECT ? AS name", event.QueryTemplate)
+			require.Equal(t, []interface{}{"alice"}, event.QueryArgs)
+			require.Equal(t, "SELECT 'alice' AS name", event.Query)
+			return ctx
+		}
+
+		var name string
+		err := db.NewRaw("SELECT ? AS name", "alice").Scan(ctx, &name)
+		require.NoError(t, err)
+		require.Equal(t, "alice", name)
+		hook.require(t)
+	}
+
+	{
+		hook.reset()
+		hook.beforeQuery = func(
+			ctx context.Context, event *bun.QueryEvent,
+		) context.Context {
+			t.Fatalf("unexpected query: %s", event.Query)
+			return ctx
+		}
+
+		var num int
+		err := db.NewSelect().ColumnExpr("1").WithoutHooks().Scan(ctx, &num)
+		require.NoError(t, err)
+		require.Equal(t, 1, num)
+
+		err = db.NewRaw("SELECT 1").WithoutHooks().Scan(ctx, &num)
+		require.NoError(t, err)
+		require.True(t, hook.startTime.IsZero())
+		require.True(t, hook.endTime.IsZero())
+	}
 }
 
 type queryHook struct {
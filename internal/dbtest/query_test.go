@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"testing"
 	"time"
@@ -12,6 +13,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/dialect/mysqldialect"
 	"github.com/uptrace/bun/dialect/sqltype"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/migrate"
@@ -1738,6 +1742,1129 @@ func TestQuery(t *testing.T) {
 					Comment("test")
 			},
 		},
+		{
+			id: 187,
+			query: func(db *bun.DB) schema.QueryAppender {
+				models := []Model{{ID: 1}, {ID: 2}}
+				return db.NewDelete().Model(&models).WherePK()
+			},
+		},
+		{
+			id: 188,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID1 int64 `bun:",pk"`
+					ID2 int64 `bun:",pk"`
+				}
+				models := []Model{{ID1: 1, ID2: 2}, {ID1: 3, ID2: 4}}
+				return db.NewDelete().Model(&models).WherePK()
+			},
+		},
+		{
+			id: 189,
+			query: func(db *bun.DB) schema.QueryAppender {
+				models := []SoftDelete1{{ID: 1}, {ID: 2}}
+				return db.NewDelete().Model(&models).WherePK()
+			},
+		},
+		{
+			id: 190,
+			query: func(db *bun.DB) schema.QueryAppender {
+				models := []Model{}
+				return db.NewDelete().Model(&models).WherePK()
+			},
+		},
+		{
+			id: 191,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(&Story{ID: 1}).
+					TableAlias("s").
+					Relation("User").
+					Where("?TableAlias.name = ?", "hello").
+					WherePK()
+			},
+		},
+		{
+			id: 192,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*User)(nil)).
+					TableAlias("u1").
+					Join("JOIN users AS u2 ON u2.id = ?TableAlias.id").
+					ColumnExpr("?TableColumns")
+			},
+		},
+		{
+			id: 193,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDropTable().
+					Model((*Model)(nil)).
+					IfExists().
+					DisableForeignKeyChecks()
+			},
+		},
+		{
+			id: 194,
+			query: func(db *bun.DB) schema.QueryAppender {
+				values := db.NewValues(&[]Model{{42, "hello"}, {43, "world"}}).
+					ColumnNames("id", "str").
+					TableAlias("t").
+					WithOrder()
+				return db.NewSelect().
+					TableExpr("?", values).
+					Column("t.id", "t.str").
+					OrderExpr("t._order")
+			},
+		},
+		{
+			id: 195,
+			query: func(db *bun.DB) schema.QueryAppender {
+				models := []Model{{42, "hello"}, {43, "world"}}
+				return db.NewUpdate().
+					Model(&models).
+					TableExpr("?", db.NewValues(&models).ColumnNames("id", "str").TableAlias("_data")).
+					Set("str = _data.str").
+					Where("model.id = _data.id")
+			},
+		},
+		{
+			id: 196,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewValues(&[]Model{{42, "hello"}}).ColumnNames("id")
+			},
+		},
+		{
+			id: 197,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().Model(new(Model)).DefaultValues()
+			},
+		},
+		{
+			id: 198,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().Table("models").DefaultValues()
+			},
+		},
+		{
+			id: 199,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Table("items").
+					Column("id").
+					ColumnExpr("row_number() OVER w AS rank").
+					ColumnExpr("sum(price) OVER w AS total").
+					Window("w", "PARTITION BY ? ORDER BY ?", bun.Ident("category"), bun.Ident("price"))
+			},
+		},
+		{
+			id: 200,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					WhereTSMatch("model.tsv", "cat & rat").
+					OrderByRank("model.tsv", "cat & rat")
+			},
+		},
+		{
+			id: 201,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewUpdate().
+					Model(&Model{ID: 1}).
+					SetMap(map[string]interface{}{"str": "hello", "id": 2}).
+					WherePK()
+			},
+		},
+		{
+			id: 202,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewUpdate().
+					Model(&Model{ID: 1}).
+					SetMap(map[string]interface{}{"unknown": "hello"}).
+					WherePK()
+			},
+		},
+		{
+			id: 203,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewAlterColumn().
+					Model(new(Model)).
+					Column("str").
+					SetDefault("?", "hello")
+			},
+		},
+		{
+			id: 204,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewAlterColumn().
+					Model(new(Model)).
+					Column("str").
+					DropDefault()
+			},
+		},
+		{
+			id: 205,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					DialectExpr(map[dialect.Name]string{
+						dialect.PG:     "'pg' AS name, ? AS n",
+						dialect.MySQL:  "'mysql' AS name, ? AS n",
+						dialect.SQLite: "'sqlite' AS name, ? AS n",
+						dialect.MSSQL:  "'mssql' AS name, ? AS n",
+					}, 42)
+			},
+		},
+		{
+			id: 206,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					DialectExpr(map[dialect.Name]string{
+						dialect.PG: "'pg' AS name",
+					})
+			},
+		},
+		{
+			id: 209,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(new(Story)).
+					RelationColumns("User", "name")
+			},
+		},
+		{
+			id: 210,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					Where("? AND str = ?", bun.SafeQuery("id IN (?, ?)", 1, bun.SafeQuery("? + ?", 2, 3)), "hello").
+					Where("str IN (?)", bun.SafeQuery("?, ?0, ?1", "a", "b?"))
+			},
+		},
+		{
+			id: 211,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID   int64  `bun:",pk"`
+					Name string `bun:",notnull,collate:en_US.utf8"`
+					Code string `bun:",unique,default:'',collate:C"`
+				}
+				return db.NewCreateTable().Model((*Model)(nil))
+			},
+		},
+		{
+			id: 207,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					ColumnExpr("str").
+					Where("id = ?", 1).
+					OrderExpr("id DESC").
+					Limit(10).
+					Reset().
+					Model((*Model)(nil)).
+					Column("id")
+			},
+		},
+		{
+			id: 208,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewUpdate().
+					Model(&Model{ID: 1, Str: "hello"}).
+					Set("str = ?", "world").
+					WherePK().
+					Returning("*").
+					Reset().
+					Model(&Model{ID: 2, Str: "hello"}).
+					WherePK()
+			},
+		},
+		{
+			id: 212,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflict("id").
+					DoUpdate().
+					Set("str = EXCLUDED.str").
+					Where("model.str IS NULL")
+			},
+		},
+		{
+			id: 213,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflict("id").
+					DoNothing()
+			},
+		},
+		{
+			id: 214,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID    int64
+					Slice []string       `bun:",nullempty"`
+					Map   map[string]int `bun:",nullempty"`
+				}
+				models := []Model{
+					{ID: 1},
+					{ID: 2, Slice: []string{}, Map: map[string]int{}},
+					{ID: 3, Slice: []string{"foo"}, Map: map[string]int{"bar": 1}},
+				}
+				return db.NewInsert().Model(&models)
+			},
+		},
+		{
+			id: 215,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					LeftJoin("users AS u", "u.id = model.id AND u.name = ?", "hello").
+					RightJoin("books b", "b.author_id = u.id").
+					JoinOn("b.id > ?", 10)
+			},
+		},
+		{
+			id: 216,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					FullJoin("users", "users.id = model.id")
+			},
+		},
+		{
+			id: 217,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Total   int    `bun:",readonly"`
+					Comment string `bun:",scanonly"`
+				}
+				return db.NewInsert().Model(&Model{ID: 1, Name: "hello", Total: 10})
+			},
+		},
+		{
+			id: 218,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Total   int    `bun:",readonly"`
+					Comment string `bun:",scanonly"`
+				}
+				return db.NewUpdate().Model(&Model{ID: 1, Name: "hello", Total: 10}).WherePK()
+			},
+		},
+		{
+			id: 219,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Total   int    `bun:",readonly"`
+					Comment string `bun:",scanonly"`
+				}
+				return db.NewSelect().Model(&Model{ID: 1}).WherePK()
+			},
+		},
+		{
+			id: 220,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflictConstraint("models_str_key").
+					DoUpdate().
+					Set("str = EXCLUDED.str")
+			},
+		},
+		{
+			id: 221,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflictConstraint("models_str_key").
+					DoNothing()
+			},
+		},
+		{
+			id: 222,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model((*Model)(nil)).ModelColumn("id", "str")
+			},
+		},
+		{
+			id: 223,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model((*Model)(nil)).ModelColumn("id", "users.password")
+			},
+		},
+		{
+			id: 224,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model((*Model)(nil)).ColumnExpr("lower(?)", bun.Ident(`str") FROM users --`))
+			},
+		},
+		{
+			id: 225,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model((*Model)(nil)).WhereDistinctFrom("str", "hello")
+			},
+		},
+		{
+			id: 226,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewUpdate().Model((*Model)(nil)).Set("str = ?", "hello").WhereNotDistinctFrom("str", nil)
+			},
+		},
+		{
+			id: 227,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID    int64 `bun:",pk,autoincrement"`
+					Name  string
+					Value string
+				}
+
+				models := []*Model{
+					{Name: "A", Value: "world"},
+					{Name: "B", Value: "test"},
+				}
+
+				return db.NewMerge().
+					Model(&models).
+					Key("name").
+					UpdateColumns("value").
+					InsertAll()
+			},
+		},
+		{
+			id: 228,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID      int64 `bun:",pk"`
+					Tenant  string
+					Name    string
+					Value   string
+					Counter int `bun:",readonly"`
+				}
+
+				return db.NewMerge().
+					Model(&Model{ID: 1, Tenant: "t1", Name: "A", Value: "world"}).
+					Key("tenant", "name").
+					UpdateColumns().
+					InsertAll()
+			},
+		},
+		{
+			id: 229,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					WhereIf(false, "str = ? AND id = ?", "skipped").
+					WhereIf(true, "id = ?", 42).
+					WhereOrIf(false, "str = ?", "skipped").
+					WhereOrIf(true, "str = ?", "hello")
+			},
+		},
+		{
+			id: 230,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().
+					Model((*Model)(nil)).
+					QueryBuilder().
+					WhereIf(false, "id = ?", 1).
+					WhereOrIf(false, "id = ?", 2).
+					WhereIf(true, "str = ?", "hello").
+					Unwrap().(*bun.DeleteQuery)
+			},
+		},
+		{
+			id: 231,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Comment struct {
+					ID     int64 `bun:",pk"`
+					PostID int64
+					Text   string
+				}
+				type Post struct {
+					ID       int64 `bun:",pk"`
+					UserID   int64
+					Title    string
+					Comments []Comment `bun:"rel:has-many,join:id=post_id"`
+				}
+				type User struct {
+					ID    int64 `bun:",pk"`
+					Name  string
+					Posts []*Post `bun:"rel:has-many,join:id=user_id"`
+				}
+
+				return db.NewSelect().Model((*User)(nil)).RelationJSON("Posts.Comments")
+			},
+		},
+		{
+			id: 232,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type User struct {
+					ID   int64 `bun:",pk"`
+					Name string
+				}
+				type Post struct {
+					ID       int64 `bun:",pk"`
+					AuthorID int64
+					Author   *User `bun:"rel:belongs-to,join:author_id=id"`
+				}
+
+				return db.NewSelect().Model((*Post)(nil)).Column("id").RelationJSON("Author")
+			},
+		},
+		{
+			id: 233,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(new(Story)).
+					RelationExcludeColumns("User", "*")
+			},
+		},
+		{
+			id: 234,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(new(Story)).
+					RelationExcludeColumns("User", "id", "name")
+			},
+		},
+		{
+			id: 235,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model(new(Story)).Relation("Usr")
+			},
+		},
+		{
+			id: 236,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model(new(Story)).Relation("User.Stories")
+			},
+		},
+		{
+			id: 237,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID        int64
+					Name      string `bun:",default:'unnamed'"`
+					CreatedAt time.Time
+				}
+				return db.NewCreateTable().
+					Model((*Model)(nil)).
+					DefaultExpr("id", "nextval(?)", "app.models_id_seq").
+					DefaultExpr("name", "?", "it's unnamed").
+					DefaultExpr("created_at", "?", bun.Safe("CURRENT_TIMESTAMP"))
+			},
+		},
+		{
+			id: 238,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID int64
+				}
+				return db.NewCreateTable().
+					Model((*Model)(nil)).
+					DefaultExpr("missing", "1")
+			},
+		},
+		{
+			id: 239,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Parent struct {
+					ID int64 `bun:",pk"`
+				}
+				type Child struct {
+					ID       int64 `bun:",pk"`
+					ParentID int64
+					Parent   *Parent `bun:"rel:belongs-to,join:parent_id=id,on_delete:cascade,deferrable"`
+				}
+				return db.NewCreateTable().Model((*Child)(nil)).WithForeignKeys()
+			},
+		},
+		{
+			id: 240,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Parent struct {
+					ID int64 `bun:",pk"`
+				}
+				type Child struct {
+					ID       int64 `bun:",pk"`
+					ParentID int64
+					Parent   *Parent `bun:"rel:belongs-to,join:parent_id=id,deferrable:immediate"`
+				}
+				return db.NewCreateTable().Model((*Child)(nil)).WithForeignKeys()
+			},
+		},
+		{
+			id: 241,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type UUID [16]byte
+
+				schema.RegisterSQLType(reflect.TypeFor[UUID](), func(d schema.Dialect) string {
+					switch d.Name() {
+					case dialect.PG:
+						return "uuid"
+					case dialect.MySQL:
+						return "char(36)"
+					case dialect.MSSQL:
+						return "uniqueidentifier"
+					}
+					return sqltype.Blob
+				})
+
+				type Model struct {
+					ID      UUID `bun:",pk"`
+					OwnerID *UUID
+					Legacy  UUID `bun:",type:varchar(36)"`
+				}
+				return db.NewCreateTable().Model((*Model)(nil))
+			},
+		},
+		{
+			id: 242,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(new(Model)).
+					Where("id = ?", 1).
+					WhereOrGroup(func(q *bun.SelectQuery) *bun.SelectQuery {
+						return q.Where("name = ?", "admin").Where("id > ?", 100)
+					}).
+					WhereAndGroup(func(q *bun.SelectQuery) *bun.SelectQuery {
+						return q.Where("name IS NOT NULL").WhereOr("id < 0")
+					})
+			},
+		},
+		{
+			id: 243,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().
+					Model(new(Model)).
+					ApplyQueryBuilder(func(q bun.QueryBuilder) bun.QueryBuilder {
+						return q.Where("id = ?", 1).
+							WhereOrGroup(func(q bun.QueryBuilder) bun.QueryBuilder {
+								return q.Where("name = ?", "a").Where("id > ?", 100)
+							})
+					})
+			},
+		},
+		{
+			id: 244,
+			query: func(db *bun.DB) schema.QueryAppender {
+				if db.Dialect().Name() == dialect.MySQL {
+					db = bun.NewDB(db.DB, mysqldialect.New(
+						mysqldialect.WithoutFeature(feature.InsertRowAlias)))
+				}
+				return db.NewInsert().
+					Model(&Model{ID: 1, Str: "hello"}).
+					On("DUPLICATE KEY UPDATE")
+			},
+		},
+		{
+			id: 245,
+			query: func(db *bun.DB) schema.QueryAppender {
+				if db.Dialect().Name() == dialect.MySQL {
+					db = bun.NewDB(db.DB, mysqldialect.New(
+						mysqldialect.WithFeature(feature.InsertRowAlias)))
+				}
+				return db.NewInsert().
+					Model(&Model{ID: 1, Str: "hello"}).
+					On("DUPLICATE KEY UPDATE")
+			},
+		},
+		{
+			id: 246,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model(new(Model)).
+					Where("str IS NOT NULL").
+					WithScope(tenantScope{tenantID: 42})
+			},
+		},
+		{
+			id: 247,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().
+					Model(&Model{ID: 1}).
+					WherePK().
+					WithScope(tenantScope{tenantID: 42})
+			},
+		},
+		{
+			id: 248,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateTable().
+					Table("active_models").
+					As(db.NewSelect().Model(new(Model)).Where("str = ?", "active"))
+			},
+		},
+		{
+			id: 249,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateTable().
+					Table("models_archive").
+					Temp().
+					IfNotExists().
+					As(db.NewSelect().Model(new(Model)).Where("id > ?", 100))
+			},
+		},
+		{
+			id: 250,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateMaterializedView().
+					View("model_counts").
+					As(db.NewSelect().
+						Model(new(Model)).
+						ColumnExpr("str, count(*) AS count").
+						Where("id > ?", 100).
+						Group("str"))
+			},
+		},
+		{
+			id: 251,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateMaterializedView().
+					IfNotExists().
+					View("model_counts").
+					As(db.NewSelect().Model(new(Model)).Where("str = ?", "active")).
+					WithNoData()
+			},
+		},
+		{
+			id: 252,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID      int64 `bun:",pk,autoincrement"`
+					Str     string
+					Version int64 `bun:",version"`
+				}
+				return db.NewUpdate().Model(&Model{ID: 1, Str: "hello", Version: 3}).WherePK()
+			},
+		},
+		{
+			id: 253,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateTable().Model(new(Model)).Temporary()
+			},
+		},
+		{
+			id: 254,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateTable().Model(new(Model)).Temporary().OnCommit("drop")
+			},
+		},
+		{
+			id: 255,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model(new(Model)).Order("str DESC").Limit(3).WithTies()
+			},
+		},
+		{
+			id: 256,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model(new(Model)).Order("str DESC").Limit(3).Offset(6).WithTies()
+			},
+		},
+		{
+			id: 257,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model(new(Model)).Limit(3).WithTies()
+			},
+		},
+		{
+			id: 258,
+			query: func(db *bun.DB) schema.QueryAppender {
+				sub := db.NewSelect().
+					Model(new(Model)).
+					Column("id").
+					ColumnExpr("COUNT(*) AS cnt").
+					Where("str = ?", "sub").
+					Group("id")
+				return db.NewSelect().
+					Model(new(Model)).
+					JoinSubquery(sub, "sub", "sub.id = model.id AND sub.cnt > ?", 1).
+					Where("model.str = ?", "outer")
+			},
+		},
+		{
+			id: 259,
+			query: func(db *bun.DB) schema.QueryAppender {
+				sub := db.NewSelect().Model(new(Model)).Where("id > ?", 10)
+				return db.NewSelect().
+					ColumnExpr("sub.id").
+					TableSubquery(sub, "sub").
+					LeftJoinSubquery(
+						db.NewSelect().Model(new(Model)).Where("str = ?", "joined"),
+						"other", "other.id = sub.id",
+					).
+					JoinOn("other.str <> ?", "skip")
+			},
+		},
+		{
+			id: 260,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateTable().
+					Model(new(Model)).
+					Exclude("USING gist (str WITH =, tsrange(?, ?) WITH &&)", "2020-01-01", "2020-01-02")
+			},
+		},
+		{
+			id: 261,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateView().
+					View("active_models").
+					As(db.NewSelect().Model(new(Model)).Where("str = ?", "active"))
+			},
+		},
+		{
+			id: 262,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewCreateView().
+					View("active_models").
+					OrReplace().
+					As(db.NewSelect().Model(new(Model)).Where("id > ?", 42))
+			},
+		},
+		{
+			id: 263,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDropView().View("active_models").IfExists().Cascade()
+			},
+		},
+		{
+			id: 264,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDropView().View("v1", "v2")
+			},
+		},
+		{
+			id: 265,
+			query: func(db *bun.DB) schema.QueryAppender {
+				values := []Model{{ID: 1, Str: "one"}, {ID: 2, Str: "two"}}
+				return db.NewSelect().
+					Model(new(Model)).
+					Join("JOIN ? AS v (id, str) ON v.id = model.id AND v.str <> ?", bun.Values(&values), "skip").
+					Where("model.str = ?", "outer")
+			},
+		},
+		{
+			id: 266,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflict("id").
+					DoUpdate().
+					SetExcluded("str", "other")
+			},
+		},
+		{
+			id: 267,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Model struct {
+					ID        int64 `bun:",pk"`
+					Name      string
+					Count     int       `bun:",skipupdate"`
+					CreatedAt time.Time `bun:",skipupdate"`
+					Computed  string    `bun:",scanonly"`
+				}
+				return db.NewInsert().
+					Model(&Model{ID: 42, Name: "hello"}).
+					OnConflict("id").
+					DoUpdate().
+					SetExcludedAll().
+					Set("count = model.count + 1")
+			},
+		},
+		{
+			id: 268,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type Model struct {
+					ID      int64 `bun:",pk,autoincrement"`
+					Name    string
+					Total   int      `bun:",scanonly"`
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewInsert().Model(&Model{Name: "hello"}).ReturningModel()
+			},
+		},
+		{
+			id: 269,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type Model struct {
+					ID      int64 `bun:",pk,autoincrement"`
+					Name    string
+					Total   int      `bun:",scanonly"`
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewUpdate().Model(&Model{ID: 1, Name: "hello"}).WherePK().ReturningModel()
+			},
+		},
+		{
+			id: 270,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type Model struct {
+					ID      int64 `bun:",pk,autoincrement"`
+					Name    string
+					Total   int      `bun:",scanonly"`
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewDelete().Model(&Model{ID: 1}).WherePK().ReturningModel()
+			},
+		},
+		{
+			id: 271,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type User struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewSelect().
+					Model(new(User)).
+					WithTableName("users_shard_3").
+					Relation("Profile").
+					Where("?TableAlias.id > (SELECT min(id) FROM ?TableName)")
+			},
+		},
+		{
+			id: 272,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type User struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewUpdate().
+					Model(&User{ID: 1, Name: "hello"}).
+					WithTableName("users_shard_3").
+					WherePK()
+			},
+		},
+		{
+			id: 273,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Profile struct {
+					ID     int64 `bun:",pk"`
+					UserID int64
+				}
+				type User struct {
+					ID      int64 `bun:",pk"`
+					Name    string
+					Profile *Profile `bun:"rel:has-one,join:id=user_id"`
+				}
+				return db.NewDelete().
+					Model(&User{ID: 1}).
+					WithTableName("users_shard_3").
+					WherePK()
+			},
+		},
+		{
+			id: 274,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Price struct {
+					ID    int64 `bun:",pk"`
+					Value float64
+				}
+				type Product struct {
+					ID    int64 `bun:",pk"`
+					Price float64
+				}
+				return db.NewUpdate().
+					Model((*Product)(nil)).
+					From("prices AS p").
+					Set("price = p.value").
+					Where("p.id = ?TableAlias.id")
+			},
+		},
+		{
+			id: 275,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Price struct {
+					ID    int64 `bun:",pk"`
+					Value float64
+				}
+				type Product struct {
+					ID    int64 `bun:",pk"`
+					Price float64
+				}
+				return db.NewUpdate().
+					Model((*Product)(nil)).
+					From("prices AS p").
+					Set("price = p.value").
+					Where("p.id = ?TableAlias.id").
+					ReturningModel()
+			},
+		},
+		{
+			id: 276,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().Model((*Model)(nil)).WhereILike("str", "%"+bun.EscapeLike(`50%_off\`)+"%")
+			},
+		},
+		{
+			id: 277,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().Model((*Model)(nil)).WhereILike("str", "hello%")
+			},
+		},
+		{
+			id: 278,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().
+					Model(&SoftDelete1{ID: 1}).
+					WithTableName("soft_deletes_2024").
+					WherePK()
+			},
+		},
+		{
+			id: 279,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewUpdate().
+					Model(&SoftDelete1{ID: 1}).
+					WithTableName("soft_deletes_2024").
+					Set("deleted_at = NULL").
+					WherePK()
+			},
+		},
+		{
+			id: 280,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewDelete().
+					Model(&SoftDelete1{ID: 1}).
+					WithTableName("soft_deletes_2024").
+					WhereDeleted().
+					WherePK().
+					ForceDelete()
+			},
+		},
+		{
+			id: 281,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflict("id").
+					DoUpdate().
+					DoUpdate().
+					Set("str = EXCLUDED.str")
+			},
+		},
+		{
+			id: 282,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewInsert().
+					Model(&Model{ID: 42, Str: "hello"}).
+					OnConflict("id")
+			},
+		},
+		{
+			id: 283,
+			query: func(db *bun.DB) schema.QueryAppender {
+				type Attachment struct {
+					ID     int64 `bun:",pk"`
+					PostID int64
+					Data   []byte
+				}
+				type Post struct {
+					ID          int64         `bun:",pk"`
+					Attachments []*Attachment `bun:"rel:has-many,join:id=post_id"`
+				}
+
+				return db.NewSelect().Model((*Post)(nil)).RelationJSON("Attachments")
+			},
+		},
+		{
+			id: 284,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					Where("? = ?", bun.DialectExpr(map[dialect.Name]string{
+						dialect.PG:     "lower(?)",
+						dialect.MySQL:  "lcase(?)",
+						dialect.SQLite: "lower(?)",
+						dialect.MSSQL:  "lower(?)",
+					}, bun.Ident("str")), "hello")
+			},
+		},
+		{
+			id: 285,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewSelect().
+					Model((*Model)(nil)).
+					Where("? = 1", bun.DialectExpr(map[dialect.Name]string{
+						dialect.PG: "true",
+					}))
+			},
+		},
+		{
+			id: 286,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewValues(&[]Model{{ID: 1, Str: "one"}}).
+					Column("str").
+					WithOrder().
+					Reset(&[]Model{{ID: 2, Str: "two"}})
+			},
+		},
+		{
+			id: 287,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewRaw("SELECT ?", 1).
+					Comment("first").
+					Reset("SELECT ?", 2)
+			},
+		},
+		{
+			id: 288,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewAlterColumn().
+					Model(new(Model)).
+					Column("str").
+					SetDefault("lower(?)", "HELLO")
+			},
+		},
+		{
+			id: 289,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewAlterColumn().
+					Model(new(Model)).
+					Column("str").
+					SetNotNull()
+			},
+		},
+		{
+			id: 290,
+			query: func(db *bun.DB) schema.QueryAppender {
+				return db.NewAlterColumn().
+					Model(new(Model)).
+					Column("str").
+					DropNotNull()
+			},
+		},
 	}
 
 	timeRE := regexp.MustCompile(`'2\d{3}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?(\+\d{2}:\d{2})?'`)
@@ -1759,6 +2886,22 @@ func TestQuery(t *testing.T) {
 	})
 }
 
+type tenantScope struct {
+	tenantID int64
+}
+
+func (s tenantScope) ApplySelect(q *bun.SelectQuery) {
+	q.Where("tenant_id = ?", s.tenantID)
+}
+
+func (s tenantScope) ApplyUpdate(q *bun.UpdateQuery) {
+	q.Where("tenant_id = ?", s.tenantID)
+}
+
+func (s tenantScope) ApplyDelete(q *bun.DeleteQuery) {
+	q.Where("tenant_id = ?", s.tenantID)
+}
+
 func TestAlterTable(t *testing.T) {
 	type Movie struct {
 		bun.BaseModel `bun:"table:hobbies.movies"`
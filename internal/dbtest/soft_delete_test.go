@@ -20,6 +20,7 @@ func TestSoftDelete(t *testing.T) {
 		{run: testSoftDeleteNilModel},
 		{run: testSoftDeleteAPI},
 		{run: testSoftDeleteBulk},
+		{run: testSoftDeleteSliceWherePK},
 		{run: testSoftDeleteForce},
 	}
 	testEachDB(t, func(t *testing.T, dbName string, db *bun.DB) {
@@ -179,3 +180,47 @@ func testSoftDeleteBulk(t *testing.T, db *bun.DB) {
 	require.NoError(t, err)
 	require.Equal(t, 0, count)
 }
+
+func testSoftDeleteSliceWherePK(t *testing.T, db *bun.DB) {
+	ctx := context.Background()
+	mustResetModel(t, ctx, db, (*Video)(nil))
+
+	videos := []Video{
+		{Name: "video1"},
+		{Name: "video2"},
+		{Name: "video3"},
+	}
+	_, err := db.NewInsert().Model(&videos).Exec(ctx)
+	require.NoError(t, err)
+
+	// Soft delete video1 and video2 by their primary keys.
+	deleted := videos[:2]
+	res, err := db.NewDelete().Model(&deleted).WherePK().Exec(ctx)
+	require.NoError(t, err)
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	var names []string
+	err = db.NewSelect().Model((*Video)(nil)).Column("name").Scan(ctx, &names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"video3"}, names)
+
+	names = nil
+	err = db.NewSelect().Model((*Video)(nil)).Column("name").WhereDeleted().Order("name").Scan(ctx, &names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"video1", "video2"}, names)
+
+	count, err := db.NewSelect().Model((*Video)(nil)).WhereAllWithDeleted().Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	// An empty slice must not delete anything.
+	empty := []Video{}
+	_, err = db.NewDelete().Model(&empty).WherePK().Exec(ctx)
+	require.Error(t, err)
+
+	count, err = db.NewSelect().Model((*Video)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
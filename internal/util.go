@@ -1,7 +1,9 @@
 package internal
 
 import (
+	"math/rand"
 	"reflect"
+	"time"
 )
 
 func MakeSliceNextElemFunc(v reflect.Value) func() reflect.Value {
@@ -56,6 +58,27 @@ func Unwrap(err error) error {
 	return u.Unwrap()
 }
 
+// RetryBackoff returns an exponential backoff with jitter for the given retry attempt.
+func RetryBackoff(retry int, minBackoff, maxBackoff time.Duration) time.Duration {
+	if retry < 0 {
+		panic("not reached")
+	}
+	if minBackoff == 0 {
+		return 0
+	}
+
+	d := minBackoff << uint(retry)
+	if d < minBackoff || d > maxBackoff {
+		return maxBackoff
+	}
+
+	d = minBackoff + time.Duration(rand.Int63n(int64(d)))
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
 func FieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
 	if len(index) == 1 {
 		return v.Field(index[0])
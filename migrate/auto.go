@@ -8,9 +8,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/internal/ordered"
 	"github.com/uptrace/bun/migrate/sqlschema"
 	"github.com/uptrace/bun/schema"
 )
@@ -35,7 +38,19 @@ func WithExcludeTable(tables ...string) AutoMigratorOption {
 	}
 }
 
+// WithExcludeIndex tells the AutoMigrator to keep an index that is not declared on the models.
+// Other indexes that exist in the database but not on the models are dropped.
+func WithExcludeIndex(names ...string) AutoMigratorOption {
+	return func(m *AutoMigrator) {
+		m.diffOpts = append(m.diffOpts, withExcludeIndexes(names...))
+	}
+}
+
 // WithSchemaName changes the default database schema to migrate objects in.
+//
+// Models that are defined in other schemas, e.g. `bun:"table:hobbies.movies"`,
+// are migrated too. In these schemas, only the tables used by the models are managed:
+// other tables are never renamed or dropped.
 func WithSchemaName(schemaName string) AutoMigratorOption {
 	return func(m *AutoMigrator) {
 		m.schemaName = schemaName
@@ -106,13 +121,11 @@ func WithMigrationsDirectoryAuto(directory string) AutoMigratorOption {
 type AutoMigrator struct {
 	db *bun.DB
 
-	// dbInspector creates the current state for the target database.
-	dbInspector sqlschema.Inspector
+	// schemas are the database schemas considered for migration:
+	// the default schema followed by the other schemas used by the models.
+	schemas []*schemaMigrator
 
-	// modelInspector creates the desired state based on the model definitions.
-	modelInspector sqlschema.Inspector
-
-	// dbMigrator executes ALTER TABLE queries.
+	// dbMigrator generates SQL for the operations in all schemas.
 	dbMigrator sqlschema.Migrator
 
 	table      string // Migrations table (excluded from database inspection)
@@ -150,44 +163,122 @@ func NewAutoMigrator(db *bun.DB, opts ...AutoMigratorOption) (*AutoMigrator, err
 	}
 	am.excludeTables = append(am.excludeTables, am.table, am.locksTable)
 
-	dbInspector, err := sqlschema.NewInspector(db, sqlschema.WithSchemaName(am.schemaName), sqlschema.WithExcludeTables(am.excludeTables...))
+	tables := schema.NewTables(db.Dialect())
+	tables.Register(am.includeModels...)
+
+	for _, schemaName := range modelSchemas(am.schemaName, tables) {
+		sm, err := am.newSchemaMigrator(schemaName, tables)
+		if err != nil {
+			return nil, err
+		}
+		am.schemas = append(am.schemas, sm)
+	}
+	am.dbMigrator = multiMigrator{}
+	am.diffOpts = append(am.diffOpts, withCompareTypeFunc(db.Dialect().(sqlschema.InspectorDialect).CompareType))
+
+	return am, nil
+}
+
+// schemaMigrator inspects and migrates a single database schema.
+type schemaMigrator struct {
+	schemaName string
+
+	// declaredOnly limits migrations to the tables used by the models.
+	declaredOnly bool
+
+	// dbInspector creates the current state for the target schema.
+	dbInspector sqlschema.Inspector
+
+	// modelInspector creates the desired state based on the model definitions.
+	modelInspector sqlschema.Inspector
+
+	// dbMigrator executes ALTER TABLE queries.
+	dbMigrator sqlschema.Migrator
+}
+
+func (am *AutoMigrator) newSchemaMigrator(schemaName string, tables *schema.Tables) (*schemaMigrator, error) {
+	dbInspector, err := sqlschema.NewInspector(am.db, sqlschema.WithSchemaName(schemaName), sqlschema.WithExcludeTables(am.excludeTables...))
 	if err != nil {
 		return nil, err
 	}
-	am.dbInspector = dbInspector
-	am.diffOpts = append(am.diffOpts, withCompareTypeFunc(db.Dialect().(sqlschema.InspectorDialect).CompareType))
 
-	dbMigrator, err := sqlschema.NewMigrator(db, am.schemaName)
+	dbMigrator, err := sqlschema.NewMigrator(am.db, schemaName)
 	if err != nil {
 		return nil, err
 	}
-	am.dbMigrator = dbMigrator
 
-	tables := schema.NewTables(db.Dialect())
-	tables.Register(am.includeModels...)
-	am.modelInspector = sqlschema.NewBunModelInspector(tables, sqlschema.WithSchemaName(am.schemaName))
+	return &schemaMigrator{
+		schemaName:     schemaName,
+		declaredOnly:   schemaName != am.schemaName,
+		dbInspector:    dbInspector,
+		modelInspector: sqlschema.NewBunModelInspector(tables, sqlschema.WithSchemaName(schemaName)),
+		dbMigrator:     dbMigrator,
+	}, nil
+}
 
-	return am, nil
+// modelSchemas returns the default schema followed by the other schemas
+// used by the models in alphabetical order.
+func modelSchemas(defaultSchema string, tables *schema.Tables) []string {
+	schemas := []string{defaultSchema}
+	var other []string
+	for _, t := range tables.All() {
+		if !slices.Contains(schemas, t.Schema) && !slices.Contains(other, t.Schema) {
+			other = append(other, t.Schema)
+		}
+	}
+	slices.Sort(other)
+	return append(schemas, other...)
 }
 
 func (am *AutoMigrator) plan(ctx context.Context) (*changeset, error) {
-	var err error
+	var changes changeset
+	for _, sm := range am.schemas {
+		got, err := sm.dbInspector.Inspect(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	got, err := am.dbInspector.Inspect(ctx)
-	if err != nil {
-		return nil, err
-	}
+		want, err := sm.modelInspector.Inspect(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	want, err := am.modelInspector.Inspect(ctx)
-	if err != nil {
-		return nil, err
+		if sm.declaredOnly {
+			got = declaredTables(got, want)
+		}
+
+		c := diff(got, want, am.diffOpts...)
+		for _, op := range c.operations {
+			changes.Add(&schemaOp{Operation: op, schemaName: sm.schemaName, migrator: sm.dbMigrator})
+		}
 	}
 
-	changes := diff(got, want, am.diffOpts...)
+	// Operations are resolved together, because a foreign key may reference a table in another schema.
 	if err := changes.ResolveDependencies(); err != nil {
 		return nil, fmt.Errorf("plan migrations: %w", err)
 	}
-	return changes, nil
+	return &changes, nil
+}
+
+// declaredTables returns the tables in the current state which are used by the models
+// and the foreign keys defined on them.
+func declaredTables(got, want sqlschema.Database) sqlschema.Database {
+	declared := sqlschema.BaseDatabase{
+		Tables:      ordered.NewMap[string, sqlschema.Table](),
+		ForeignKeys: make(map[sqlschema.ForeignKey]string),
+	}
+	wantTables := want.GetTables()
+	for _, pair := range got.GetTables().Pairs() {
+		if _, ok := wantTables.Load(pair.Key); ok {
+			declared.Tables.Store(pair.Key, pair.Value)
+		}
+	}
+	for fk, name := range got.GetForeignKeys() {
+		if _, ok := declared.Tables.Load(fk.From.TableName); ok {
+			declared.ForeignKeys[fk] = name
+		}
+	}
+	return declared
 }
 
 // Migrate writes required changes to a new migration file and runs the migration.
@@ -299,6 +390,80 @@ func (am *AutoMigrator) createSQL(_ context.Context, migrations *Migrations, fna
 	return mf, nil
 }
 
+// schemaOp is an operation on a table in one of the migrated schemas.
+// Table names in the operation are relative to that schema.
+type schemaOp struct {
+	Operation
+	schemaName string
+	migrator   sqlschema.Migrator
+}
+
+func (op *schemaOp) GetReverse() Operation {
+	reverse := op.Operation.GetReverse()
+	if _, isComment := reverse.(*comment); isComment {
+		return reverse
+	}
+	return &schemaOp{Operation: reverse, schemaName: op.schemaName, migrator: op.migrator}
+}
+
+// DependsOn qualifies the table names in an operation on another schema,
+// so that it can be compared to this operation.
+func (op *schemaOp) DependsOn(another Operation) bool {
+	dop, ok := op.Operation.(interface {
+		DependsOn(Operation) bool
+	})
+	if !ok {
+		return false
+	}
+	other, ok := another.(*schemaOp)
+	if !ok {
+		return false
+	}
+	if other.schemaName == op.schemaName {
+		return dop.DependsOn(other.Operation)
+	}
+	return dop.DependsOn(requalify(other.Operation, other.schemaName, op.schemaName))
+}
+
+// requalify returns a copy of the operation with the table names relative to schema "to"
+// instead of schema "from". Only the operations that other operations may depend on are copied.
+func requalify(op Operation, from, to string) Operation {
+	name := func(tableName string) string {
+		schemaName, tableName, ok := strings.Cut(tableName, ".")
+		if !ok {
+			schemaName, tableName = from, schemaName
+		}
+		if schemaName == to {
+			return tableName
+		}
+		return schemaName + "." + tableName
+	}
+
+	switch op := op.(type) {
+	case *CreateTableOp:
+		return &CreateTableOp{TableName: name(op.TableName), Model: op.Model}
+	case *RenameTableOp:
+		return &RenameTableOp{TableName: name(op.TableName), NewName: name(op.NewName)}
+	case *DropForeignKeyOp:
+		fk := op.ForeignKey
+		fk.From.TableName = name(fk.From.TableName)
+		fk.To.TableName = name(fk.To.TableName)
+		return &DropForeignKeyOp{ForeignKey: fk, ConstraintName: op.ConstraintName}
+	}
+	return op
+}
+
+// multiMigrator generates SQL for each operation with the migrator of its schema.
+type multiMigrator struct{}
+
+func (multiMigrator) AppendSQL(b []byte, operation interface{}) ([]byte, error) {
+	op, ok := operation.(*schemaOp)
+	if !ok {
+		return nil, fmt.Errorf("append sql: unknown operation %T", operation)
+	}
+	return op.migrator.AppendSQL(b, op.Operation)
+}
+
 func (c *changeset) Len() int {
 	return len(c.operations)
 }
@@ -398,9 +563,11 @@ func (c *changeset) ResolveDependencies() error {
 	var nextOp Operation
 	var visit func(op Operation) error
 
+	// Operations are picked in reverse, so that independent operations keep their order
+	// after being prepended to the resolved list.
 	next := func() bool {
-		for op, s := range status {
-			if s == unvisited {
+		for i := len(c.operations) - 1; i >= 0; i-- {
+			if op := c.operations[i]; status[op] == unvisited {
 				nextOp = op
 				return true
 			}
@@ -63,6 +63,14 @@ RenameCreate:
 			TableName: wantTable.GetName(),
 			Model:     additional.Model,
 		})
+
+		// CREATE TABLE does not include ordinary indexes, so they are created separately.
+		for _, index := range wantTable.GetIndexes() {
+			d.changes.Add(&CreateIndexOp{
+				TableName: wantTable.GetName(),
+				Index:     index,
+			})
+		}
 	}
 
 	// Drop any remaining "current" tables which do not have a model.
@@ -142,6 +150,12 @@ ChangeRename:
 			// Update primary key definition to avoid superficially recreating the constraint.
 			current.GetPrimaryKey().Columns.Replace(cName, tName)
 
+			// Same goes for the indexes which include the renamed column.
+			indexes := current.GetIndexes()
+			for i := range indexes {
+				indexes[i].Columns.ReplaceOrdered(cName, tName)
+			}
+
 			continue ChangeRename
 		}
 
@@ -193,6 +207,37 @@ Drop:
 		})
 	}
 
+CreateIndex:
+	for _, want := range target.GetIndexes() {
+		for _, got := range current.GetIndexes() {
+			if got.Equals(want) {
+				continue CreateIndex
+			}
+		}
+		d.changes.Add(&CreateIndexOp{
+			TableName: target.GetName(),
+			Index:     want,
+		})
+	}
+
+	// Indexes that back PRIMARY KEY and UNIQUE constraints are not inspected,
+	// so any other index that is not declared on the models is dropped, unless it is excluded.
+DropIndex:
+	for _, got := range current.GetIndexes() {
+		for _, want := range target.GetIndexes() {
+			if got.Equals(want) {
+				continue DropIndex
+			}
+		}
+		if _, exclude := d.excludeIndexes[got.GetName(current.GetName())]; exclude {
+			continue
+		}
+		d.changes.Add(&DropIndexOp{
+			TableName: target.GetName(),
+			Index:     got,
+		})
+	}
+
 	targetPK := target.GetPrimaryKey()
 	currentPK := current.GetPrimaryKey()
 
@@ -230,11 +275,17 @@ func newDetector(got, want sqlschema.Database, opts ...diffOption) *detector {
 		opt(cfg)
 	}
 
+	excludeIndexes := make(map[string]struct{}, len(cfg.excludeIndexes))
+	for _, name := range cfg.excludeIndexes {
+		excludeIndexes[name] = struct{}{}
+	}
+
 	return &detector{
-		current: got,
-		target:  want,
-		refMap:  newRefMap(got.GetForeignKeys()),
-		cmpType: cfg.cmpType,
+		current:        got,
+		target:         want,
+		refMap:         newRefMap(got.GetForeignKeys()),
+		cmpType:        cfg.cmpType,
+		excludeIndexes: excludeIndexes,
 	}
 }
 
@@ -246,9 +297,16 @@ func withCompareTypeFunc(f CompareTypeFunc) diffOption {
 	}
 }
 
+func withExcludeIndexes(names ...string) diffOption {
+	return func(cfg *detectorConfig) {
+		cfg.excludeIndexes = append(cfg.excludeIndexes, names...)
+	}
+}
+
 // detectorConfig controls how differences in the model states are resolved.
 type detectorConfig struct {
-	cmpType CompareTypeFunc
+	cmpType        CompareTypeFunc
+	excludeIndexes []string
 }
 
 // detector may modify the passed database schemas, so it isn't safe to re-use them.
@@ -267,6 +325,9 @@ type detector struct {
 	// due to the existence of dialect-specific type aliases. The caller
 	// should pass a concrete InspectorDialect.EquuivalentType for robust comparison.
 	cmpType CompareTypeFunc
+
+	// excludeIndexes are the names of the indexes that must not be dropped.
+	excludeIndexes map[string]struct{}
 }
 
 // canRename checks if t1 can be renamed to t2.
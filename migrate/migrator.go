@@ -2,11 +2,13 @@ package migrate
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -33,6 +35,39 @@ func WithLocksTableName(table string) MigratorOption {
 	}
 }
 
+// WithLockTTL sets the time after which the migration lock expires and can be acquired
+// by another migrator, e.g. when the process that held the lock was killed.
+// By default, the lock never expires.
+//
+// While the lock is held, it is renewed in the background every third of the TTL
+// until Unlock is called or the context passed to Lock is done, so migrations may run
+// longer than the TTL. TTLs shorter than a second are rounded up to a second. On SQLite,
+// the renewal competes with the migrations for the write lock, so set a busy timeout
+// or limit the connection pool to a single connection.
+//
+// The lock has extra owner and expires_at columns when TTL is set. Lock returns
+// an error if the locks table was created by an older version without them;
+// drop the locks table and call Init to recreate it.
+func WithLockTTL(ttl time.Duration) MigratorOption {
+	return func(m *Migrator) {
+		if ttl > 0 && ttl < minLockTTL {
+			ttl = minLockTTL
+		}
+		m.lockTTL = ttl
+	}
+}
+
+// minLockTTL leaves the lock renewal enough time to reach the database.
+const minLockTTL = time.Second
+
+// WithLockOwner overrides the identifier of the migration lock owner,
+// which defaults to the host name and the process ID.
+func WithLockOwner(owner string) MigratorOption {
+	return func(m *Migrator) {
+		m.lockOwner = owner
+	}
+}
+
 // WithMarkAppliedOnSuccess sets the migrator to only mark migrations as applied/unapplied
 // when their up/down is successful.
 func WithMarkAppliedOnSuccess(enabled bool) MigratorOption {
@@ -49,7 +84,12 @@ type Migrator struct {
 
 	table                string
 	locksTable           string
+	lockTTL              time.Duration
+	lockOwner            string
 	markAppliedOnSuccess bool
+
+	lockMu      sync.Mutex
+	lockRenewal *lockRenewal
 }
 
 func NewMigrator(db *bun.DB, migrations *Migrations, opts ...MigratorOption) *Migrator {
@@ -65,6 +105,9 @@ func NewMigrator(db *bun.DB, migrations *Migrations, opts ...MigratorOption) *Mi
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.lockOwner == "" {
+		m.lockOwner = defaultLockOwner()
+	}
 	return m
 }
 
@@ -407,6 +450,26 @@ func (m *Migrator) AppliedMigrations(ctx context.Context) (MigrationSlice, error
 	return ms, nil
 }
 
+// CurrentVersion returns the name of the last applied migration or an empty string
+// if no migrations have been applied. Unlike MigrationsWithStatus, it only selects
+// a single row, so it is cheap enough to be used in health checks.
+func (m *Migrator) CurrentVersion(ctx context.Context) (string, error) {
+	var name string
+	if err := m.db.NewSelect().
+		ColumnExpr("name").
+		Model((*Migration)(nil)).
+		ModelTableExpr(m.table).
+		OrderExpr("id DESC").
+		Limit(1).
+		Scan(ctx, &name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return name, nil
+}
+
 func (m *Migrator) formattedTableName(db *bun.DB) string {
 	return db.Formatter().FormatQuery(m.table)
 }
@@ -421,31 +484,164 @@ func (m *Migrator) validate() error {
 //------------------------------------------------------------------------------
 
 type migrationLock struct {
-	ID        int64  `bun:",pk,autoincrement"`
-	TableName string `bun:",unique"`
+	ID        int64     `bun:",pk,autoincrement"`
+	TableName string    `bun:",unique"`
+	Owner     string    `bun:",nullzero"`
+	ExpiresAt time.Time `bun:",nullzero"`
 }
 
+// Lock locks the migrations table. If the lock TTL is set,
+// Lock acquires the lock held by another migrator once it expires.
 func (m *Migrator) Lock(ctx context.Context) error {
 	lock := &migrationLock{
 		TableName: m.formattedTableName(m.db),
 	}
-	if _, err := m.db.NewInsert().
+
+	q := m.db.NewInsert().
 		Model(lock).
-		ModelTableExpr(m.locksTable).
-		Exec(ctx); err != nil {
+		ModelTableExpr(m.locksTable)
+
+	if m.lockTTL > 0 {
+		if err := m.checkLocksTable(ctx); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		if _, err := m.db.NewDelete().
+			Model((*migrationLock)(nil)).
+			ModelTableExpr(m.locksTable).
+			Where("? = ?", bun.Ident("table_name"), lock.TableName).
+			Where("? < ?", bun.Ident("expires_at"), now).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("migrate: can't remove expired lock: %w", err)
+		}
+
+		lock.Owner = m.lockOwner
+		lock.ExpiresAt = now.Add(m.lockTTL)
+	} else {
+		// Keep compatibility with the locks tables that don't have owner and expiry columns.
+		q = q.Column("table_name")
+	}
+
+	if _, err := q.Exec(ctx); err != nil {
 		return fmt.Errorf("migrate: migrations table is already locked (%w)", err)
 	}
+
+	if m.lockTTL > 0 {
+		m.startLockRenewal(ctx, lock.TableName)
+	}
+	return nil
+}
+
+// checkLocksTable checks that the locks table has the columns required by the lock TTL.
+func (m *Migrator) checkLocksTable(ctx context.Context) error {
+	if _, err := m.db.NewSelect().
+		TableExpr(m.locksTable).
+		// Columns are qualified, because SQLite treats unknown quoted identifiers as strings.
+		ColumnExpr("?0.?1, ?0.?2", bun.Safe(m.locksTable), bun.Ident("owner"), bun.Ident("expires_at")).
+		Where("1 = 0").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("migrate: locks table %q must have owner and expires_at columns "+
+			"to use WithLockTTL; drop the table and call Init to recreate it (%w)", m.locksTable, err)
+	}
 	return nil
 }
 
 func (m *Migrator) Unlock(ctx context.Context) error {
+	renewErr := m.stopLockRenewal()
+
 	tableName := m.formattedTableName(m.db)
-	_, err := m.db.NewDelete().
+	q := m.db.NewDelete().
 		Model((*migrationLock)(nil)).
 		ModelTableExpr(m.locksTable).
-		Where("? = ?", bun.Ident("table_name"), tableName).
-		Exec(ctx)
-	return err
+		Where("? = ?", bun.Ident("table_name"), tableName)
+	if m.lockTTL > 0 {
+		// The lock could have expired and been acquired by another migrator.
+		q = q.Where("? = ?", bun.Ident("owner"), m.lockOwner)
+	}
+	if _, err := q.Exec(ctx); err != nil {
+		return err
+	}
+	return renewErr
+}
+
+// lockRenewal extends the lock expiry in the background while the lock is held.
+type lockRenewal struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+func (m *Migrator) startLockRenewal(ctx context.Context, tableName string) {
+	ctx, cancel := context.WithCancel(ctx)
+	r := &lockRenewal{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	m.lockMu.Lock()
+	m.lockRenewal = r
+	m.lockMu.Unlock()
+
+	go func() {
+		defer close(r.done)
+		defer cancel()
+		r.err = m.renewLock(ctx, tableName)
+	}()
+}
+
+// stopLockRenewal stops the lock renewal and returns an error if the lock was lost.
+func (m *Migrator) stopLockRenewal() error {
+	m.lockMu.Lock()
+	r := m.lockRenewal
+	m.lockRenewal = nil
+	m.lockMu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	r.cancel()
+	<-r.done
+	return r.err
+}
+
+func (m *Migrator) renewLock(ctx context.Context, tableName string) error {
+	ticker := time.NewTicker(m.lockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		renewCtx, cancel := context.WithTimeout(ctx, m.lockTTL)
+		res, err := m.db.NewUpdate().
+			Model((*migrationLock)(nil)).
+			ModelTableExpr(m.locksTable).
+			Set("? = ?", bun.Ident("expires_at"), time.Now().Add(m.lockTTL)).
+			Where("? = ?", bun.Ident("table_name"), tableName).
+			Where("? = ?", bun.Ident("owner"), m.lockOwner).
+			Exec(renewCtx)
+		cancel()
+		if err != nil {
+			// Retry on the next tick: the lock is still valid until it expires.
+			continue
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return errors.New("migrate: migration lock expired and was acquired by another migrator")
+		}
+	}
+}
+
+func defaultLockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
 }
 
 func migrationMap(ms MigrationSlice) map[string]*Migration {
@@ -145,6 +145,8 @@ func (op *DropColumnOp) DependsOn(another Operation) bool {
 		return op.TableName == drop.TableName && drop.PrimaryKey.Columns.Contains(op.ColumnName)
 	case *ChangePrimaryKeyOp:
 		return op.TableName == drop.TableName && drop.Old.Columns.Contains(op.ColumnName)
+	case *DropIndexOp:
+		return op.TableName == drop.TableName && drop.Index.Columns.Contains(op.ColumnName)
 	}
 	return false
 }
@@ -249,6 +251,60 @@ func (op *DropUniqueConstraintOp) GetReverse() Operation {
 	}
 }
 
+// CreateIndexOp creates a new index on the table.
+type CreateIndexOp struct {
+	TableName string
+	Index     sqlschema.Index
+}
+
+var _ Operation = (*CreateIndexOp)(nil)
+
+func (op *CreateIndexOp) GetReverse() Operation {
+	return &DropIndexOp{
+		TableName: op.TableName,
+		Index:     op.Index,
+	}
+}
+
+func (op *CreateIndexOp) DependsOn(another Operation) bool {
+	switch another := another.(type) {
+	case *CreateTableOp:
+		return op.TableName == another.TableName
+	case *AddColumnOp:
+		return op.TableName == another.TableName && op.Index.Columns.Contains(another.ColumnName)
+	case *RenameTableOp:
+		return op.TableName == another.NewName
+	case *DropIndexOp:
+		// We want to drop the index with the same name before creating this one.
+		return op.TableName == another.TableName &&
+			op.Index.GetName(op.TableName) == another.Index.GetName(another.TableName)
+	default:
+		return false
+	}
+}
+
+// DropIndexOp drops an index.
+type DropIndexOp struct {
+	TableName string
+	Index     sqlschema.Index
+}
+
+var _ Operation = (*DropIndexOp)(nil)
+
+func (op *DropIndexOp) DependsOn(another Operation) bool {
+	if rename, ok := another.(*RenameTableOp); ok {
+		return op.TableName == rename.NewName
+	}
+	return false
+}
+
+func (op *DropIndexOp) GetReverse() Operation {
+	return &CreateIndexOp{
+		TableName: op.TableName,
+		Index:     op.Index,
+	}
+}
+
 // ChangeColumnTypeOp set a new data type for the column.
 // The two types should be such that the data can be auto-casted from one to another.
 // E.g. reducing VARCHAR lenght is not possible in most dialects.
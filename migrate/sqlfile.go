@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+type sqlFileConfig struct {
+	separator string
+}
+
+type SQLFileOption func(cfg *sqlFileConfig)
+
+// WithStatementSeparator overrides the separator used to split the SQL file
+// into statements. The default is ";".
+func WithStatementSeparator(sep string) SQLFileOption {
+	return func(cfg *sqlFileConfig) {
+		cfg.separator = sep
+	}
+}
+
+// ExecSQLFile reads the SQL file from the fsys and executes it statement by statement.
+// It is meant to be used in Go migrations that need to run large bundled SQL files.
+//
+// Separators inside string literals, quoted identifiers, comments,
+// and PostgreSQL dollar-quoted strings ($$ ... $$) do not split the statement.
+// Backslash escapes inside string literals are not recognized.
+func ExecSQLFile(
+	ctx context.Context, db bun.IConn, fsys fs.FS, name string, opts ...SQLFileOption,
+) error {
+	cfg := &sqlFileConfig{
+		separator: ";",
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range splitSQL(string(b), cfg.separator) {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitSQL splits s into statements using the sep, ignoring separators
+// inside quotes, comments, and dollar-quoted strings.
+// Statements that contain only whitespace and comments are dropped.
+func splitSQL(s, sep string) []string {
+	var queries []string
+
+	start := 0
+	hasCode := false
+
+	flush := func(end int) {
+		if hasCode {
+			queries = append(queries, strings.TrimSpace(s[start:end]))
+		}
+		hasCode = false
+	}
+
+	for i := 0; i < len(s); {
+		if sep != "" && strings.HasPrefix(s[i:], sep) {
+			flush(i)
+			i += len(sep)
+			start = i
+			continue
+		}
+
+		switch c := s[i]; {
+		case c == '-' && strings.HasPrefix(s[i:], "--"):
+			i = skipUntil(s, i+2, "\n")
+			continue
+		case c == '/' && strings.HasPrefix(s[i:], "/*"):
+			i = skipUntil(s, i+2, "*/")
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			hasCode = true
+			i = skipUntil(s, i+1, string(c))
+			continue
+		case c == '$':
+			hasCode = true
+			if tag, ok := dollarQuoteTag(s, i); ok {
+				i = skipUntil(s, i+len(tag), tag)
+				continue
+			}
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			hasCode = true
+		}
+		i++
+	}
+	flush(len(s))
+
+	return queries
+}
+
+// skipUntil returns the position right after the first occurrence of the end
+// in s[i:] or len(s) when there is none.
+func skipUntil(s string, i int, end string) int {
+	if j := strings.Index(s[i:], end); j >= 0 {
+		return i + j + len(end)
+	}
+	return len(s)
+}
+
+// dollarQuoteTag returns the dollar-quote tag such as "$$" or "$body$" starting at s[i].
+func dollarQuoteTag(s string, i int) (string, bool) {
+	if i > 0 && isIdentChar(s[i-1]) {
+		return "", false
+	}
+	for j := i + 1; j < len(s); j++ {
+		c := s[j]
+		if c == '$' {
+			return s[i : j+1], true
+		}
+		if !isIdentChar(c) || (j == i+1 && c >= '0' && c <= '9') {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
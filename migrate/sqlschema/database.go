@@ -63,6 +63,12 @@ func NewColumns(columns ...string) Columns {
 	return Columns(strings.Join(columns, ","))
 }
 
+// NewOrderedColumns creates a composite column from a slice of column names
+// preserving their order, which matters for indexes.
+func NewOrderedColumns(columns ...string) Columns {
+	return Columns(strings.Join(columns, ","))
+}
+
 func (c *Columns) String() string {
 	return string(*c)
 }
@@ -110,6 +116,19 @@ func (c *Columns) Replace(oldColumn, newColumn string) bool {
 	return false
 }
 
+// ReplaceOrdered is like Replace, but keeps the order of the columns, which matters for indexes.
+func (c *Columns) ReplaceOrdered(oldColumn, newColumn string) bool {
+	columns := c.Split()
+	for i, column := range columns {
+		if column == oldColumn {
+			columns[i] = newColumn
+			*c = NewOrderedColumns(columns...)
+			return true
+		}
+	}
+	return false
+}
+
 // Unique represents a unique constraint defined on 1 or more columns.
 type Unique struct {
 	Name    string
@@ -121,6 +140,31 @@ func (u Unique) Equals(other Unique) bool {
 	return u.Columns == other.Columns
 }
 
+// Index represents an ordinary (non-unique) index defined on 1 or more columns.
+// Columns are kept in index order.
+type Index struct {
+	Name    string
+	Columns Columns
+
+	// Where is the predicate of a partial index. Indexes declared on models are never partial.
+	Where string
+}
+
+// GetName returns the index name or, if it is empty,
+// the default name bun gives to unnamed indexes: <table>_<columns>_idx.
+func (i Index) GetName(tableName string) string {
+	if i.Name != "" {
+		return i.Name
+	}
+	return tableName + "_" + strings.Join(i.Columns.Split(), "_") + "_idx"
+}
+
+// Equals checks that two indexes are the same, assuming both are defined for the same table.
+// A partial index is never equal to a full index on the same columns.
+func (i Index) Equals(other Index) bool {
+	return i.Columns == other.Columns && i.Where == other.Where
+}
+
 type ColumnReference struct {
 	TableName string
 	Column    Columns
@@ -118,7 +118,7 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 			}
 			columns.Store(f.Name, &BaseColumn{
 				Name:            f.Name,
-				SQLType:         strings.ToLower(sqlType), // TODO(dyma): maybe this is not necessary after Column.Eq()
+				SQLType:         NormalizeType(sqlType),
 				VarcharLen:      length,
 				DefaultValue:    exprOrLiteral(f.SQLDefault),
 				IsNullable:      !f.NotNull,
@@ -146,6 +146,24 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 			unique = append(unique, Unique{Name: name, Columns: NewColumns(columns...)})
 		}
 
+		var indexes []Index
+		for name, group := range t.Indexes {
+			// Create a separate index for each column with an unnamed index and
+			// let each dialect apply the default naming convention.
+			if name == "" {
+				for _, f := range group {
+					indexes = append(indexes, Index{Columns: NewOrderedColumns(f.Name)})
+				}
+				continue
+			}
+
+			var columns []string
+			for _, f := range group {
+				columns = append(columns, f.Name)
+			}
+			indexes = append(indexes, Index{Name: name, Columns: NewOrderedColumns(columns...)})
+		}
+
 		var pk *PrimaryKey
 		if len(t.PKs) > 0 {
 			var columns []string
@@ -168,6 +186,7 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 				Name:              tableName,
 				Columns:           columns,
 				UniqueConstraints: unique,
+				Indexes:           indexes,
 				PrimaryKey:        pk,
 			},
 			Model: t.ZeroIface,
@@ -189,10 +208,15 @@ func (bmi *BunModelInspector) Inspect(ctx context.Context) (Database, error) {
 				toCols = append(toCols, f.Name)
 			}
 
+			// Tables in other schemas are referenced by their qualified name.
 			target := rel.JoinTable
+			targetName := strings.TrimPrefix(target.Name, target.Schema+".")
+			if target.Schema != bmi.SchemaName {
+				targetName = target.Schema + "." + targetName
+			}
 			state.ForeignKeys[ForeignKey{
-				From: NewColumnReference(t.Name, fromCols...),
-				To:   NewColumnReference(target.Name, toCols...),
+				From: NewColumnReference(tableName, fromCols...),
+				To:   NewColumnReference(targetName, toCols...),
 			}] = ""
 		}
 	}
@@ -211,7 +235,7 @@ func parseLen(typ string) (string, int, error) {
 	return typ[:paren], length, nil
 }
 
-// exprOrLiteral converts string to lowercase, if it does not contain a string literal 'lit'
+// exprOrLiteral normalizes the expression with NormalizeDefault, if it does not contain a string literal 'lit'
 // and trims the surrounding '' otherwise.
 // Use it to ensure that user-defined default values in the models are always comparable
 // to those returned by the database inspector, regardless of the case convention in individual drivers.
@@ -219,7 +243,7 @@ func exprOrLiteral(s string) string {
 	if strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") {
 		return strings.Trim(s, "'")
 	}
-	return strings.ToLower(s)
+	return NormalizeDefault(s)
 }
 
 // BunModelSchema is the schema state derived from bun table models.
@@ -0,0 +1,87 @@
+package sqlschema
+
+import (
+	"strings"
+)
+
+// typeSynonyms maps standard SQL type names to their shorter canonical form.
+var typeSynonyms = map[string]string{
+	"character varying":           "varchar",
+	"character":                   "char",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+	"time with time zone":         "timetz",
+	"time without time zone":      "time",
+	"int":                         "integer",
+	"int2":                        "smallint",
+	"int4":                        "integer",
+	"int8":                        "bigint",
+	"bool":                        "boolean",
+	"float4":                      "real",
+	"float8":                      "double precision",
+	"decimal":                     "numeric",
+}
+
+// defaultSynonyms maps default value expressions to their canonical form.
+var defaultSynonyms = map[string]string{
+	"now()":                   "current_timestamp",
+	"current_timestamp()":     "current_timestamp",
+	"transaction_timestamp()": "current_timestamp",
+}
+
+// NormalizeType converts SQL type name to its canonical form:
+// lowercase, with single spaces between words, and with standard aliases
+// replaced by their shorter equivalent (e.g. "CHARACTER VARYING" -> "varchar").
+//
+// Inspectors should normalize the types they return so that the types
+// reported by the database are comparable to those defined in bun models.
+func NormalizeType(typ string) string {
+	typ = strings.ToLower(strings.Join(strings.Fields(typ), " "))
+	if canonical, ok := typeSynonyms[typ]; ok {
+		return canonical
+	}
+	return typ
+}
+
+// NormalizeDefault converts default value expression to its canonical form:
+// it trims redundant enclosing parentheses, converts the expression to lowercase,
+// and replaces equivalent expressions with a common one (e.g. "NOW()" -> "current_timestamp").
+//
+// NormalizeDefault must not be used for string literals, as their case is significant.
+func NormalizeDefault(expr string) string {
+	expr = strings.TrimSpace(expr)
+	for isEnclosed(expr) {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	expr = strings.ToLower(expr)
+	if canonical, ok := defaultSynonyms[expr]; ok {
+		return canonical
+	}
+	return expr
+}
+
+// isEnclosed checks if the expression is wrapped in a pair of matching parentheses, e.g. "((0))",
+// which is how some databases report the default values.
+func isEnclosed(expr string) bool {
+	if len(expr) < 2 || expr[0] != '(' || expr[len(expr)-1] != ')' {
+		return false
+	}
+
+	var depth int
+	var inLiteral bool
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			inLiteral = !inLiteral
+		case inLiteral:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 && i != len(expr)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
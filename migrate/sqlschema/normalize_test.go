@@ -0,0 +1,52 @@
+package sqlschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeType(t *testing.T) {
+	tests := []struct {
+		inspected, model string
+	}{
+		{"character varying", "VARCHAR"},
+		{"timestamp with time zone", "TIMESTAMPTZ"},
+		{"timestamp  without time zone", "timestamp"},
+		{"integer", "INT"},
+		{"bigint", "int8"},
+		{"boolean", "BOOL"},
+		{"double precision", "float8"},
+		{"numeric", "DECIMAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inspected, func(t *testing.T) {
+			require.Equal(t, NormalizeType(tt.inspected), NormalizeType(tt.model))
+		})
+	}
+
+	require.NotEqual(t, NormalizeType("timestamp"), NormalizeType("timestamptz"))
+}
+
+func TestNormalizeDefault(t *testing.T) {
+	tests := []struct {
+		inspected, model string
+	}{
+		{"CURRENT_TIMESTAMP", "current_timestamp"},
+		{"now()", "CURRENT_TIMESTAMP"},
+		{"current_timestamp()", "NOW()"},
+		{"((0))", "0"},
+		{"(gen_random_uuid())", "gen_random_uuid()"},
+		{" random() ", "RANDOM()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inspected, func(t *testing.T) {
+			require.Equal(t, NormalizeDefault(tt.inspected), NormalizeDefault(tt.model))
+		})
+	}
+
+	require.Equal(t, "(1) + (2)", NormalizeDefault("(1) + (2)"))
+	require.Equal(t, "concat('(', ')')", NormalizeDefault("(concat('(', ')'))"))
+}
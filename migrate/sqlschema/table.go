@@ -10,6 +10,7 @@ type Table interface {
 	GetColumns() *ordered.Map[string, Column]
 	GetPrimaryKey() *PrimaryKey
 	GetUniqueConstraints() []Unique
+	GetIndexes() []Index
 }
 
 var _ Table = (*BaseTable)(nil)
@@ -31,6 +32,9 @@ type BaseTable struct {
 
 	// UniqueConstraints defined on the table.
 	UniqueConstraints []Unique
+
+	// Indexes defined on the table. Indexes backing PRIMARY KEY and UNIQUE constraints are not included.
+	Indexes []Index
 }
 
 // PrimaryKey represents a primary key constraint defined on 1 or more columns.
@@ -58,3 +62,7 @@ func (td *BaseTable) GetPrimaryKey() *PrimaryKey {
 func (td *BaseTable) GetUniqueConstraints() []Unique {
 	return td.UniqueConstraints
 }
+
+func (td *BaseTable) GetIndexes() []Index {
+	return td.Indexes
+}
@@ -11,19 +11,72 @@ import (
 	"github.com/uptrace/bun/schema"
 )
 
-var errNilModel = errors.New("bun: Model(nil)")
+var (
+	// ErrNilModel is returned when the query model is nil.
+	ErrNilModel = errors.New("bun: Model(nil)")
+	// ErrModelNotPointer is returned when the query model or the Scan destination
+	// is not a pointer. The returned errors wrap it and include the type.
+	ErrModelNotPointer = errors.New("bun: model is not a pointer")
+)
+
+// modelNotPointerError keeps the original error message that includes the type.
+type modelNotPointerError struct {
+	msg string
+}
+
+func (e *modelNotPointerError) Error() string {
+	return e.msg
+}
+
+func (e *modelNotPointerError) Unwrap() error {
+	return ErrModelNotPointer
+}
 
 var (
-	timeType  = reflect.TypeFor[time.Time]()
-	bytesType = reflect.TypeFor[[]byte]()
+	timeType    = reflect.TypeFor[time.Time]()
+	bytesType   = reflect.TypeFor[[]byte]()
+	scannerType = reflect.TypeFor[sql.Scanner]()
 )
 
 type Model = schema.Model
 
+// checkNullScan returns an error if the db scans NULL strictly and typ can't hold NULL.
+func checkNullScan(db *DB, typ reflect.Type, src interface{}) error {
+	if src != nil || !db.flags.Has(strictNullScan) || isNullable(typ) {
+		return nil
+	}
+	return fmt.Errorf("bun: can't scan NULL into %s", typ)
+}
+
+func isNullable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	return reflect.PointerTo(typ).Implements(scannerType)
+}
+
 type rowScanner interface {
 	ScanRow(ctx context.Context, rows *sql.Rows) error
 }
 
+// rowLimiter is embedded by the models that scan multiple rows to support SelectQuery.MaxRows.
+type rowLimiter struct {
+	maxRows int
+}
+
+func (l *rowLimiter) setMaxRows(n int) {
+	l.maxRows = n
+}
+
+// checkRow is called before scanning each row with the number of rows scanned so far.
+func (l *rowLimiter) checkRow(numRow int) error {
+	if l.maxRows > 0 && numRow >= l.maxRows {
+		return fmt.Errorf("bun: query returned more than %d rows (MaxRows)", l.maxRows)
+	}
+	return nil
+}
+
 type TableModel interface {
 	Model
 
@@ -45,6 +98,25 @@ type TableModel interface {
 	mount(reflect.Value)
 
 	updateSoftDeleteField(time.Time) error
+	updateTimestamps(tm time.Time, isInsert bool)
+	setDiscardUnknownColumns()
+	scanPolymorphic() error
+	validate(ctx context.Context, query Query) error
+}
+
+// ValidationError is returned when ValidateHook fails for an element of a slice model.
+type ValidationError struct {
+	// Index of the slice element that failed validation.
+	Index int
+	Err   error
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("bun: validation failed for element %d: %s", err.Index, err.Err)
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
 }
 
 func newModel(db *DB, dest []interface{}) (Model, error) {
@@ -57,7 +129,7 @@ func newModel(db *DB, dest []interface{}) (Model, error) {
 	for i, el := range dest {
 		v := reflect.ValueOf(el)
 		if v.Kind() != reflect.Ptr {
-			return nil, fmt.Errorf("bun: Scan(non-pointer %T)", dest)
+			return nil, &modelNotPointerError{msg: fmt.Sprintf("bun: Scan(non-pointer %T)", dest)}
 		}
 
 		v = v.Elem()
@@ -78,22 +150,23 @@ func newSingleModel(db *DB, dest interface{}) (Model, error) {
 func _newModel(db *DB, dest interface{}, scan bool) (Model, error) {
 	switch dest := dest.(type) {
 	case nil:
-		return nil, errNilModel
+		return nil, ErrNilModel
 	case Model:
 		return dest, nil
 	case sql.Scanner:
 		if !scan {
 			return nil, fmt.Errorf("bun: Model(unsupported %T)", dest)
 		}
+		// The whole row is scanned by the type, for example, a struct that scans a composite value.
 		return newScanModel(db, []interface{}{dest}), nil
 	}
 
 	v := reflect.ValueOf(dest)
 	if !v.IsValid() {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 	if v.Kind() != reflect.Ptr {
-		return nil, fmt.Errorf("bun: Model(non-pointer %T)", dest)
+		return nil, &modelNotPointerError{msg: fmt.Sprintf("bun: Model(non-pointer %T)", dest)}
 	}
 
 	if v.IsNil() {
@@ -115,6 +188,10 @@ func _newModel(db *DB, dest interface{}, scan bool) (Model, error) {
 	switch v.Kind() {
 	case reflect.Map:
 		if err := validMap(typ); err != nil {
+			if scan {
+				// Two columns are scanned as key-value pairs, e.g. into map[string]int.
+				return newKeyValueMapModel(db, v), nil
+			}
 			return nil, err
 		}
 		mapPtr := v.Addr().Interface().(*map[string]interface{})
@@ -124,7 +201,9 @@ func _newModel(db *DB, dest interface{}, scan bool) (Model, error) {
 	case reflect.Slice:
 		switch elemType := sliceElemType(v); elemType.Kind() {
 		case reflect.Struct:
-			if elemType != timeType {
+			// Scan(ctx, &rows) scans each row into an element that implements sql.Scanner,
+			// but models always use the table model.
+			if elemType != timeType && !(scan && reflect.PointerTo(elemType).Implements(scannerType)) {
 				return newSliceTableModel(db, dest, v, elemType), nil
 			}
 		case reflect.Map:
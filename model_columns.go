@@ -0,0 +1,128 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+)
+
+// columnsModel scans rows into a slice of values, preserving the order of the columns.
+type columnsModel struct {
+	mapModel
+	rowLimiter
+
+	dest *[][]interface{}
+	row  []interface{}
+}
+
+var _ Model = (*columnsModel)(nil)
+
+func newColumnsModel(db *DB, dest *[][]interface{}) *columnsModel {
+	return &columnsModel{
+		mapModel: mapModel{
+			db: db,
+		},
+		dest: dest,
+	}
+}
+
+func (m *columnsModel) Value() interface{} {
+	return m.dest
+}
+
+func (m *columnsModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	m.rows = rows
+	m.columns = columns
+	dest := makeDest(m, len(columns))
+
+	slice := *m.dest
+	if len(slice) > 0 {
+		slice = slice[:0]
+	}
+
+	var n int
+
+	for rows.Next() {
+		if err := m.checkRow(n); err != nil {
+			return 0, err
+		}
+
+		m.row = make([]interface{}, len(m.columns))
+
+		m.scanIndex = 0
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+
+		slice = append(slice, m.row)
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	*m.dest = slice
+	return n, nil
+}
+
+func (m *columnsModel) Scan(src interface{}) error {
+	v, err := m.scanValue(src)
+	if err != nil {
+		return err
+	}
+	m.row[m.scanIndex] = v
+	m.scanIndex++
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ColumnType describes a result column as reported by the driver.
+type ColumnType struct {
+	Name string
+	// DatabaseType is the database type name, e.g. "VARCHAR" or "INT4".
+	// It is empty if the driver does not report it.
+	DatabaseType string
+	// Nullable reports whether the column may be NULL.
+	// It is false if the driver does not report it.
+	Nullable bool
+	// ScanType is a Go type suitable for scanning the column values.
+	ScanType reflect.Type
+}
+
+// columnTypesModel reads the result column types without scanning the rows.
+type columnTypesModel struct {
+	dest *[]ColumnType
+}
+
+var _ Model = (*columnTypesModel)(nil)
+
+func (m *columnTypesModel) Value() interface{} {
+	return m.dest
+}
+
+func (m *columnTypesModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	types := make([]ColumnType, len(columnTypes))
+	for i, ct := range columnTypes {
+		nullable, _ := ct.Nullable()
+		types[i] = ColumnType{
+			Name:         ct.Name(),
+			DatabaseType: ct.DatabaseTypeName(),
+			Nullable:     nullable,
+			ScanType:     ct.ScanType(),
+		}
+	}
+
+	*m.dest = types
+	return 0, nil
+}
@@ -68,33 +68,41 @@ func (m *mapModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 }
 
 func (m *mapModel) Scan(src interface{}) error {
+	v, err := m.scanValue(src)
+	if err != nil {
+		return err
+	}
+	return m.scanRaw(v)
+}
+
+// scanValue converts src to the Go type reported by the driver for the current column.
+func (m *mapModel) scanValue(src interface{}) (interface{}, error) {
 	if _, ok := src.([]byte); !ok {
-		return m.scanRaw(src)
+		return src, nil
 	}
 
 	columnTypes, err := m.columnTypes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	scanType := columnTypes[m.scanIndex].ScanType()
 	switch scanType.Kind() {
 	case reflect.Interface:
-		return m.scanRaw(src)
+		return src, nil
 	case reflect.Slice:
 		if scanType.Elem().Kind() == reflect.Uint8 {
 			// Reference types such as []byte are only valid until the next call to Scan.
-			src := bytes.Clone(src.([]byte))
-			return m.scanRaw(src)
+			return bytes.Clone(src.([]byte)), nil
 		}
 	}
 
 	dest := reflect.New(scanType).Elem()
 	if err := schema.Scanner(scanType)(dest, src); err != nil {
-		return err
+		return nil, err
 	}
 
-	return m.scanRaw(dest.Interface())
+	return dest.Interface(), nil
 }
 
 func (m *mapModel) columnTypes() ([]*sql.ColumnType, error) {
@@ -0,0 +1,87 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// keyValueMapModel scans a two-column result into a map using the first column as the key
+// and the second column as the value, for example, map[string]int from a GROUP BY query.
+type keyValueMapModel struct {
+	rowLimiter
+
+	dest reflect.Value
+
+	key       reflect.Value
+	value     reflect.Value
+	scanKey   schema.ScannerFunc
+	scanValue schema.ScannerFunc
+	scanIndex int
+}
+
+var _ Model = (*keyValueMapModel)(nil)
+
+func newKeyValueMapModel(db *DB, dest reflect.Value) *keyValueMapModel {
+	typ := dest.Type()
+	return &keyValueMapModel{
+		dest:      dest,
+		scanKey:   schema.Scanner(typ.Key()),
+		scanValue: schema.Scanner(typ.Elem()),
+	}
+}
+
+func (m *keyValueMapModel) Value() interface{} {
+	return m.dest.Addr().Interface()
+}
+
+func (m *keyValueMapModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) != 2 {
+		return 0, fmt.Errorf("bun: Scan(%s) requires 2 columns (key and value), got %d",
+			m.dest.Type(), len(columns))
+	}
+
+	if m.dest.IsNil() {
+		m.dest.Set(reflect.MakeMap(m.dest.Type()))
+	}
+
+	typ := m.dest.Type()
+	dest := makeDest(m, len(columns))
+
+	var n int
+
+	for rows.Next() {
+		if err := m.checkRow(n); err != nil {
+			return 0, err
+		}
+
+		m.key = reflect.New(typ.Key()).Elem()
+		m.value = reflect.New(typ.Elem()).Elem()
+		m.scanIndex = 0
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		m.dest.SetMapIndex(m.key, m.value)
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (m *keyValueMapModel) Scan(src interface{}) error {
+	m.scanIndex++
+	if m.scanIndex == 1 {
+		return m.scanKey(m.key, src)
+	}
+	return m.scanValue(m.value, src)
+}
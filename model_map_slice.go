@@ -12,6 +12,7 @@ import (
 
 type mapSliceModel struct {
 	mapModel
+	rowLimiter
 	dest *[]map[string]interface{}
 
 	keys []string
@@ -59,6 +60,10 @@ func (m *mapSliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, erro
 	var n int
 
 	for rows.Next() {
+		if err := m.checkRow(n); err != nil {
+			return 0, err
+		}
+
 		m.m = make(map[string]interface{}, len(m.columns))
 
 		m.scanIndex = 0
@@ -51,6 +51,12 @@ func (m *scanModel) Scan(src interface{}) error {
 	dest := reflect.ValueOf(m.dest[m.scanIndex])
 	m.scanIndex++
 
+	if dest.Kind() == reflect.Ptr {
+		if err := checkNullScan(m.db, dest.Type().Elem(), src); err != nil {
+			return err
+		}
+	}
+
 	scanner := schema.Scanner(dest.Type())
 	return scanner(dest, src)
 }
@@ -12,9 +12,14 @@ import (
 type sliceInfo struct {
 	nextElem func() reflect.Value
 	scan     schema.ScannerFunc
+	elemType reflect.Type
 }
 
 type sliceModel struct {
+	rowLimiter
+
+	db *DB
+
 	dest      []interface{}
 	values    []reflect.Value
 	scanIndex int
@@ -25,6 +30,7 @@ var _ Model = (*sliceModel)(nil)
 
 func newSliceModel(db *DB, dest []interface{}, values []reflect.Value) *sliceModel {
 	return &sliceModel{
+		db:     db,
 		dest:   dest,
 		values: values,
 	}
@@ -49,6 +55,7 @@ func (m *sliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error)
 		m.info[i] = sliceInfo{
 			nextElem: internal.MakeSliceNextElemFunc(v),
 			scan:     schema.Scanner(v.Type().Elem()),
+			elemType: v.Type().Elem(),
 		}
 	}
 
@@ -60,6 +67,10 @@ func (m *sliceModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error)
 	var n int
 
 	for rows.Next() {
+		if err := m.checkRow(n); err != nil {
+			return 0, err
+		}
+
 		m.scanIndex = 0
 		if err := rows.Scan(dest...); err != nil {
 			return 0, err
@@ -77,6 +88,10 @@ func (m *sliceModel) Scan(src interface{}) error {
 	info := m.info[m.scanIndex]
 	m.scanIndex++
 
+	if err := checkNullScan(m.db, info.elemType, src); err != nil {
+		return err
+	}
+
 	dest := info.nextElem()
 	return info.scan(dest, src)
 }
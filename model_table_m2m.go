@@ -66,6 +66,10 @@ func (m *m2mModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 			return 0, err
 		}
 
+		if err := m.scanPolymorphic(); err != nil {
+			return 0, err
+		}
+
 		if err := m.parkStruct(); err != nil {
 			return 0, err
 		}
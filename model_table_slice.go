@@ -12,6 +12,7 @@ import (
 
 type sliceTableModel struct {
 	structTableModel
+	rowLimiter
 
 	slice      reflect.Value
 	sliceLen   int
@@ -57,6 +58,10 @@ func (m *sliceTableModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, er
 		return 0, err
 	}
 
+	if err := m.checkPositional(columns); err != nil {
+		return 0, err
+	}
+
 	m.columns = columns
 	dest := makeDest(m, len(columns))
 
@@ -67,6 +72,10 @@ func (m *sliceTableModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, er
 	var n int
 
 	for rows.Next() {
+		if err := m.checkRow(n); err != nil {
+			return 0, err
+		}
+
 		m.strct = m.nextElem()
 		if m.sliceOfPtr {
 			m.strct = m.strct.Elem()
@@ -107,6 +116,24 @@ func (m *sliceTableModel) BeforeAppendModel(ctx context.Context, query Query) er
 	return nil
 }
 
+func (m *sliceTableModel) validate(ctx context.Context, query Query) error {
+	if !m.table.HasValidateHook() || !m.slice.IsValid() {
+		return nil
+	}
+
+	sliceLen := m.slice.Len()
+	for i := 0; i < sliceLen; i++ {
+		strct := m.slice.Index(i)
+		if !m.sliceOfPtr {
+			strct = strct.Addr()
+		}
+		if err := strct.Interface().(schema.ValidateHook).Validate(ctx, query); err != nil {
+			return &ValidationError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
 // Inherit these hooks from structTableModel.
 var (
 	_ schema.BeforeScanRowHook = (*sliceTableModel)(nil)
@@ -124,3 +151,10 @@ func (m *sliceTableModel) updateSoftDeleteField(tm time.Time) error {
 	}
 	return nil
 }
+
+func (m *sliceTableModel) updateTimestamps(tm time.Time, isInsert bool) {
+	sliceLen := m.slice.Len()
+	for i := 0; i < sliceLen; i++ {
+		m.table.UpdateTimestamps(indirect(m.slice.Index(i)), tm, isInsert)
+	}
+}
@@ -1,6 +1,7 @@
 package bun
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/uptrace/bun/extra/bunjson"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
 )
@@ -29,6 +31,22 @@ type structTableModel struct {
 
 	columns   []string
 	scanIndex int
+
+	// positional maps columns to the table fields by index instead of by name.
+	positional bool
+
+	discardUnknownColumns bool
+	// relationJSON is set when the query selects relations with SelectQuery.RelationJSON.
+	relationJSON bool
+
+	// polymorphic holds the payloads of the discriminator fields
+	// that are scanned after the rest of the row.
+	polymorphic []polymorphicSrc
+}
+
+type polymorphicSrc struct {
+	field *schema.Field
+	src   []byte
 }
 
 var _ TableModel = (*structTableModel)(nil)
@@ -71,7 +89,7 @@ func (m *structTableModel) initStruct() error {
 
 	switch m.strct.Kind() {
 	case reflect.Invalid:
-		m.structInitErr = errNilModel
+		m.structInitErr = ErrNilModel
 		return m.structInitErr
 	case reflect.Interface:
 		m.strct = m.strct.Elem()
@@ -110,6 +128,13 @@ func (m *structTableModel) BeforeAppendModel(ctx context.Context, query Query) e
 	return m.strct.Addr().Interface().(schema.BeforeAppendModelHook).BeforeAppendModel(ctx, query)
 }
 
+func (m *structTableModel) validate(ctx context.Context, query Query) error {
+	if !m.table.HasValidateHook() || !m.strct.IsValid() {
+		return nil
+	}
+	return m.strct.Addr().Interface().(schema.ValidateHook).Validate(ctx, query)
+}
+
 var _ schema.BeforeScanRowHook = (*structTableModel)(nil)
 
 func (m *structTableModel) BeforeScanRow(ctx context.Context) error {
@@ -229,6 +254,27 @@ func (m *structTableModel) updateSoftDeleteField(tm time.Time) error {
 	return m.table.UpdateSoftDeleteField(fv, tm)
 }
 
+// setRelationJSON makes the model look up the unknown columns in the relations
+// selected with SelectQuery.RelationJSON.
+func (m *structTableModel) setRelationJSON() {
+	m.relationJSON = true
+}
+
+// setDiscardUnknownColumns makes the model and its joins ignore unknown columns.
+func (m *structTableModel) setDiscardUnknownColumns() {
+	m.discardUnknownColumns = true
+	for i := range m.joins {
+		m.joins[i].JoinModel.setDiscardUnknownColumns()
+	}
+}
+
+func (m *structTableModel) updateTimestamps(tm time.Time, isInsert bool) {
+	if !m.strct.IsValid() {
+		return
+	}
+	m.table.UpdateTimestamps(m.strct, tm, isInsert)
+}
+
 func (m *structTableModel) ScanRows(ctx context.Context, rows *sql.Rows) (int, error) {
 	if !rows.Next() {
 		return 0, rows.Err()
@@ -260,12 +306,24 @@ func (m *structTableModel) ScanRow(ctx context.Context, rows *sql.Rows) error {
 		return err
 	}
 
+	if err := m.checkPositional(columns); err != nil {
+		return err
+	}
+
 	m.columns = columns
 	dest := makeDest(m, len(columns))
 
 	return m.scanRow(ctx, rows, dest)
 }
 
+func (m *structTableModel) checkPositional(columns []string) error {
+	if m.positional && len(columns) != len(m.table.Fields) {
+		return fmt.Errorf("bun: ScanPositional got %d columns, but %s has %d fields",
+			len(columns), m.table.TypeName, len(m.table.Fields))
+	}
+	return nil
+}
+
 func (m *structTableModel) scanRow(ctx context.Context, rows *sql.Rows, dest []interface{}) error {
 	if err := m.BeforeScanRow(ctx); err != nil {
 		return err
@@ -276,6 +334,10 @@ func (m *structTableModel) scanRow(ctx context.Context, rows *sql.Rows, dest []i
 		return err
 	}
 
+	if err := m.scanPolymorphic(); err != nil {
+		return err
+	}
+
 	if err := m.AfterScanRow(ctx); err != nil {
 		return err
 	}
@@ -284,6 +346,12 @@ func (m *structTableModel) scanRow(ctx context.Context, rows *sql.Rows, dest []i
 }
 
 func (m *structTableModel) Scan(src interface{}) error {
+	if m.positional {
+		field := m.table.Fields[m.scanIndex]
+		m.scanIndex++
+		return m.scanField(field, src)
+	}
+
 	column := m.columns[m.scanIndex]
 	m.scanIndex++
 
@@ -294,26 +362,24 @@ func (m *structTableModel) ScanColumn(column string, src interface{}) error {
 	if ok, err := m.scanColumn(column, src); ok {
 		return err
 	}
-	if column == "" || column[0] == '_' || m.db.flags.Has(discardUnknownColumns) {
+	if column == "" || column[0] == '_' ||
+		m.discardUnknownColumns || m.db.flags.Has(discardUnknownColumns) {
 		return nil
 	}
 	return fmt.Errorf("bun: %s does not have column %q", m.table.TypeName, column)
 }
 
 func (m *structTableModel) scanColumn(column string, src interface{}) (bool, error) {
+	if field := m.table.LookupField(column); field != nil {
+		return true, m.scanField(field, src)
+	}
+
 	if src != nil {
 		if err := m.initStruct(); err != nil {
 			return true, err
 		}
 	}
 
-	if field := m.table.LookupField(column); field != nil {
-		if src == nil && m.isNil() {
-			return true, nil
-		}
-		return true, field.ScanValue(m.strct, src)
-	}
-
 	if joinName, column := splitColumn(column); joinName != "" {
 		if join := m.getJoin(joinName); join != nil {
 			return true, join.JoinModel.ScanColumn(column, src)
@@ -324,9 +390,121 @@ func (m *structTableModel) scanColumn(column string, src interface{}) (bool, err
 		}
 	}
 
+	// Relations selected with SelectQuery.RelationJSON.
+	if m.relationJSON {
+		if rel := relationByName(m.table, column); rel != nil {
+			return true, scanRelationJSON(m.db, m.strct, rel, src)
+		}
+	}
+
 	return false, nil
 }
 
+func (m *structTableModel) scanField(field *schema.Field, src interface{}) error {
+	if src != nil {
+		if err := m.initStruct(); err != nil {
+			return err
+		}
+	}
+	if src == nil && m.isNil() {
+		return nil
+	}
+	if src == nil && m.db.flags.Has(strictNullScan) &&
+		m.rel == nil && !field.NullZero && !isNullable(field.StructField.Type) {
+		return fmt.Errorf("bun: can't scan NULL into %s.%s (%s)",
+			m.table.TypeName, field.GoName, field.StructField.Type)
+	}
+	if field.Discriminator != "" && src != nil {
+		// The concrete type depends on the discriminator column,
+		// which may not be scanned yet.
+		b, err := polymorphicBytes(src)
+		if err != nil {
+			return err
+		}
+		m.polymorphic = append(m.polymorphic, polymorphicSrc{field: field, src: b})
+		return nil
+	}
+	return field.ScanValue(m.strct, src)
+}
+
+// scanPolymorphic scans the discriminator fields of the model and its joins
+// using the types registered with DB.RegisterPolymorphic.
+func (m *structTableModel) scanPolymorphic() error {
+	for _, p := range m.polymorphic {
+		if err := m.scanPolymorphicField(p.field, p.src); err != nil {
+			return err
+		}
+	}
+	m.polymorphic = m.polymorphic[:0]
+
+	for i := range m.joins {
+		j := &m.joins[i]
+		switch j.Relation.Type {
+		case schema.HasOneRelation, schema.BelongsToRelation:
+			if err := j.JoinModel.scanPolymorphic(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *structTableModel) scanPolymorphicField(field *schema.Field, src []byte) error {
+	if string(src) == "null" {
+		// Nil interfaces are marshaled as JSON null.
+		fv := field.Value(m.strct)
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	discr := m.table.LookupField(field.Discriminator)
+	if discr == nil {
+		return fmt.Errorf("bun: %s does not have discriminator column %q",
+			m.table.TypeName, field.Discriminator)
+	}
+
+	var value string
+	if v := reflect.Indirect(discr.Value(m.strct)); v.IsValid() {
+		value = fmt.Sprint(v.Interface())
+	}
+
+	typ, ok := m.db.polymorphicType(field.Discriminator, value)
+	if !ok {
+		return fmt.Errorf("bun: %s.%s: no type is registered for %s=%q",
+			m.table.TypeName, field.GoName, field.Discriminator, value)
+	}
+
+	fv := field.Value(m.strct)
+	if !typ.AssignableTo(fv.Type()) {
+		return fmt.Errorf("bun: %s.%s: %s is not assignable to %s",
+			m.table.TypeName, field.GoName, typ, fv.Type())
+	}
+
+	v := reflect.New(typ)
+	if typ.Kind() == reflect.Ptr {
+		v.Elem().Set(reflect.New(typ.Elem()))
+		if err := bunjson.Unmarshal(src, v.Elem().Interface()); err != nil {
+			return err
+		}
+	} else if err := bunjson.Unmarshal(src, v.Interface()); err != nil {
+		return err
+	}
+	fv.Set(v.Elem())
+	return nil
+}
+
+// polymorphicBytes copies the src, because drivers may reuse the buffer for the next row.
+func polymorphicBytes(src interface{}) ([]byte, error) {
+	switch src := src.(type) {
+	case []byte:
+		return bytes.Clone(src), nil
+	case string:
+		return []byte(src), nil
+	default:
+		return nil, fmt.Errorf("bun: can't scan %T into a discriminator field", src)
+	}
+}
+
 func (m *structTableModel) isNil() bool {
 	return m.strct.Kind() == reflect.Ptr && m.strct.IsNil()
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/uptrace/bun/dialect"
@@ -20,6 +21,8 @@ const (
 	forceDeleteFlag internal.Flag = 1 << iota
 	deletedFlag
 	allWithDeletedFlag
+	withoutHooksFlag
+	deleteWherePKFlag
 )
 
 type withQuery struct {
@@ -63,6 +66,10 @@ type IDB interface {
 	NewTruncateTable() *TruncateTableQuery
 	NewAddColumn() *AddColumnQuery
 	NewDropColumn() *DropColumnQuery
+	NewAlterColumn() *AlterColumnQuery
+	NewCreateView() *CreateViewQuery
+	NewDropView() *DropViewQuery
+	NewCreateMaterializedView() *CreateMaterializedViewQuery
 
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
 	RunInTx(ctx context.Context, opts *sql.TxOptions, f func(ctx context.Context, tx Tx) error) error
@@ -79,7 +86,11 @@ type QueryBuilder interface {
 	Query
 	Where(query string, args ...interface{}) QueryBuilder
 	WhereGroup(sep string, fn func(QueryBuilder) QueryBuilder) QueryBuilder
+	WhereOrGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder
+	WhereAndGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder
 	WhereOr(query string, args ...interface{}) QueryBuilder
+	WhereIf(cond bool, query string, args ...interface{}) QueryBuilder
+	WhereOrIf(cond bool, query string, args ...interface{}) QueryBuilder
 	WhereDeleted() QueryBuilder
 	WhereAllWithDeleted() QueryBuilder
 	WherePK(cols ...string) QueryBuilder
@@ -92,6 +103,14 @@ var (
 	_ QueryBuilder = (*deleteQueryBuilder)(nil)
 )
 
+// Scope is a reusable set of query modifiers, for example, a tenant filter,
+// that can be attached to select, update, and delete queries using WithScope.
+type Scope interface {
+	ApplySelect(*SelectQuery)
+	ApplyUpdate(*UpdateQuery)
+	ApplyDelete(*DeleteQuery)
+}
+
 type baseQuery struct {
 	db   *DB
 	conn IConn
@@ -101,6 +120,8 @@ type baseQuery struct {
 
 	tableModel TableModel
 	table      *schema.Table
+	tableAlias schema.Safe
+	tableName  string
 
 	with           []withQuery
 	modelTableName schema.QueryWithArgs
@@ -108,12 +129,41 @@ type baseQuery struct {
 	columns        []schema.QueryWithArgs
 
 	flags internal.Flag
+
+	// compiled holds the query string sent to the database by the last execution.
+	// Copies of the query, e.g. insert batches and delete chunks, report it back
+	// to the original query with setCompiledQuery.
+	compiled atomic.Value
 }
 
 func (q *baseQuery) DB() *DB {
 	return q.db
 }
 
+// reset clears the query state, but keeps the DB and the connection.
+// Slices are truncated to reuse their memory unless nil has a meaning of its own.
+func (q *baseQuery) reset() {
+	q.model = nil
+	q.err = nil
+	q.tableModel = nil
+	q.table = nil
+	q.tableAlias = ""
+	q.tableName = ""
+	q.with = resetSlice(q.with)
+	q.modelTableName = schema.QueryWithArgs{}
+	q.tables = resetSlice(q.tables)
+	// nil columns select all model columns.
+	q.columns = nil
+	q.flags = 0
+	q.setCompiledQuery("")
+}
+
+// resetSlice truncates the slice and clears the elements, so they can be garbage collected.
+func resetSlice[T any](s []T) []T {
+	clear(s)
+	return s[:0]
+}
+
 func (q *baseQuery) resolveConn(query Query) IConn {
 	if q.conn != nil {
 		return q.conn
@@ -126,11 +176,51 @@ func (q *baseQuery) resolveConn(query Query) IConn {
 	return q.db.DB
 }
 
+// CompiledQuery returns the SQL that was sent to the database when the query was last executed,
+// including the interpolated arguments, or an empty string if the query has not been executed yet.
+// Unlike String, it reflects the changes made while executing the query, e.g. by BeforeAppendModel.
+// It is the same string query hooks receive in QueryEvent.Query.
+func (q *baseQuery) CompiledQuery() string {
+	query, _ := q.compiled.Load().(string)
+	return query
+}
+
+func (q *baseQuery) setCompiledQuery(query string) {
+	q.compiled.Store(query)
+}
+
 func (q *baseQuery) GetModel() Model {
 	return q.model
 }
 
+// sqlAlias returns the table alias set with TableAlias or the model table alias.
+func (q *baseQuery) sqlAlias() schema.Safe {
+	if q.tableAlias != "" {
+		return q.tableAlias
+	}
+	return q.table.SQLAlias
+}
+
+// sqlTableName returns the table name set with WithTableName or the model table name.
+func (q *baseQuery) sqlTableName() schema.Safe {
+	if q.tableName != "" {
+		return schema.Safe(q.db.fmter.AppendIdent(nil, q.tableName))
+	}
+	return q.table.SQLName
+}
+
+// sqlTableNameForSelects is like sqlTableName, but uses the model `select` table name.
+func (q *baseQuery) sqlTableNameForSelects() schema.Safe {
+	if q.tableName != "" {
+		return q.sqlTableName()
+	}
+	return q.table.SQLNameForSelects
+}
+
 func (q *baseQuery) GetTableName() string {
+	if q.tableName != "" {
+		return q.tableName
+	}
 	if q.table != nil {
 		return q.table.Name
 	}
@@ -155,6 +245,11 @@ func (q *baseQuery) GetTableName() string {
 	return ""
 }
 
+// hooksDisabled reports whether query hooks are disabled with WithoutHooks.
+func (q *baseQuery) hooksDisabled() bool {
+	return q.flags.Has(withoutHooksFlag)
+}
+
 func (q *baseQuery) setConn(db IConn) {
 	// Unwrap Bun wrappers to not call query hooks twice.
 	switch db := db.(type) {
@@ -196,7 +291,7 @@ func (q *baseQuery) getModel(dest []interface{}) (Model, error) {
 	if q.model != nil {
 		return q.model, nil
 	}
-	return nil, errNilModel
+	return nil, ErrNilModel
 }
 
 func (q *baseQuery) beforeAppendModel(ctx context.Context, query Query) error {
@@ -206,6 +301,21 @@ func (q *baseQuery) beforeAppendModel(ctx context.Context, query Query) error {
 	return nil
 }
 
+// updateTimestamps populates the fields marked with the created_at and updated_at tag options.
+func (q *baseQuery) updateTimestamps(isInsert bool) {
+	if q.table == nil || (q.table.CreatedAtField == nil && q.table.UpdatedAtField == nil) {
+		return
+	}
+	q.tableModel.updateTimestamps(time.Now(), isInsert)
+}
+
+func (q *baseQuery) validate(ctx context.Context, query Query) error {
+	if q.tableModel != nil {
+		return q.tableModel.validate(ctx, query)
+	}
+	return nil
+}
+
 func (q *baseQuery) hasFeature(feature feature.Feature) bool {
 	return q.db.HasFeature(feature)
 }
@@ -353,9 +463,30 @@ func (q *baseQuery) addColumn(column schema.QueryWithArgs) {
 	q.columns = append(q.columns, column)
 }
 
+// dialectExpr returns the query registered for the current dialect
+// or sets an error if there is none.
+func (q *baseQuery) dialectExpr(
+	exprs map[dialect.Name]string, args []interface{},
+) (schema.QueryWithArgs, bool) {
+	query, err := dialectQuery(exprs, q.db.Dialect().Name())
+	if err != nil {
+		q.setErr(err)
+		return schema.QueryWithArgs{}, false
+	}
+	return schema.SafeQuery(query, args), true
+}
+
+func dialectQuery(exprs map[dialect.Name]string, name dialect.Name) (string, error) {
+	query, ok := exprs[name]
+	if !ok {
+		return "", fmt.Errorf("bun: DialectExpr has no query for %s dialect", name)
+	}
+	return query, nil
+}
+
 func (q *baseQuery) excludeColumn(columns []string) {
 	if q.table == nil {
-		q.setErr(errNilModel)
+		q.setErr(ErrNilModel)
 		return
 	}
 
@@ -425,14 +556,15 @@ func (q *baseQuery) _appendTables(
 				return nil, err
 			}
 		} else {
-			b = fmter.AppendQuery(b, string(q.table.SQLNameForSelects))
-			if withAlias && q.table.SQLAlias != q.table.SQLNameForSelects {
+			tableName := q.sqlTableNameForSelects()
+			b = fmter.AppendQuery(b, string(tableName))
+			if alias := q.sqlAlias(); withAlias && alias != tableName {
 				if q.db.dialect.Name() == dialect.Oracle {
 					b = append(b, ' ')
 				} else {
 					b = append(b, " AS "...)
 				}
-				b = append(b, q.table.SQLAlias...)
+				b = append(b, alias...)
 			}
 		}
 	}
@@ -468,10 +600,10 @@ func (q *baseQuery) _appendFirstTable(
 	}
 
 	if q.table != nil {
-		b = fmter.AppendQuery(b, string(q.table.SQLName))
+		b = fmter.AppendQuery(b, string(q.sqlTableName()))
 		if withAlias {
 			b = append(b, " AS "...)
-			b = append(b, q.table.SQLAlias...)
+			b = append(b, q.sqlAlias()...)
 		}
 		return b, nil
 	}
@@ -525,7 +657,7 @@ func (q *baseQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte, e
 func (q *baseQuery) getFields() ([]*schema.Field, error) {
 	if len(q.columns) == 0 {
 		if q.table == nil {
-			return nil, errNilModel
+			return nil, ErrNilModel
 		}
 		return q.table.Fields, nil
 	}
@@ -535,7 +667,7 @@ func (q *baseQuery) getFields() ([]*schema.Field, error) {
 func (q *baseQuery) getDataFields() ([]*schema.Field, error) {
 	if len(q.columns) == 0 {
 		if q.table == nil {
-			return nil, errNilModel
+			return nil, ErrNilModel
 		}
 		return q.table.DataFields, nil
 	}
@@ -572,7 +704,7 @@ func (q *baseQuery) scan(
 ) (sql.Result, error) {
 	ctx, event := q.db.beforeQuery(ctx, iquery, query, nil, query, q.model)
 	res, err := q._scan(ctx, iquery, query, model, hasDest)
-	q.db.afterQuery(ctx, event, res, err)
+	err = q.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
 
@@ -607,7 +739,7 @@ func (q *baseQuery) exec(
 ) (sql.Result, error) {
 	ctx, event := q.db.beforeQuery(ctx, iquery, query, nil, query, q.model)
 	res, err := q.resolveConn(iquery).ExecContext(ctx, query)
-	q.db.afterQuery(ctx, event, res, err)
+	err = q.db.afterQuery(ctx, event, res, err)
 	return res, err
 }
 
@@ -626,22 +758,22 @@ func (q *baseQuery) AppendNamedArg(fmter schema.Formatter, b []byte, name string
 
 	switch name {
 	case "TableName":
-		b = fmter.AppendQuery(b, string(q.table.SQLName))
+		b = fmter.AppendQuery(b, string(q.sqlTableName()))
 		return b, true
 	case "TableAlias":
-		b = fmter.AppendQuery(b, string(q.table.SQLAlias))
+		b = fmter.AppendQuery(b, string(q.sqlAlias()))
 		return b, true
 	case "PKs":
 		b = appendColumns(b, "", q.table.PKs)
 		return b, true
 	case "TablePKs":
-		b = appendColumns(b, q.table.SQLAlias, q.table.PKs)
+		b = appendColumns(b, q.sqlAlias(), q.table.PKs)
 		return b, true
 	case "Columns":
 		b = appendColumns(b, "", q.table.Fields)
 		return b, true
 	case "TableColumns":
-		b = appendColumns(b, q.table.SQLAlias, q.table.Fields)
+		b = appendColumns(b, q.sqlAlias(), q.table.Fields)
 		return b, true
 	}
 
@@ -706,6 +838,22 @@ func (q *baseQuery) NewDropColumn() *DropColumnQuery {
 	return NewDropColumnQuery(q.db).Conn(q.conn)
 }
 
+func (q *baseQuery) NewAlterColumn() *AlterColumnQuery {
+	return NewAlterColumnQuery(q.db).Conn(q.conn)
+}
+
+func (q *baseQuery) NewCreateView() *CreateViewQuery {
+	return NewCreateViewQuery(q.db).Conn(q.conn)
+}
+
+func (q *baseQuery) NewDropView() *DropViewQuery {
+	return NewDropViewQuery(q.db).Conn(q.conn)
+}
+
+func (q *baseQuery) NewCreateMaterializedView() *CreateMaterializedViewQuery {
+	return NewCreateMaterializedViewQuery(q.db).Conn(q.conn)
+}
+
 //------------------------------------------------------------------------------
 
 func appendColumns(b []byte, table schema.Safe, fields []*schema.Field) []byte {
@@ -739,6 +887,13 @@ type whereBaseQuery struct {
 	whereFields []*schema.Field
 }
 
+func (q *whereBaseQuery) reset() {
+	q.baseQuery.reset()
+	q.where = resetSlice(q.where)
+	// nil whereFields means that WherePK was not called.
+	q.whereFields = nil
+}
+
 func (q *whereBaseQuery) addWhere(where schema.QueryWithSep) {
 	q.where = append(q.where, where)
 }
@@ -789,16 +944,101 @@ func (q *whereBaseQuery) addWhereCols(cols []string) {
 	}
 }
 
+func (q *whereBaseQuery) addWhereDistinctFrom(column string, value interface{}, not bool) {
+	cond := distinctFrom{column: column, value: value, not: not}
+	q.addWhere(schema.SafeQueryWithSep("?", []interface{}{cond}, " AND "))
+}
+
+// distinctFrom is a NULL-safe comparison of the column with the value:
+// NULL is not distinct from NULL, but is distinct from any other value.
+type distinctFrom struct {
+	column string
+	value  interface{}
+	not    bool
+}
+
+var _ schema.QueryAppender = distinctFrom{}
+
+func (c distinctFrom) AppendQuery(fmter schema.Formatter, b []byte) ([]byte, error) {
+	var query string
+	switch {
+	case fmter.HasFeature(feature.DistinctFrom):
+		if c.not {
+			query = "? IS NOT DISTINCT FROM ?"
+		} else {
+			query = "? IS DISTINCT FROM ?"
+		}
+	case fmter.Dialect().Name() == dialect.MySQL:
+		// <=> is the NULL-safe equal operator.
+		if c.not {
+			query = "? <=> ?"
+		} else {
+			query = "NOT (? <=> ?)"
+		}
+	case fmter.Dialect().Name() == dialect.MSSQL:
+		// INTERSECT treats NULLs as equal.
+		if c.not {
+			query = "EXISTS (SELECT ? INTERSECT SELECT ?)"
+		} else {
+			query = "NOT EXISTS (SELECT ? INTERSECT SELECT ?)"
+		}
+	case fmter.Dialect().Name() == dialect.Oracle:
+		// DECODE treats NULLs as equal.
+		if c.not {
+			query = "DECODE(?, ?, 0, 1) = 0"
+		} else {
+			query = "DECODE(?, ?, 0, 1) = 1"
+		}
+	default:
+		return nil, feature.NewNotSupportError(feature.DistinctFrom)
+	}
+	return fmter.AppendQuery(b, query, Ident(c.column), c.value), nil
+}
+
+func (q *whereBaseQuery) addWhereILike(column string, pattern string) {
+	cond := iLike{column: column, pattern: pattern}
+	q.addWhere(schema.SafeQueryWithSep("?", []interface{}{cond}, " AND "))
+}
+
+// iLike is a case-insensitive LIKE. Backslash is the escape character on every dialect
+// so patterns escaped with EscapeLike match literally.
+type iLike struct {
+	column  string
+	pattern string
+}
+
+var _ schema.QueryAppender = iLike{}
+
+func (c iLike) AppendQuery(fmter schema.Formatter, b []byte) ([]byte, error) {
+	var query string
+	switch name := fmter.Dialect().Name(); {
+	case fmter.HasFeature(feature.ILike):
+		query = "? ILIKE ?"
+	case name == dialect.MySQL:
+		// MySQL already uses backslash as the default escape character.
+		query = "LOWER(?) LIKE LOWER(?)"
+	default:
+		query = "LOWER(?) LIKE LOWER(?) ESCAPE '\\'"
+	}
+	return fmter.AppendQuery(b, query, Ident(c.column), c.pattern), nil
+}
+
 func (q *whereBaseQuery) mustAppendWhere(
 	fmter schema.Formatter, b []byte, withAlias bool,
 ) ([]byte, error) {
-	if len(q.where) == 0 && q.whereFields == nil && !q.flags.Has(deletedFlag) {
-		err := errors.New("bun: Update and Delete queries require at least one Where")
+	if err := q.checkWhere(); err != nil {
 		return nil, err
 	}
 	return q.appendWhere(fmter, b, withAlias)
 }
 
+func (q *whereBaseQuery) checkWhere() error {
+	if len(q.where) == 0 && q.whereFields == nil && !q.flags.Has(deletedFlag) {
+		return errors.New("bun: Update and Delete queries require at least one Where")
+	}
+	return nil
+}
+
 func (q *whereBaseQuery) appendWhere(
 	fmter schema.Formatter, b []byte, withAlias bool,
 ) (_ []byte, err error) {
@@ -822,9 +1062,9 @@ func (q *whereBaseQuery) appendWhere(
 		}
 
 		if withAlias {
-			b = append(b, q.tableModel.Table().SQLAlias...)
+			b = append(b, q.sqlAlias()...)
 		} else {
-			b = append(b, q.tableModel.Table().SQLName...)
+			b = append(b, q.sqlTableName()...)
 		}
 		b = append(b, '.')
 
@@ -908,7 +1148,7 @@ func (q *whereBaseQuery) appendWhereStructFields(
 	withAlias bool,
 ) (_ []byte, err error) {
 	if !model.strct.IsValid() {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	isTemplate := fmter.IsNop()
@@ -918,7 +1158,7 @@ func (q *whereBaseQuery) appendWhereStructFields(
 			b = append(b, " AND "...)
 		}
 		if withAlias {
-			b = append(b, q.table.SQLAlias...)
+			b = append(b, q.sqlAlias()...)
 			b = append(b, '.')
 		}
 		b = append(b, f.SQLName...)
@@ -940,11 +1180,26 @@ func (q *whereBaseQuery) appendWhereSliceFields(
 	fields []*schema.Field,
 	withAlias bool,
 ) (_ []byte, err error) {
+	isTemplate := fmter.IsNop()
+	slice := model.slice
+	sliceLen := slice.Len()
+
+	// Deleting by an empty slice is most likely a bug and must not render
+	// an invalid or unbounded query.
+	if q.flags.Has(deleteWherePKFlag) {
+		if sliceLen == 0 && !isTemplate {
+			return nil, fmt.Errorf("bun: WherePK requires a non-empty slice, got empty %s", slice.Type())
+		}
+		if len(fields) > 1 && !fmter.HasFeature(feature.CompositeIn) {
+			return q.appendWhereSliceFieldsMulti(fmter, b, model, fields, withAlias)
+		}
+	}
+
 	if len(fields) > 1 {
 		b = append(b, '(')
 	}
 	if withAlias {
-		b = appendColumns(b, q.table.SQLAlias, fields)
+		b = appendColumns(b, q.sqlAlias(), fields)
 	} else {
 		b = appendColumns(b, "", fields)
 	}
@@ -954,9 +1209,6 @@ func (q *whereBaseQuery) appendWhereSliceFields(
 
 	b = append(b, " IN ("...)
 
-	isTemplate := fmter.IsNop()
-	slice := model.slice
-	sliceLen := slice.Len()
 	for i := 0; i < sliceLen; i++ {
 		if i > 0 {
 			if isTemplate {
@@ -990,6 +1242,54 @@ func (q *whereBaseQuery) appendWhereSliceFields(
 	return b, nil
 }
 
+// appendWhereSliceFieldsMulti is used by dialects that don't support composite IN
+// and generates ((a = 1 AND b = 2) OR (a = 3 AND b = 4)) conditions instead.
+func (q *whereBaseQuery) appendWhereSliceFieldsMulti(
+	fmter schema.Formatter,
+	b []byte,
+	model *sliceTableModel,
+	fields []*schema.Field,
+	withAlias bool,
+) (_ []byte, err error) {
+	isTemplate := fmter.IsNop()
+	slice := model.slice
+	sliceLen := slice.Len()
+
+	b = append(b, '(')
+	for i := 0; i < sliceLen; i++ {
+		if i > 0 {
+			if isTemplate {
+				break
+			}
+			b = append(b, " OR "...)
+		}
+
+		el := indirect(slice.Index(i))
+
+		b = append(b, '(')
+		for j, f := range fields {
+			if j > 0 {
+				b = append(b, " AND "...)
+			}
+			if withAlias {
+				b = append(b, q.sqlAlias()...)
+				b = append(b, '.')
+			}
+			b = append(b, f.SQLName...)
+			b = append(b, " = "...)
+			if isTemplate {
+				b = append(b, '?')
+			} else {
+				b = f.AppendValue(fmter, b, el)
+			}
+		}
+		b = append(b, ')')
+	}
+	b = append(b, ')')
+
+	return b, nil
+}
+
 //------------------------------------------------------------------------------
 
 type returningQuery struct {
@@ -997,10 +1297,23 @@ type returningQuery struct {
 	returningFields []*schema.Field
 }
 
+func (q *returningQuery) reset() {
+	q.returning = resetSlice(q.returning)
+	q.returningFields = resetSlice(q.returningFields)
+}
+
 func (q *returningQuery) addReturning(ret schema.QueryWithArgs) {
 	q.returning = append(q.returning, ret)
 }
 
+// addReturningModel replaces the RETURNING clause with the table columns
+// excluding relations and scanonly fields.
+func (q *returningQuery) addReturningModel(table *schema.Table) {
+	q.returning = nil
+	q.returningFields = make([]*schema.Field, len(table.Fields))
+	copy(q.returningFields, table.Fields)
+}
+
 func (q *returningQuery) addReturningField(field *schema.Field) {
 	if len(q.returning) > 0 {
 		return
@@ -1070,6 +1383,11 @@ type customValueQuery struct {
 	extraValues []columnValue
 }
 
+func (q *customValueQuery) reset() {
+	clear(q.modelValues)
+	q.extraValues = resetSlice(q.extraValues)
+}
+
 func (q *customValueQuery) addValue(
 	table *schema.Table, column string, value string, args []interface{},
 ) {
@@ -1097,6 +1415,10 @@ type setQuery struct {
 	set []schema.QueryWithArgs
 }
 
+func (q *setQuery) reset() {
+	q.set = resetSlice(q.set)
+}
+
 func (q *setQuery) addSet(set schema.QueryWithArgs) {
 	q.set = append(q.set, set)
 }
@@ -1375,6 +1697,12 @@ type orderLimitOffsetQuery struct {
 	offset int32
 }
 
+func (q *orderLimitOffsetQuery) reset() {
+	q.order = resetSlice(q.order)
+	q.limit = 0
+	q.offset = 0
+}
+
 func (q *orderLimitOffsetQuery) addOrder(orders ...string) {
 	for _, order := range orders {
 		if order == "" {
@@ -53,6 +53,16 @@ func (q *AddColumnQuery) Apply(fns ...func(*AddColumnQuery) *AddColumnQuery) *Ad
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *AddColumnQuery) Reset() *AddColumnQuery {
+	q.baseQuery.reset()
+	q.ifNotExists = false
+	q.comment = ""
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *AddColumnQuery) Table(tables ...string) *AddColumnQuery {
@@ -0,0 +1,327 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/sqltype"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// AlterColumnQuery changes the default value or the nullability of a column using
+// ALTER TABLE ... ALTER COLUMN, or ALTER TABLE ... MODIFY on MySQL and Oracle.
+type AlterColumnQuery struct {
+	baseQuery
+
+	setDefault  schema.QueryWithArgs
+	dropDefault bool
+	setNotNull  bool
+	dropNotNull bool
+	comment     string
+}
+
+var _ Query = (*AlterColumnQuery)(nil)
+
+func NewAlterColumnQuery(db *DB) *AlterColumnQuery {
+	q := &AlterColumnQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *AlterColumnQuery) Conn(db IConn) *AlterColumnQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *AlterColumnQuery) Model(model interface{}) *AlterColumnQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *AlterColumnQuery) Err(err error) *AlterColumnQuery {
+	q.setErr(err)
+	return q
+}
+
+// Apply calls each function in fns, passing the AlterColumnQuery as an argument.
+func (q *AlterColumnQuery) Apply(fns ...func(*AlterColumnQuery) *AlterColumnQuery) *AlterColumnQuery {
+	for _, fn := range fns {
+		if fn != nil {
+			q = fn(q)
+		}
+	}
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *AlterColumnQuery) Reset() *AlterColumnQuery {
+	q.baseQuery.reset()
+	q.resetAction()
+	q.comment = ""
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *AlterColumnQuery) Table(tables ...string) *AlterColumnQuery {
+	for _, table := range tables {
+		q.addTable(schema.UnsafeIdent(table))
+	}
+	return q
+}
+
+func (q *AlterColumnQuery) TableExpr(query string, args ...interface{}) *AlterColumnQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *AlterColumnQuery) ModelTableExpr(query string, args ...interface{}) *AlterColumnQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *AlterColumnQuery) Column(columns ...string) *AlterColumnQuery {
+	for _, column := range columns {
+		q.addColumn(schema.UnsafeIdent(column))
+	}
+	return q
+}
+
+func (q *AlterColumnQuery) ColumnExpr(query string, args ...interface{}) *AlterColumnQuery {
+	q.addColumn(schema.SafeQuery(query, args))
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// SetDefault sets the column default to the expression, e.g. SetDefault("now()").
+// MySQL requires parentheses around expression defaults, so they are added unless
+// the default is a single argument, e.g. SetDefault("?", "hello"), which also works on MySQL 5.7.
+func (q *AlterColumnQuery) SetDefault(query string, args ...interface{}) *AlterColumnQuery {
+	q.resetAction()
+	q.setDefault = schema.SafeQuery(query, args)
+	return q
+}
+
+// DropDefault removes the column default.
+func (q *AlterColumnQuery) DropDefault() *AlterColumnQuery {
+	q.resetAction()
+	q.dropDefault = true
+	return q
+}
+
+// SetNotNull adds the NOT NULL constraint to the column.
+//
+// MySQL and SQL Server can only change the nullability together with the column type,
+// so the query requires a model with the column. On MySQL, the column is redefined
+// with the type and the default of the model field.
+func (q *AlterColumnQuery) SetNotNull() *AlterColumnQuery {
+	q.resetAction()
+	q.setNotNull = true
+	return q
+}
+
+// DropNotNull removes the NOT NULL constraint from the column.
+// See SetNotNull for the limitations on MySQL and SQL Server.
+func (q *AlterColumnQuery) DropNotNull() *AlterColumnQuery {
+	q.resetAction()
+	q.dropNotNull = true
+	return q
+}
+
+// resetAction clears the previous action, because the query changes one thing at a time.
+func (q *AlterColumnQuery) resetAction() {
+	q.setDefault = schema.QueryWithArgs{}
+	q.dropDefault = false
+	q.setNotNull = false
+	q.dropNotNull = false
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *AlterColumnQuery) Comment(comment string) *AlterColumnQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *AlterColumnQuery) Operation() string {
+	return "ALTER COLUMN"
+}
+
+func (q *AlterColumnQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	b = appendComment(b, q.comment)
+
+	if len(q.columns) != 1 {
+		return nil, fmt.Errorf("bun: AlterColumnQuery requires exactly one column")
+	}
+	if q.setDefault.IsZero() && !q.dropDefault && !q.setNotNull && !q.dropNotNull {
+		return nil, fmt.Errorf("bun: AlterColumnQuery requires SetDefault, DropDefault, SetNotNull, or DropNotNull")
+	}
+
+	name := fmter.Dialect().Name()
+	switch name {
+	case dialect.SQLite:
+		return nil, fmt.Errorf("bun: %s does not support ALTER COLUMN", name)
+	case dialect.MSSQL:
+		// SQL Server stores defaults as named constraints.
+		if q.dropDefault {
+			return nil, fmt.Errorf("bun: %s requires dropping the default constraint by name", name)
+		}
+	}
+
+	b = append(b, "ALTER TABLE "...)
+
+	b, err = q.appendFirstTable(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case dialect.Oracle:
+		return q.appendOracleModify(fmter, b)
+	case dialect.MSSQL:
+		if q.setNotNull || q.dropNotNull {
+			b = append(b, " ALTER COLUMN "...)
+			return q.appendColumnDefinition(fmter, b, false)
+		}
+		b = append(b, " ADD DEFAULT "...)
+		b, err = q.setDefault.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, " FOR "...)
+		return q.columns[0].AppendQuery(fmter, b)
+	case dialect.MySQL:
+		if q.setNotNull || q.dropNotNull {
+			b = append(b, " MODIFY COLUMN "...)
+			return q.appendColumnDefinition(fmter, b, true)
+		}
+	}
+
+	b = append(b, " ALTER COLUMN "...)
+
+	b, err = q.columns[0].AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case q.dropDefault:
+		return append(b, " DROP DEFAULT"...), nil
+	case q.setNotNull:
+		return append(b, " SET NOT NULL"...), nil
+	case q.dropNotNull:
+		return append(b, " DROP NOT NULL"...), nil
+	}
+
+	b = append(b, " SET DEFAULT "...)
+	if name != dialect.MySQL || isArgDefault(q.setDefault) {
+		return q.setDefault.AppendQuery(fmter, b)
+	}
+
+	b = append(b, '(')
+	b, err = q.setDefault.AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, ')'), nil
+}
+
+// isArgDefault reports whether the default is a single argument, which is appended as a literal.
+func isArgDefault(query schema.QueryWithArgs) bool {
+	return query.Query == "?" && len(query.Args) == 1
+}
+
+// appendOracleModify appends MODIFY (column ...), which Oracle uses instead of ALTER COLUMN.
+func (q *AlterColumnQuery) appendOracleModify(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	b = append(b, " MODIFY ("...)
+
+	b, err = q.columns[0].AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case q.dropDefault:
+		b = append(b, " DEFAULT NULL"...)
+	case q.setNotNull:
+		b = append(b, " NOT NULL"...)
+	case q.dropNotNull:
+		b = append(b, " NULL"...)
+	default:
+		b = append(b, " DEFAULT "...)
+		b, err = q.setDefault.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, ')'), nil
+}
+
+// appendColumnDefinition appends the column with the type of the model field and the nullability.
+func (q *AlterColumnQuery) appendColumnDefinition(
+	fmter schema.Formatter, b []byte, withDefault bool,
+) (_ []byte, err error) {
+	var field *schema.Field
+	if q.table != nil {
+		field = q.table.FieldMap[q.columns[0].Query]
+	}
+	if field == nil {
+		return nil, fmt.Errorf("bun: %s requires a model with column %q to change NOT NULL",
+			fmter.Dialect().Name(), q.columns[0].Query)
+	}
+
+	b = append(b, field.SQLName...)
+	b = append(b, ' ')
+	b = append(b, field.CreateTableSQLType...)
+	if n := fmter.Dialect().DefaultVarcharLen(); n > 0 &&
+		strings.EqualFold(field.CreateTableSQLType, sqltype.VarChar) {
+		b = append(b, '(')
+		b = strconv.AppendInt(b, int64(n), 10)
+		b = append(b, ')')
+	}
+
+	if q.setNotNull {
+		b = append(b, " NOT NULL"...)
+	} else {
+		b = append(b, " NULL"...)
+	}
+
+	if withDefault && field.SQLDefault != "" {
+		b = append(b, " DEFAULT "...)
+		b = append(b, field.SQLDefault...)
+	}
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *AlterColumnQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+	return q.exec(ctx, q, query)
+}
@@ -51,6 +51,15 @@ func (q *DropColumnQuery) Apply(fns ...func(*DropColumnQuery) *DropColumnQuery)
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *DropColumnQuery) Reset() *DropColumnQuery {
+	q.baseQuery.reset()
+	q.comment = ""
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *DropColumnQuery) Table(tables ...string) *DropColumnQuery {
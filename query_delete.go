@@ -3,7 +3,9 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/uptrace/bun/dialect/feature"
@@ -16,7 +18,8 @@ type DeleteQuery struct {
 	orderLimitOffsetQuery
 	returningQuery
 
-	comment string
+	comment   string
+	chunkSize int
 }
 
 var _ Query = (*DeleteQuery)(nil)
@@ -37,6 +40,13 @@ func (q *DeleteQuery) Conn(db IConn) *DeleteQuery {
 	return q
 }
 
+// WithoutHooks disables the query hooks registered with DB.AddQueryHook for this query,
+// for example, to exclude a noisy health check from logging and tracing.
+func (q *DeleteQuery) WithoutHooks() *DeleteQuery {
+	q.flags = q.flags.Set(withoutHooksFlag)
+	return q
+}
+
 func (q *DeleteQuery) Model(model interface{}) *DeleteQuery {
 	q.setModel(model)
 	return q
@@ -57,6 +67,26 @@ func (q *DeleteQuery) Apply(fns ...func(*DeleteQuery) *DeleteQuery) *DeleteQuery
 	return q
 }
 
+// WithScope applies the scope to the query. A nil scope is ignored.
+func (q *DeleteQuery) WithScope(scope Scope) *DeleteQuery {
+	if scope != nil {
+		scope.ApplyDelete(q)
+	}
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *DeleteQuery) Reset() *DeleteQuery {
+	q.whereBaseQuery.reset()
+	q.orderLimitOffsetQuery.reset()
+	q.returningQuery.reset()
+	q.comment = ""
+	q.chunkSize = 0
+	return q
+}
+
 func (q *DeleteQuery) With(name string, query Query) *DeleteQuery {
 	q.addWith(name, query, false)
 	return q
@@ -84,10 +114,42 @@ func (q *DeleteQuery) ModelTableExpr(query string, args ...interface{}) *DeleteQ
 	return q
 }
 
+// WithTableName overrides the model table name for this query, for example,
+// to query a shard of the table. Unlike ModelTableExpr, it keeps the model alias
+// and the ?TableName placeholder resolves to the new name.
+func (q *DeleteQuery) WithTableName(name string) *DeleteQuery {
+	q.tableName = name
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *DeleteQuery) WherePK(cols ...string) *DeleteQuery {
 	q.addWhereCols(cols)
+	q.flags = q.flags.Set(deleteWherePKFlag)
+	return q
+}
+
+// WhereDistinctFrom adds a NULL-safe inequality condition, i.e. `column IS DISTINCT FROM value`,
+// which, unlike `<>`, is true when only one of the sides is NULL. Databases without
+// IS DISTINCT FROM use an equivalent expression, e.g. `NOT (column <=> value)` on MySQL.
+func (q *DeleteQuery) WhereDistinctFrom(column string, value interface{}) *DeleteQuery {
+	q.addWhereDistinctFrom(column, value, false)
+	return q
+}
+
+// WhereNotDistinctFrom adds a NULL-safe equality condition, i.e. `column IS NOT DISTINCT FROM value`,
+// which, unlike `=`, is true when both sides are NULL.
+func (q *DeleteQuery) WhereNotDistinctFrom(column string, value interface{}) *DeleteQuery {
+	q.addWhereDistinctFrom(column, value, true)
+	return q
+}
+
+// WhereILike adds a case-insensitive LIKE condition, i.e. `column ILIKE pattern` on PostgreSQL
+// and `LOWER(column) LIKE LOWER(pattern)` elsewhere. The % and _ wildcards in the pattern
+// are not escaped; use EscapeLike to match user input literally.
+func (q *DeleteQuery) WhereILike(column string, pattern string) *DeleteQuery {
+	q.addWhereILike(column, pattern)
 	return q
 }
 
@@ -101,6 +163,23 @@ func (q *DeleteQuery) WhereOr(query string, args ...interface{}) *DeleteQuery {
 	return q
 }
 
+// WhereIf is like Where, but only adds the condition when cond is true, for example,
+// WhereIf(filter.Name != "", "name = ?", filter.Name).
+func (q *DeleteQuery) WhereIf(cond bool, query string, args ...interface{}) *DeleteQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
+	}
+	return q
+}
+
+// WhereOrIf is like WhereOr, but only adds the condition when cond is true.
+func (q *DeleteQuery) WhereOrIf(cond bool, query string, args ...interface{}) *DeleteQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " OR "))
+	}
+	return q
+}
+
 func (q *DeleteQuery) WhereGroup(sep string, fn func(*DeleteQuery) *DeleteQuery) *DeleteQuery {
 	saved := q.where
 	q.where = nil
@@ -115,6 +194,18 @@ func (q *DeleteQuery) WhereGroup(sep string, fn func(*DeleteQuery) *DeleteQuery)
 	return q
 }
 
+// WhereOrGroup is a shorthand for WhereGroup(" OR ", fn), which joins the group of
+// conditions to the previous conditions with OR.
+func (q *DeleteQuery) WhereOrGroup(fn func(*DeleteQuery) *DeleteQuery) *DeleteQuery {
+	return q.WhereGroup(" OR ", fn)
+}
+
+// WhereAndGroup is a shorthand for WhereGroup(" AND ", fn), which joins the group of
+// conditions to the previous conditions with AND.
+func (q *DeleteQuery) WhereAndGroup(fn func(*DeleteQuery) *DeleteQuery) *DeleteQuery {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *DeleteQuery) WhereDeleted() *DeleteQuery {
 	q.whereDeleted()
 	return q
@@ -158,6 +249,18 @@ func (q *DeleteQuery) Limit(n int) *DeleteQuery {
 	return q
 }
 
+// ChunkSize makes Exec delete the matching rows in chunks of n rows
+// until no rows remain, which avoids holding locks on all rows at once.
+// Each chunk is a separate statement, so the deleted rows are not rolled back
+// when a later chunk fails. Exec returns the total number of deleted rows.
+// The context is checked between chunks.
+//
+// ChunkSize requires a model with a single primary key.
+func (q *DeleteQuery) ChunkSize(n int) *DeleteQuery {
+	q.chunkSize = n
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Returning adds a RETURNING clause to the query.
@@ -173,6 +276,22 @@ func (q *DeleteQuery) Returning(query string, args ...interface{}) *DeleteQuery
 	return q
 }
 
+// ReturningModel adds a RETURNING clause with exactly the model columns,
+// excluding relations and scanonly fields, so the returned columns match the model.
+// It replaces columns added with Returning.
+func (q *DeleteQuery) ReturningModel() *DeleteQuery {
+	if !q.hasFeature(feature.DeleteReturning) {
+		q.err = feature.NewNotSupportError(feature.DeleteReturning)
+		return q
+	}
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	q.addReturningModel(q.table)
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Comment adds a comment to the query, wrapped by /* ... */.
@@ -310,6 +429,10 @@ func (q *DeleteQuery) scanOrExec(
 		return nil, q.err
 	}
 
+	if q.chunkSize > 0 {
+		return q.execChunks(ctx, hasDest)
+	}
+
 	if q.table != nil {
 		if err := q.beforeDeleteHook(ctx); err != nil {
 			return nil, err
@@ -363,6 +486,95 @@ func (q *DeleteQuery) scanOrExec(
 	return res, nil
 }
 
+func (q *DeleteQuery) execChunks(ctx context.Context, hasDest bool) (sql.Result, error) {
+	if hasDest || q.hasReturning() {
+		return nil, errors.New("bun: ChunkSize does not support Returning")
+	}
+	if q.limit > 0 {
+		return nil, errors.New("bun: ChunkSize does not support Limit")
+	}
+	if q.table == nil || len(q.table.PKs) != 1 {
+		return nil, errors.New("bun: ChunkSize requires a model with a single primary key")
+	}
+	if err := q.checkWhere(); err != nil {
+		return nil, err
+	}
+
+	useLimit := q.hasFeature(feature.DeleteOrderLimit)
+	if useLimit && q.isSoftDelete() {
+		return nil, fmt.Errorf("bun: ChunkSize does not support soft deletes on %s",
+			q.db.dialect.Name())
+	}
+
+	if err := q.beforeDeleteHook(ctx); err != nil {
+		return nil, err
+	}
+	if err := q.beforeAppendModel(ctx, q); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return driver.RowsAffected(total), err
+		}
+
+		chunk := q.chunkQuery(useLimit)
+		queryBytes, err := chunk.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+		if err != nil {
+			return driver.RowsAffected(total), err
+		}
+
+		res, err := chunk.exec(ctx, chunk, internal.String(queryBytes))
+		q.setCompiledQuery(chunk.CompiledQuery())
+		if err != nil {
+			return driver.RowsAffected(total), err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return driver.RowsAffected(total), err
+		}
+		total += n
+
+		if n < int64(q.chunkSize) {
+			break
+		}
+	}
+
+	if err := q.afterDeleteHook(ctx); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(total), nil
+}
+
+// chunkQuery returns a query that deletes a single chunk. Dialects that support
+// DELETE ... LIMIT use it directly; others select the primary keys in a subquery.
+func (q *DeleteQuery) chunkQuery(useLimit bool) *DeleteQuery {
+	chunk := *q
+	chunk.chunkSize = 0
+
+	if useLimit {
+		chunk.setLimit(q.chunkSize)
+		return &chunk
+	}
+
+	pk := q.table.PKs[0]
+
+	sub := NewSelectQuery(q.db)
+	sub.whereBaseQuery = q.whereBaseQuery
+	sub.columns = nil
+	sub.Column(pk.Name).Order(pk.Name).Limit(q.chunkSize)
+
+	chunk.with = nil
+	chunk.where = []schema.QueryWithSep{
+		schema.SafeQueryWithSep("? IN (?)", []interface{}{Safe(pk.SQLName), sub}, " AND "),
+	}
+	chunk.whereFields = nil
+	return &chunk
+}
+
 func (q *DeleteQuery) beforeDeleteHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeDeleteHook); ok {
 		if err := hook.BeforeDelete(ctx, q); err != nil {
@@ -413,6 +625,14 @@ func (q *deleteQueryBuilder) WhereGroup(
 	return q
 }
 
+func (q *deleteQueryBuilder) WhereOrGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" OR ", fn)
+}
+
+func (q *deleteQueryBuilder) WhereAndGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *deleteQueryBuilder) Where(query string, args ...interface{}) QueryBuilder {
 	q.DeleteQuery.Where(query, args...)
 	return q
@@ -423,6 +643,16 @@ func (q *deleteQueryBuilder) WhereOr(query string, args ...interface{}) QueryBui
 	return q
 }
 
+func (q *deleteQueryBuilder) WhereIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.DeleteQuery.WhereIf(cond, query, args...)
+	return q
+}
+
+func (q *deleteQueryBuilder) WhereOrIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.DeleteQuery.WhereOrIf(cond, query, args...)
+	return q
+}
+
 func (q *deleteQueryBuilder) WhereDeleted() QueryBuilder {
 	q.DeleteQuery.WhereDeleted()
 	return q
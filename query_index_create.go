@@ -51,6 +51,23 @@ func (q *CreateIndexQuery) Err(err error) *CreateIndexQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *CreateIndexQuery) Reset() *CreateIndexQuery {
+	q.whereBaseQuery.reset()
+	q.unique = false
+	q.fulltext = false
+	q.spatial = false
+	q.concurrently = false
+	q.ifNotExists = false
+	q.index = schema.QueryWithArgs{}
+	q.using = schema.QueryWithArgs{}
+	q.include = resetSlice(q.include)
+	q.comment = ""
+	return q
+}
+
 func (q *CreateIndexQuery) Unique() *CreateIndexQuery {
 	q.unique = true
 	return q
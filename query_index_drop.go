@@ -45,6 +45,19 @@ func (q *DropIndexQuery) Err(err error) *DropIndexQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *DropIndexQuery) Reset() *DropIndexQuery {
+	q.baseQuery.reset()
+	q.cascadeQuery = cascadeQuery{}
+	q.concurrently = false
+	q.ifExists = false
+	q.index = schema.QueryWithArgs{}
+	q.comment = ""
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *DropIndexQuery) Concurrently() *DropIndexQuery {
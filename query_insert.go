@@ -3,10 +3,12 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
@@ -17,12 +19,17 @@ type InsertQuery struct {
 	returningQuery
 	customValueQuery
 
-	on schema.QueryWithArgs
+	on             schema.QueryWithArgs
+	onConflict     bool
+	onConstraint   bool
+	conflictAction string
 	setQuery
 
-	ignore  bool
-	replace bool
-	comment string
+	ignore        bool
+	replace       bool
+	defaultValues bool
+	batchSize     int
+	comment       string
 }
 
 var _ Query = (*InsertQuery)(nil)
@@ -43,6 +50,13 @@ func (q *InsertQuery) Conn(db IConn) *InsertQuery {
 	return q
 }
 
+// WithoutHooks disables the query hooks registered with DB.AddQueryHook for this query,
+// for example, to exclude a noisy health check from logging and tracing.
+func (q *InsertQuery) WithoutHooks() *InsertQuery {
+	q.flags = q.flags.Set(withoutHooksFlag)
+	return q
+}
+
 func (q *InsertQuery) Model(model interface{}) *InsertQuery {
 	q.setModel(model)
 	return q
@@ -63,6 +77,26 @@ func (q *InsertQuery) Apply(fns ...func(*InsertQuery) *InsertQuery) *InsertQuery
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *InsertQuery) Reset() *InsertQuery {
+	q.whereBaseQuery.reset()
+	q.returningQuery.reset()
+	q.customValueQuery.reset()
+	q.on = schema.QueryWithArgs{}
+	q.onConflict = false
+	q.onConstraint = false
+	q.conflictAction = ""
+	q.setQuery.reset()
+	q.ignore = false
+	q.replace = false
+	q.defaultValues = false
+	q.batchSize = 0
+	q.comment = ""
+	return q
+}
+
 func (q *InsertQuery) With(name string, query Query) *InsertQuery {
 	q.addWith(name, query, false)
 	return q
@@ -92,6 +126,14 @@ func (q *InsertQuery) ModelTableExpr(query string, args ...interface{}) *InsertQ
 	return q
 }
 
+// WithTableName overrides the model table name for this query, for example,
+// to query a shard of the table. Unlike ModelTableExpr, it keeps the model alias
+// and the ?TableName placeholder resolves to the new name.
+func (q *InsertQuery) WithTableName(name string) *InsertQuery {
+	q.tableName = name
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *InsertQuery) Column(columns ...string) *InsertQuery {
@@ -114,7 +156,7 @@ func (q *InsertQuery) ExcludeColumn(columns ...string) *InsertQuery {
 // Value overwrites model value for the column.
 func (q *InsertQuery) Value(column string, expr string, args ...interface{}) *InsertQuery {
 	if q.table == nil {
-		q.err = errNilModel
+		q.err = ErrNilModel
 		return q
 	}
 	q.addValue(q.table, column, expr, args)
@@ -136,13 +178,39 @@ func (q *InsertQuery) WhereOr(query string, args ...interface{}) *InsertQuery {
 // Returning adds a RETURNING clause to the query.
 //
 // To suppress the auto-generated RETURNING clause, use `Returning("")`.
+//
+// MySQL does not support RETURNING, so bun emulates it by selecting the returned columns
+// by the primary key of the inserted row after the insert, which costs an extra round trip.
+// The emulation only supports inserting a single struct with a primary key; inserting
+// a slice with Returning fails with an error before the query is executed.
 func (q *InsertQuery) Returning(query string, args ...interface{}) *InsertQuery {
 	q.addReturning(schema.SafeQuery(query, args))
 	return q
 }
 
+// ReturningModel adds a RETURNING clause with exactly the model columns,
+// excluding relations and scanonly fields, so the returned columns match the model.
+// It replaces columns added with Returning.
+func (q *InsertQuery) ReturningModel() *InsertQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	q.addReturningModel(q.table)
+	return q
+}
+
 //------------------------------------------------------------------------------
 
+// DefaultValues inserts a row where all columns have default values,
+// ignoring the model values. It generates different queries depending on the DBMS:
+//   - On MySQL, it generates `INSERT INTO table () VALUES ()`.
+//   - On other databases, it generates `INSERT INTO table DEFAULT VALUES`.
+func (q *InsertQuery) DefaultValues() *InsertQuery {
+	q.defaultValues = true
+	return q
+}
+
 // Ignore generates different queries depending on the DBMS:
 //   - On MySQL, it generates `INSERT IGNORE INTO`.
 //   - On PostgreSQL, it generates `ON CONFLICT DO NOTHING`.
@@ -164,6 +232,20 @@ func (q *InsertQuery) Replace() *InsertQuery {
 
 //------------------------------------------------------------------------------
 
+// BatchSize splits inserting a slice into multiple INSERT statements with at most n rows
+// each. The statements are executed in a transaction unless the query already
+// uses one. When BatchSize is not set, the slice is split only when it exceeds
+// the number of values the dialect accepts in a single statement. Negative n disables
+// batching.
+//
+// Model hooks are called for each batch.
+func (q *InsertQuery) BatchSize(n int) *InsertQuery {
+	q.batchSize = n
+	return q
+}
+
+//------------------------------------------------------------------------------
+
 // Comment adds a comment to the query, wrapped by /* ... */.
 func (q *InsertQuery) Comment(comment string) *InsertQuery {
 	q.comment = comment
@@ -233,6 +315,10 @@ func (q *InsertQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 func (q *InsertQuery) appendColumnsValues(
 	fmter schema.Formatter, b []byte, skipOutput bool,
 ) (_ []byte, err error) {
+	if q.defaultValues {
+		return q.appendDefaultValues(fmter, b, skipOutput)
+	}
+
 	if q.hasMultiTables() {
 		if q.columns != nil {
 			b = append(b, " ("...)
@@ -279,7 +365,7 @@ func (q *InsertQuery) appendColumnsValues(
 	}
 
 	if q.model == nil {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	// Build fields to populate RETURNING clause.
@@ -322,6 +408,31 @@ func (q *InsertQuery) appendColumnsValues(
 	return b, nil
 }
 
+func (q *InsertQuery) appendDefaultValues(
+	fmter schema.Formatter, b []byte, skipOutput bool,
+) (_ []byte, err error) {
+	if q.db.dialect.Name() == dialect.MySQL {
+		return append(b, " () VALUES ()"...), nil
+	}
+
+	// All columns are generated by the database, so return them to populate the model.
+	if q.table != nil {
+		for _, f := range q.table.Fields {
+			q.addReturningField(f)
+		}
+	}
+
+	if q.hasFeature(feature.Output) && q.hasReturning() && !skipOutput {
+		b = append(b, " OUTPUT "...)
+		b, err = q.appendOutput(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, " DEFAULT VALUES"...), nil
+}
+
 func (q *InsertQuery) appendStructValues(
 	fmter schema.Formatter, b []byte, fields []*schema.Field, strct reflect.Value,
 ) (_ []byte, err error) {
@@ -398,7 +509,11 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 	hasIdentity := q.db.HasFeature(feature.Identity)
 
 	if len(q.columns) > 0 || q.db.HasFeature(feature.DefaultPlaceholder) && !hasIdentity {
-		return q.baseQuery.getFields()
+		fields, err := q.baseQuery.getFields()
+		if err != nil {
+			return nil, err
+		}
+		return withoutReadOnly(fields), nil
 	}
 
 	var strct reflect.Value
@@ -412,12 +527,15 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 		}
 		strct = indirect(model.slice.Index(0))
 	default:
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	fields := make([]*schema.Field, 0, len(q.table.Fields))
 
 	for _, f := range q.table.Fields {
+		if f.ReadOnly {
+			continue
+		}
 		if hasIdentity && f.AutoIncrement {
 			q.addReturningField(f)
 			continue
@@ -432,6 +550,26 @@ func (q *InsertQuery) getFields() ([]*schema.Field, error) {
 	return fields, nil
 }
 
+// withoutReadOnly returns the fields except the read-only ones.
+// It returns the original slice if there are no read-only fields.
+func withoutReadOnly(fields []*schema.Field) []*schema.Field {
+	for i, f := range fields {
+		if !f.ReadOnly {
+			continue
+		}
+
+		filtered := make([]*schema.Field, i, len(fields)-1)
+		copy(filtered, fields[:i])
+		for _, f := range fields[i+1:] {
+			if !f.ReadOnly {
+				filtered = append(filtered, f)
+			}
+		}
+		return filtered
+	}
+	return fields
+}
+
 // marshalsToDefault checks if the value will be marshaled as DEFAULT or NULL (if DEFAULT placeholder is not supported)
 // when appending it to the VALUES clause in place of the given field.
 func (q InsertQuery) marshalsToDefault(f *schema.Field, v reflect.Value) bool {
@@ -456,6 +594,68 @@ func (q *InsertQuery) appendFields(
 
 func (q *InsertQuery) On(s string, args ...interface{}) *InsertQuery {
 	q.on = schema.SafeQuery(s, args)
+	q.onConflict = false
+	q.onConstraint = false
+	q.conflictAction = ""
+	return q
+}
+
+// OnConflict starts a portable `ON CONFLICT (columns)` clause that must be followed
+// by DoUpdate or DoNothing, for example:
+//
+//	db.NewInsert().Model(model).
+//		OnConflict("id").
+//		DoUpdate().
+//		Set("name = EXCLUDED.name").
+//		Where("model.name IS NULL")
+//
+// Without Set, DoUpdate updates all data columns with the excluded values.
+// The query returns an error on databases that don't support ON CONFLICT, e.g. MySQL.
+func (q *InsertQuery) OnConflict(columns ...string) *InsertQuery {
+	query := "CONFLICT"
+	args := make([]interface{}, len(columns))
+	if len(columns) > 0 {
+		query += " (" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+		for i, column := range columns {
+			args[i] = schema.Ident(column)
+		}
+	}
+	q.on = schema.SafeQuery(query, args)
+	q.onConflict = true
+	q.onConstraint = false
+	q.conflictAction = ""
+	return q
+}
+
+// OnConflictConstraint is like OnConflict, but targets the named unique or exclusion
+// constraint with `ON CONFLICT ON CONSTRAINT name`. Only PostgreSQL supports
+// named conflict targets; other databases return an error.
+func (q *InsertQuery) OnConflictConstraint(name string) *InsertQuery {
+	q.on = schema.SafeQuery("CONFLICT ON CONSTRAINT ?", []interface{}{schema.Ident(name)})
+	q.onConflict = true
+	q.onConstraint = true
+	q.conflictAction = ""
+	return q
+}
+
+// DoUpdate completes the clause started with OnConflict with `DO UPDATE`.
+// Use Set to specify the updated columns and Where to update the row conditionally.
+// Calling DoUpdate or DoNothing again replaces the action.
+func (q *InsertQuery) DoUpdate() *InsertQuery {
+	return q.setConflictAction("DO UPDATE")
+}
+
+// DoNothing completes the clause started with OnConflict with `DO NOTHING`.
+func (q *InsertQuery) DoNothing() *InsertQuery {
+	return q.setConflictAction("DO NOTHING")
+}
+
+func (q *InsertQuery) setConflictAction(action string) *InsertQuery {
+	if !q.onConflict {
+		q.setErr(fmt.Errorf("bun: %s requires OnConflict or OnConflictConstraint", action))
+		return q
+	}
+	q.conflictAction = action
 	return q
 }
 
@@ -464,16 +664,68 @@ func (q *InsertQuery) Set(query string, args ...interface{}) *InsertQuery {
 	return q
 }
 
+// SetExcluded adds `column = EXCLUDED.column` to the ON CONFLICT DO UPDATE clause
+// for each of the columns, so the columns are updated with the values proposed for insertion.
+// EXCLUDED is only supported by PostgreSQL and SQLite.
+func (q *InsertQuery) SetExcluded(columns ...string) *InsertQuery {
+	for _, column := range columns {
+		q.addSet(schema.SafeQuery("? = EXCLUDED.?",
+			[]interface{}{schema.Ident(column), schema.Ident(column)}))
+	}
+	return q
+}
+
+// SetExcludedAll is like SetExcluded, but uses all the model columns
+// except primary keys and read-only columns.
+func (q *InsertQuery) SetExcludedAll() *InsertQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	for _, f := range withoutReadOnly(q.table.DataFields) {
+		if f.SkipUpdate() {
+			continue
+		}
+		q.addSet(schema.SafeQuery("? = EXCLUDED.?",
+			[]interface{}{schema.Safe(f.SQLName), schema.Safe(f.SQLName)}))
+	}
+	return q
+}
+
 func (q *InsertQuery) appendOn(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	if q.on.IsZero() {
 		return b, nil
 	}
 
+	if q.onConflict && !fmter.HasFeature(feature.InsertOnConflict) {
+		return nil, fmt.Errorf("bun: %s does not support ON CONFLICT", fmter.Dialect().Name())
+	}
+	if q.onConflict && q.conflictAction == "" {
+		return nil, errors.New("bun: OnConflict requires DoUpdate or DoNothing")
+	}
+	if q.onConstraint && fmter.Dialect().Name() != dialect.PG {
+		return nil, fmt.Errorf("bun: %s does not support ON CONFLICT ON CONSTRAINT", fmter.Dialect().Name())
+	}
+	if q.onDuplicateKeyUpdate() && len(q.where) > 0 {
+		return nil, fmt.Errorf("bun: %s does not support WHERE in ON DUPLICATE KEY UPDATE", fmter.Dialect().Name())
+	}
+
+	rowAlias := q.onDuplicateKeyUpdate() && q.db.HasFeature(feature.InsertRowAlias) &&
+		!q.hasMultiTables() && !q.defaultValues
+	if rowAlias {
+		b = append(b, " AS "...)
+		b = append(b, insertRowAlias...)
+	}
+
 	b = append(b, " ON "...)
 	b, err = q.on.AppendQuery(fmter, b)
 	if err != nil {
 		return nil, err
 	}
+	if q.conflictAction != "" {
+		b = append(b, ' ')
+		b = append(b, q.conflictAction...)
+	}
 
 	if len(q.set) > 0 {
 		if fmter.HasFeature(feature.InsertOnDuplicateKey) {
@@ -495,6 +747,7 @@ func (q *InsertQuery) appendOn(fmter schema.Formatter, b []byte) (_ []byte, err
 		if len(fields) == 0 {
 			fields = q.tableModel.Table().DataFields
 		}
+		fields = withoutReadOnly(fields)
 
 		b = q.appendSetExcluded(b, fields)
 	} else if q.onDuplicateKeyUpdate() {
@@ -506,8 +759,9 @@ func (q *InsertQuery) appendOn(fmter schema.Formatter, b []byte) (_ []byte, err
 		if len(fields) == 0 {
 			fields = q.tableModel.Table().DataFields
 		}
+		fields = withoutReadOnly(fields)
 
-		b = q.appendSetValues(b, fields)
+		b = q.appendSetValues(b, fields, rowAlias)
 	}
 
 	if len(q.where) > 0 {
@@ -523,6 +777,9 @@ func (q *InsertQuery) appendOn(fmter schema.Formatter, b []byte) (_ []byte, err
 }
 
 func (q *InsertQuery) onConflictDoUpdate() bool {
+	if q.onConflict {
+		return q.conflictAction == "DO UPDATE"
+	}
 	return strings.HasSuffix(strings.ToUpper(q.on.Query), " DO UPDATE")
 }
 
@@ -543,16 +800,27 @@ func (q *InsertQuery) appendSetExcluded(b []byte, fields []*schema.Field) []byte
 	return b
 }
 
-func (q *InsertQuery) appendSetValues(b []byte, fields []*schema.Field) []byte {
+// insertRowAlias is the alias of the inserted row that ON DUPLICATE KEY UPDATE
+// uses instead of the deprecated VALUES(col) on MySQL 8.0.20+.
+const insertRowAlias = "new"
+
+func (q *InsertQuery) appendSetValues(b []byte, fields []*schema.Field, rowAlias bool) []byte {
 	b = append(b, " "...)
 	for i, f := range fields {
 		if i > 0 {
 			b = append(b, ", "...)
 		}
 		b = append(b, f.SQLName...)
-		b = append(b, " = VALUES("...)
-		b = append(b, f.SQLName...)
-		b = append(b, ")"...)
+		if rowAlias {
+			b = append(b, " = "...)
+			b = append(b, insertRowAlias...)
+			b = append(b, '.')
+			b = append(b, f.SQLName...)
+		} else {
+			b = append(b, " = VALUES("...)
+			b = append(b, f.SQLName...)
+			b = append(b, ")"...)
+		}
 	}
 	return b
 }
@@ -575,6 +843,10 @@ func (q *InsertQuery) scanOrExec(
 		return nil, q.err
 	}
 
+	if batchSize := q.getBatchSize(dest); batchSize > 0 {
+		return q.execBatches(ctx, batchSize)
+	}
+
 	if q.table != nil {
 		if err := q.beforeInsertHook(ctx); err != nil {
 			return nil, err
@@ -585,6 +857,10 @@ func (q *InsertQuery) scanOrExec(
 	if err := q.beforeAppendModel(ctx, q); err != nil {
 		return nil, err
 	}
+	q.updateTimestamps(true)
+	if err := q.validate(ctx, q); err != nil {
+		return nil, err
+	}
 
 	// Generate the query before checking hasReturning.
 	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
@@ -612,6 +888,13 @@ func (q *InsertQuery) scanOrExec(
 			return nil, err
 		}
 	} else {
+		if q.emulatesReturning(dest) {
+			if _, ok := q.model.(*structTableModel); !ok {
+				return nil, fmt.Errorf("bun: %s supports Returning only when inserting a single struct, got %T",
+					q.db.Dialect().Name(), q.model)
+			}
+		}
+
 		res, err = q.exec(ctx, q, query)
 		if err != nil {
 			return nil, err
@@ -620,6 +903,9 @@ func (q *InsertQuery) scanOrExec(
 		if err := q.tryLastInsertID(res, dest); err != nil {
 			return nil, err
 		}
+		if err := q.trySelectReturning(ctx, dest); err != nil {
+			return nil, err
+		}
 	}
 
 	if q.table != nil {
@@ -631,6 +917,150 @@ func (q *InsertQuery) scanOrExec(
 	return res, nil
 }
 
+// maxInsertParams is the maximum number of values in a single INSERT statement.
+var maxInsertParams = map[dialect.Name]int{
+	dialect.PG:     65535,
+	dialect.MySQL:  65535,
+	dialect.SQLite: 32766,
+	dialect.MSSQL:  2100,
+}
+
+// maxInsertRows is the maximum number of rows in a single VALUES clause.
+var maxInsertRows = map[dialect.Name]int{
+	dialect.MSSQL: 1000,
+}
+
+func (q *InsertQuery) getBatchSize(dest []interface{}) int {
+	if q.batchSize < 0 || len(dest) > 0 || q.defaultValues {
+		return 0
+	}
+
+	model, ok := q.model.(*sliceTableModel)
+	if !ok {
+		return 0
+	}
+
+	batchSize := q.batchSize
+	if batchSize == 0 {
+		batchSize = q.defaultBatchSize()
+	}
+	if batchSize <= 0 || model.slice.Len() <= batchSize {
+		return 0
+	}
+	return batchSize
+}
+
+func (q *InsertQuery) defaultBatchSize() int {
+	name := q.db.Dialect().Name()
+
+	maxParams, ok := maxInsertParams[name]
+	if !ok {
+		return 0
+	}
+
+	fields, err := q.getFields()
+	if err != nil {
+		return 0
+	}
+	numCols := len(fields) + len(q.extraValues)
+	if numCols == 0 {
+		numCols = 1
+	}
+
+	batchSize := maxParams / numCols
+	if maxRows, ok := maxInsertRows[name]; ok && batchSize > maxRows {
+		batchSize = maxRows
+	}
+	return batchSize
+}
+
+func (q *InsertQuery) execBatches(ctx context.Context, batchSize int) (sql.Result, error) {
+	var res insertBatchResult
+
+	err := q.runInBatchTx(ctx, func(ctx context.Context, conn IConn) error {
+		slice := q.model.(*sliceTableModel).slice
+		sliceLen := slice.Len()
+
+		for i := 0; i < sliceLen; i += batchSize {
+			j := min(i+batchSize, sliceLen)
+
+			// The batch shares the underlying array with the slice
+			// so RETURNING values are scanned into the original elements.
+			batch := reflect.New(slice.Type())
+			batch.Elem().Set(slice.Slice3(i, j, j))
+
+			bq := *q
+			bq.batchSize = -1
+			bq.setModel(batch.Interface())
+			bq.setConn(conn)
+
+			batchRes, err := bq.scanOrExec(ctx, nil, false)
+			q.setCompiledQuery(bq.CompiledQuery())
+			if err != nil {
+				var verr *ValidationError
+				if errors.As(err, &verr) {
+					verr.Index += i
+				}
+				return err
+			}
+
+			if n, err := batchRes.RowsAffected(); err == nil {
+				res.rowsAffected += n
+			}
+			if id, err := batchRes.LastInsertId(); err == nil {
+				res.lastInsertID = id
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// runInBatchTx runs fn in a new transaction unless the query already uses one.
+// The transaction is started on the connection the query resolves to.
+func (q *InsertQuery) runInBatchTx(
+	ctx context.Context, fn func(ctx context.Context, conn IConn) error,
+) error {
+	runInTx := func(ctx context.Context, tx Tx) error {
+		return fn(ctx, tx)
+	}
+
+	switch conn := q.resolveConn(q).(type) {
+	case *sql.DB:
+		c, err := conn.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		return Conn{db: q.db, Conn: c}.RunInTx(ctx, nil, runInTx)
+	case *sql.Conn:
+		return Conn{db: q.db, Conn: conn}.RunInTx(ctx, nil, runInTx)
+	case Conn:
+		return conn.RunInTx(ctx, nil, runInTx)
+	default:
+		return fn(ctx, conn)
+	}
+}
+
+type insertBatchResult struct {
+	rowsAffected int64
+	lastInsertID int64
+}
+
+var _ sql.Result = insertBatchResult{}
+
+func (res insertBatchResult) LastInsertId() (int64, error) {
+	return res.lastInsertID, nil
+}
+
+func (res insertBatchResult) RowsAffected() (int64, error) {
+	return res.rowsAffected, nil
+}
+
 func (q *InsertQuery) beforeInsertHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeInsertHook); ok {
 		if err := hook.BeforeInsert(ctx, q); err != nil {
@@ -691,6 +1121,38 @@ func (q *InsertQuery) tryLastInsertID(res sql.Result, dest []interface{}) error
 	return nil
 }
 
+// trySelectReturning emulates RETURNING on MySQL by selecting the returned columns
+// of the inserted row using its primary key, for example, the one obtained with
+// LAST_INSERT_ID(). This costs an extra round trip and only works when a single struct
+// is inserted; see emulatesReturning.
+func (q *InsertQuery) trySelectReturning(ctx context.Context, dest []interface{}) error {
+	if !q.emulatesReturning(dest) {
+		return nil
+	}
+
+	model, ok := q.model.(*structTableModel)
+	if !ok {
+		return nil
+	}
+
+	sel := NewSelectQuery(q.db).Conn(q.resolveConn(q)).Model(model).WherePK()
+	for _, ret := range q.returning {
+		sel.addColumn(ret)
+	}
+	return sel.Scan(ctx)
+}
+
+// emulatesReturning reports whether RETURNING is emulated with a SELECT after the insert.
+func (q *InsertQuery) emulatesReturning(dest []interface{}) bool {
+	return q.db.Dialect().Name() == dialect.MySQL &&
+		!q.hasFeature(feature.InsertReturning) &&
+		len(q.returning) > 0 &&
+		q.hasReturning() &&
+		len(dest) == 0 &&
+		q.table != nil &&
+		len(q.table.PKs) > 0
+}
+
 func (q *InsertQuery) String() string {
 	buf, err := q.AppendQuery(q.db.Formatter(), nil)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
@@ -17,10 +18,14 @@ type MergeQuery struct {
 
 	using   schema.QueryWithArgs
 	on      schema.QueryWithArgs
+	key     []*schema.Field
 	when    []schema.QueryAppender
 	comment string
 }
 
+// mergeSource is the name of the CTE with the model values that is used by Key.
+const mergeSource = "_data"
+
 var _ Query = (*MergeQuery)(nil)
 
 func NewMergeQuery(db *DB) *MergeQuery {
@@ -60,6 +65,24 @@ func (q *MergeQuery) Apply(fns ...func(*MergeQuery) *MergeQuery) *MergeQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *MergeQuery) Reset() *MergeQuery {
+	q.baseQuery.reset()
+	q.returningQuery.reset()
+	q.using = schema.QueryWithArgs{}
+	q.on = schema.QueryWithArgs{}
+	// nil key means that the key is not set.
+	q.key = nil
+	q.when = resetSlice(q.when)
+	q.comment = ""
+	if q.db.dialect.Name() != dialect.MSSQL && q.db.dialect.Name() != dialect.PG {
+		q.err = errors.New("bun: merge not supported for current dialect")
+	}
+	return q
+}
+
 func (q *MergeQuery) With(name string, query Query) *MergeQuery {
 	q.addWith(name, query, false)
 	return q
@@ -112,6 +135,133 @@ func (q *MergeQuery) On(s string, args ...interface{}) *MergeQuery {
 	return q
 }
 
+// Key configures the common "upsert on key" merge: the model values are used as
+// the source rows, available as _data, and are matched with the table rows
+// by the key columns, for example:
+//
+//	db.NewMerge().Model(&models).Key("name").UpdateColumns("value").InsertAll()
+//
+// Use UpdateColumns and InsertAll, or the lower-level When* methods, to add the actions.
+func (q *MergeQuery) Key(columns ...string) *MergeQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	if len(columns) == 0 {
+		q.setErr(errors.New("bun: Key requires at least one column"))
+		return q
+	}
+
+	fields := make([]*schema.Field, len(columns))
+	var on strings.Builder
+	args := make([]interface{}, 0, 2*len(columns))
+	for i, column := range columns {
+		field, err := q.table.Field(column)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+		fields[i] = field
+
+		if i > 0 {
+			on.WriteString(" AND ")
+		}
+		on.WriteString("?TableAlias.? = " + mergeSource + ".?")
+		args = append(args, field.SQLName, field.SQLName)
+	}
+
+	q.key = fields
+	q.addWith(mergeSource, NewValuesQuery(q.db, q.model), false)
+	q.using = schema.SafeQuery(mergeSource, nil)
+	q.on = schema.SafeQuery(on.String(), args)
+	return q
+}
+
+// UpdateColumns adds `WHEN MATCHED THEN UPDATE` that copies the columns from the source row.
+// Without columns, all model columns are updated except primary keys, the Key columns,
+// and the columns that are skipped on update. It requires Key.
+func (q *MergeQuery) UpdateColumns(columns ...string) *MergeQuery {
+	if q.key == nil {
+		q.setErr(errors.New("bun: UpdateColumns requires Key"))
+		return q
+	}
+
+	var fields []*schema.Field
+	if len(columns) > 0 {
+		fields = make([]*schema.Field, len(columns))
+		for i, column := range columns {
+			field, err := q.table.Field(column)
+			if err != nil {
+				q.setErr(err)
+				return q
+			}
+			fields[i] = field
+		}
+	} else {
+		for _, f := range q.table.DataFields {
+			if !f.SkipUpdate() && !q.isKeyField(f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		q.setErr(errors.New("bun: UpdateColumns has no columns to update"))
+		return q
+	}
+
+	var query strings.Builder
+	query.WriteString("MATCHED THEN UPDATE SET ")
+	args := make([]interface{}, 0, 2*len(fields))
+	for i, f := range fields {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("? = " + mergeSource + ".?")
+		args = append(args, f.SQLName, f.SQLName)
+	}
+
+	q.when = append(q.when, schema.SafeQuery(query.String(), args))
+	return q
+}
+
+// InsertAll adds `WHEN NOT MATCHED THEN INSERT` that inserts the source row.
+// Auto-increment, identity, and read-only columns are left to the database.
+// It requires Key.
+func (q *MergeQuery) InsertAll() *MergeQuery {
+	if q.key == nil {
+		q.setErr(errors.New("bun: InsertAll requires Key"))
+		return q
+	}
+
+	var columns, values strings.Builder
+	args := make([]interface{}, 0, len(q.table.Fields))
+	for _, f := range q.table.Fields {
+		if f.AutoIncrement || f.Identity || f.ReadOnly {
+			continue
+		}
+		if len(args) > 0 {
+			columns.WriteString(", ")
+			values.WriteString(", ")
+		}
+		columns.WriteString("?")
+		values.WriteString(mergeSource + ".?")
+		args = append(args, f.SQLName)
+	}
+
+	query := "NOT MATCHED THEN INSERT (" + columns.String() + ") VALUES (" + values.String() + ")"
+	q.when = append(q.when, schema.SafeQuery(query, append(args, args...)))
+	return q
+}
+
+func (q *MergeQuery) isKeyField(field *schema.Field) bool {
+	for _, f := range q.key {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 // WhenInsert for when insert clause.
 func (q *MergeQuery) WhenInsert(expr string, fn func(q *InsertQuery) *InsertQuery) *MergeQuery {
 	sq := NewInsertQuery(q.db)
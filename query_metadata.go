@@ -0,0 +1,283 @@
+package bun
+
+import (
+	"strings"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// QueryMetadata describes the tables and columns referenced by a query.
+// It is computed from the query builder state rather than from the generated SQL,
+// so it can be used for authorization checks and query linting.
+type QueryMetadata struct {
+	// Table is the model table or nil if the query does not have a struct or slice-based model.
+	Table *schema.Table
+	// Tables are the table expressions added with Table, TableExpr, and ModelTableExpr.
+	Tables []string
+	// Relations are the joined relations including nested ones, e.g. "Author" and "Author.Avatar".
+	Relations []string
+	// Joins are the join clauses added with Join.
+	Joins []string
+	// Columns are the columns added with Column and ColumnExpr. Expressions are formatted
+	// with their arguments. Empty Columns means all columns of the model table.
+	Columns []string
+	// Where are the conditions added with Where, WhereOr, and WhereGroup.
+	Where []WhereCondition
+	// WhereColumns are the distinct columns referenced by WherePK, WhereDistinctFrom,
+	// and WhereILike. Columns used in raw Where expressions are not included.
+	WhereColumns []string
+	// WhereOpaque reports whether some conditions are raw Where expressions,
+	// in which case WhereColumns does not list every column used by the query.
+	WhereOpaque bool
+}
+
+// WhereCondition is a single condition or a group of conditions added with WhereGroup.
+type WhereCondition struct {
+	// Sep joins the condition with the previous one, e.g. "AND" or "OR".
+	// It is empty for the first condition in a group.
+	Sep string
+	// Query is the condition formatted with its arguments. It is empty for groups.
+	Query string
+	// Columns are the columns referenced by the condition. They are only known
+	// for conditions added with WhereDistinctFrom and WhereILike.
+	Columns []string
+	// Opaque reports whether the condition is a raw SQL expression added with Where
+	// or WhereOr. Bun does not parse SQL, so Columns is empty for such conditions.
+	Opaque bool
+	// Group are the nested conditions added with WhereGroup.
+	Group []WhereCondition
+}
+
+func (q *baseQuery) appendMetadata(md *QueryMetadata) error {
+	md.Table = q.table
+
+	if !q.modelTableName.IsZero() {
+		s, err := q.formatMetadata(q.modelTableName)
+		if err != nil {
+			return err
+		}
+		md.Tables = append(md.Tables, s)
+	}
+	for _, table := range q.tables {
+		s, err := q.formatMetadata(table)
+		if err != nil {
+			return err
+		}
+		md.Tables = append(md.Tables, s)
+	}
+
+	for _, col := range q.columns {
+		s, err := q.formatMetadata(col)
+		if err != nil {
+			return err
+		}
+		md.Columns = append(md.Columns, s)
+	}
+
+	if q.tableModel != nil {
+		md.Relations = appendRelationNames(md.Relations, "", q.tableModel.getJoins())
+	}
+
+	return nil
+}
+
+// formatMetadata formats the query with its arguments, but leaves identifiers unquoted.
+func (q *baseQuery) formatMetadata(query schema.QueryWithArgs) (string, error) {
+	if query.Args == nil {
+		return query.Query, nil
+	}
+	b, err := query.AppendQuery(q.db.fmter, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func appendRelationNames(names []string, prefix string, joins []relationJoin) []string {
+	for i := range joins {
+		j := &joins[i]
+		name := prefix + j.Relation.Field.GoName
+		names = append(names, name)
+		names = appendRelationNames(names, name+".", j.JoinModel.getJoins())
+	}
+	return names
+}
+
+func (q *whereBaseQuery) appendWhereMetadata(md *QueryMetadata) error {
+	conds, _, err := q.whereMetadata(q.where)
+	if err != nil {
+		return err
+	}
+	md.Where = conds
+
+	md.WhereColumns = appendWhereColumns(md.WhereColumns, conds)
+	md.WhereOpaque = hasOpaqueWhere(conds)
+	for _, f := range q.whereFields {
+		md.WhereColumns = appendUniqueString(md.WhereColumns, f.Name)
+	}
+
+	return nil
+}
+
+// whereMetadata rebuilds the groups that addWhereGroup flattens into
+// the separator, "(", and ")" markers.
+func (q *whereBaseQuery) whereMetadata(
+	where []schema.QueryWithSep,
+) ([]WhereCondition, int, error) {
+	var conds []WhereCondition
+	var groupSep string
+
+	for i := 0; i < len(where); i++ {
+		w := where[i]
+
+		if w.Query != "" {
+			s, err := q.formatMetadata(w.QueryWithArgs)
+			if err != nil {
+				return nil, 0, err
+			}
+			cols, ok := whereColumns(w.QueryWithArgs)
+			conds = append(conds, WhereCondition{
+				Sep:     whereSep(conds, w.Sep),
+				Query:   s,
+				Columns: cols,
+				Opaque:  !ok,
+			})
+			continue
+		}
+
+		switch w.Sep {
+		case "(":
+			group, n, err := q.whereMetadata(where[i+1:])
+			if err != nil {
+				return nil, 0, err
+			}
+			i += n + 1
+			conds = append(conds, WhereCondition{
+				Sep:   whereSep(conds, groupSep),
+				Group: group,
+			})
+		case ")":
+			return conds, i, nil
+		default:
+			groupSep = w.Sep
+		}
+	}
+	return conds, len(where), nil
+}
+
+func whereSep(conds []WhereCondition, sep string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(sep)
+}
+
+func appendWhereColumns(dst []string, conds []WhereCondition) []string {
+	for i := range conds {
+		for _, col := range conds[i].Columns {
+			dst = appendUniqueString(dst, col)
+		}
+		dst = appendWhereColumns(dst, conds[i].Group)
+	}
+	return dst
+}
+
+func hasOpaqueWhere(conds []WhereCondition) bool {
+	for i := range conds {
+		if conds[i].Opaque || hasOpaqueWhere(conds[i].Group) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUniqueString(ss []string, s string) []string {
+	for _, v := range ss {
+		if v == s {
+			return ss
+		}
+	}
+	return append(ss, s)
+}
+
+// whereColumns returns the column of a condition added with WhereDistinctFrom or WhereILike.
+// Other conditions are raw SQL expressions and are reported as opaque.
+func whereColumns(query schema.QueryWithArgs) ([]string, bool) {
+	if query.Query != "?" || len(query.Args) != 1 {
+		return nil, false
+	}
+	switch arg := query.Args[0].(type) {
+	case distinctFrom:
+		return []string{arg.column}, true
+	case iLike:
+		return []string{arg.column}, true
+	}
+	return nil, false
+}
+
+func (q *baseQuery) appendJoinsMetadata(md *QueryMetadata, joins []joinQuery) error {
+	for i := range joins {
+		b, err := joins[i].AppendQuery(q.db.fmter, nil)
+		if err != nil {
+			return err
+		}
+		md.Joins = append(md.Joins, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// Metadata returns the tables and columns referenced by the query.
+func (q *SelectQuery) Metadata() (*QueryMetadata, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	md := new(QueryMetadata)
+	if err := q.appendMetadata(md); err != nil {
+		return nil, err
+	}
+	if err := q.appendJoinsMetadata(md, q.joins); err != nil {
+		return nil, err
+	}
+	if err := q.appendWhereMetadata(md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// Metadata returns the tables and columns referenced by the query.
+func (q *UpdateQuery) Metadata() (*QueryMetadata, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	md := new(QueryMetadata)
+	if err := q.appendMetadata(md); err != nil {
+		return nil, err
+	}
+	if err := q.appendJoinsMetadata(md, q.joins); err != nil {
+		return nil, err
+	}
+	if err := q.appendWhereMetadata(md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// Metadata returns the tables and columns referenced by the query.
+func (q *DeleteQuery) Metadata() (*QueryMetadata, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	md := new(QueryMetadata)
+	if err := q.appendMetadata(md); err != nil {
+		return nil, err
+	}
+	if err := q.appendWhereMetadata(md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
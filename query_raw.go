@@ -3,6 +3,9 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 
 	"github.com/uptrace/bun/schema"
 )
@@ -30,11 +33,35 @@ func (q *RawQuery) Conn(db IConn) *RawQuery {
 	return q
 }
 
+// WithoutHooks disables the query hooks registered with DB.AddQueryHook for this query,
+// for example, to exclude a noisy health check from logging and tracing.
+func (q *RawQuery) WithoutHooks() *RawQuery {
+	q.flags = q.flags.Set(withoutHooksFlag)
+	return q
+}
+
+// queryTemplate returns the query and the args before interpolation,
+// see QueryEvent.QueryTemplate.
+func (q *RawQuery) queryTemplate() (string, []interface{}) {
+	return q.query, q.args
+}
+
 func (q *RawQuery) Err(err error) *RawQuery {
 	q.setErr(err)
 	return q
 }
 
+// Reset clears the query state and sets the new query and args, so the query can be reused,
+// for example, with sync.Pool. The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *RawQuery) Reset(query string, args ...interface{}) *RawQuery {
+	q.baseQuery.reset()
+	q.query = query
+	q.args = args
+	q.comment = ""
+	return q
+}
+
 func (q *RawQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	return q.scanOrExec(ctx, dest, len(dest) > 0)
 }
@@ -44,6 +71,92 @@ func (q *RawQuery) Scan(ctx context.Context, dest ...interface{}) error {
 	return err
 }
 
+// ScanMulti executes a query that returns multiple result sets, for example,
+// a stored procedure call, and scans each result set into the corresponding destination:
+//
+//	var users []User
+//	var count int
+//	err := db.NewRaw("EXEC get_users").ScanMulti(ctx, &users, &count)
+//
+// Each destination accepts the same values as a single Scan destination.
+// ScanMulti returns an error if the query returns fewer result sets than destinations.
+func (q *RawQuery) ScanMulti(ctx context.Context, dest ...interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	if len(dest) == 0 {
+		return errors.New("bun: ScanMulti requires at least one destination")
+	}
+
+	models := make([]Model, len(dest))
+	for i := range dest {
+		model, err := newModel(q.db, dest[i:i+1])
+		if err != nil {
+			return err
+		}
+		models[i] = model
+	}
+
+	query := q.db.format(q.query, q.args)
+
+	ctx, event := q.db.beforeQuery(ctx, q, query, nil, query, q.model)
+	res, err := q.scanMulti(ctx, query, models)
+	err = q.db.afterQuery(ctx, event, res, err)
+	return err
+}
+
+func (q *RawQuery) scanMulti(ctx context.Context, query string, models []Model) (sql.Result, error) {
+	rows, err := q.resolveConn(q).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var numRow int
+	for i, model := range models {
+		if i > 0 && !rows.NextResultSet() {
+			if err := rows.Err(); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("bun: query returned %d result sets, but ScanMulti got %d destinations",
+				i, len(models))
+		}
+
+		n, err := model.ScanRows(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 && isSingleRowModel(model) {
+			return nil, sql.ErrNoRows
+		}
+		numRow += n
+	}
+
+	return driver.RowsAffected(numRow), nil
+}
+
+// ScanColumns executes the query and returns the names of the result columns
+// together with the rows, where values are ordered the same way as the columns.
+func (q *RawQuery) ScanColumns(ctx context.Context) ([]string, [][]interface{}, error) {
+	var rows [][]interface{}
+	model := newColumnsModel(q.db, &rows)
+	if err := q.Scan(ctx, model); err != nil {
+		return nil, nil, err
+	}
+	return model.columns, rows, nil
+}
+
+// Columns executes the query and returns the types of the result columns without
+// scanning the rows. The query is still executed by the database, so add LIMIT 0
+// or an equivalent when only the shape of the result is needed.
+func (q *RawQuery) Columns(ctx context.Context) ([]ColumnType, error) {
+	var columns []ColumnType
+	if err := q.Scan(ctx, &columnTypesModel{dest: &columns}); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
 // Comment adds a comment to the query, wrapped by /* ... */.
 func (q *RawQuery) Comment(comment string) *RawQuery {
 	q.comment = comment
@@ -6,6 +6,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/uptrace/bun/dialect"
@@ -20,6 +23,11 @@ type union struct {
 	query *SelectQuery
 }
 
+type windowQuery struct {
+	name  string
+	query schema.QueryWithArgs
+}
+
 type SelectQuery struct {
 	whereBaseQuery
 	idxHintsQuery
@@ -27,9 +35,15 @@ type SelectQuery struct {
 
 	distinctOn []schema.QueryWithArgs
 	joins      []joinQuery
+	jsonRels   []*relationJSON
 	group      []schema.QueryWithArgs
 	having     []schema.QueryWithArgs
+	windows    []windowQuery
 	selFor     schema.QueryWithArgs
+	maxRows    int
+	withTies   bool
+
+	discardUnknownColumns bool
 
 	union   []union
 	comment string
@@ -52,6 +66,13 @@ func (q *SelectQuery) Conn(db IConn) *SelectQuery {
 	return q
 }
 
+// WithoutHooks disables the query hooks registered with DB.AddQueryHook for this query,
+// for example, to exclude a noisy health check from logging and tracing.
+func (q *SelectQuery) WithoutHooks() *SelectQuery {
+	q.flags = q.flags.Set(withoutHooksFlag)
+	return q
+}
+
 func (q *SelectQuery) Model(model interface{}) *SelectQuery {
 	q.setModel(model)
 	return q
@@ -72,6 +93,37 @@ func (q *SelectQuery) Apply(fns ...func(*SelectQuery) *SelectQuery) *SelectQuery
 	return q
 }
 
+// WithScope applies the scope to the query. A nil scope is ignored.
+func (q *SelectQuery) WithScope(scope Scope) *SelectQuery {
+	if scope != nil {
+		scope.ApplySelect(q)
+	}
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *SelectQuery) Reset() *SelectQuery {
+	q.whereBaseQuery.reset()
+	q.idxHintsQuery = idxHintsQuery{}
+	q.orderLimitOffsetQuery.reset()
+	// Empty distinctOn means DISTINCT.
+	q.distinctOn = nil
+	q.joins = resetSlice(q.joins)
+	q.jsonRels = resetSlice(q.jsonRels)
+	q.group = resetSlice(q.group)
+	q.having = resetSlice(q.having)
+	q.windows = resetSlice(q.windows)
+	q.selFor = schema.QueryWithArgs{}
+	q.maxRows = 0
+	q.withTies = false
+	q.discardUnknownColumns = false
+	q.union = resetSlice(q.union)
+	q.comment = ""
+	return q
+}
+
 func (q *SelectQuery) With(name string, query Query) *SelectQuery {
 	q.addWith(name, query, false)
 	return q
@@ -106,13 +158,38 @@ func (q *SelectQuery) TableExpr(query string, args ...interface{}) *SelectQuery
 	return q
 }
 
+// TableSubquery adds `(subquery) AS alias` to the FROM clause.
+// The subquery args are bound when the subquery is rendered.
+func (q *SelectQuery) TableSubquery(sub *SelectQuery, alias string) *SelectQuery {
+	q.addTable(schema.SafeQuery("(?)"+q.aliasSep()+"?", []interface{}{sub, schema.Ident(alias)}))
+	return q
+}
+
 func (q *SelectQuery) ModelTableExpr(query string, args ...interface{}) *SelectQuery {
 	q.modelTableName = schema.SafeQuery(query, args)
 	return q
 }
 
+// WithTableName overrides the model table name for this query, for example,
+// to query a shard of the table. Unlike ModelTableExpr, it keeps the model alias
+// and the ?TableName placeholder resolves to the new name.
+func (q *SelectQuery) WithTableName(name string) *SelectQuery {
+	q.tableName = name
+	return q
+}
+
+// TableAlias overrides the model table alias for this query,
+// including the ?TableAlias placeholder and relation joins.
+func (q *SelectQuery) TableAlias(alias string) *SelectQuery {
+	q.tableAlias = schema.Safe(q.db.fmter.AppendIdent(nil, alias))
+	return q
+}
+
 //------------------------------------------------------------------------------
 
+// Column adds columns to the query. Names are quoted as identifiers, but `*` and
+// dots are kept, so "users.*" and "users.password" select columns of other tables.
+// Use ModelColumn for column names that come from untrusted input.
 func (q *SelectQuery) Column(columns ...string) *SelectQuery {
 	for _, column := range columns {
 		q.addColumn(schema.UnsafeIdent(column))
@@ -120,11 +197,65 @@ func (q *SelectQuery) Column(columns ...string) *SelectQuery {
 	return q
 }
 
+// ModelColumn is like Column, but only accepts the model column names and
+// sets an error otherwise, for example:
+//
+//	db.NewSelect().Model(&users).ModelColumn(req.URL.Query()["field"]...)
+//
+// The model must be set before calling ModelColumn.
+func (q *SelectQuery) ModelColumn(columns ...string) *SelectQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	for _, column := range columns {
+		field, err := q.table.Field(column)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+		q.addColumn(schema.UnsafeIdent(field.Name))
+	}
+	return q
+}
+
+// ColumnExpr adds a column expression to the query. Aliases that join struct field names
+// with double underscores are scanned into nested structs, including computed columns:
+//
+//	type Stats struct {
+//		Total int
+//		Last  struct{ ID int64 }
+//	}
+//	var dest struct{ Stats Stats }
+//	err := db.NewSelect().Model((*Book)(nil)).
+//		ColumnExpr("count(*) AS stats__total").
+//		ColumnExpr("max(id) AS stats__last__id").
+//		Scan(ctx, &dest)
+//
+// Nil pointers to nested structs are allocated when a non-NULL value is scanned.
+//
+// The query is not escaped, so pass untrusted identifiers as arguments with bun.Ident,
+// e.g. ColumnExpr("lower(?)", bun.Ident(name)), which quotes the name.
 func (q *SelectQuery) ColumnExpr(query string, args ...interface{}) *SelectQuery {
 	q.addColumn(schema.SafeQuery(query, args))
 	return q
 }
 
+// DialectExpr adds a column expression picked from exprs by the current dialect, e.g.
+//
+//	q.DialectExpr(map[dialect.Name]string{
+//		dialect.PG:    "data->>'name'",
+//		dialect.MySQL: "JSON_UNQUOTE(JSON_EXTRACT(data, '$.name'))",
+//	})
+//
+// The query fails with an error if exprs does not contain the current dialect.
+func (q *SelectQuery) DialectExpr(exprs map[dialect.Name]string, args ...interface{}) *SelectQuery {
+	if query, ok := q.dialectExpr(exprs, args); ok {
+		q.addColumn(query)
+	}
+	return q
+}
+
 func (q *SelectQuery) ExcludeColumn(columns ...string) *SelectQuery {
 	q.excludeColumn(columns)
 	return q
@@ -137,6 +268,29 @@ func (q *SelectQuery) WherePK(cols ...string) *SelectQuery {
 	return q
 }
 
+// WhereDistinctFrom adds a NULL-safe inequality condition, i.e. `column IS DISTINCT FROM value`,
+// which, unlike `<>`, is true when only one of the sides is NULL. Databases without
+// IS DISTINCT FROM use an equivalent expression, e.g. `NOT (column <=> value)` on MySQL.
+func (q *SelectQuery) WhereDistinctFrom(column string, value interface{}) *SelectQuery {
+	q.addWhereDistinctFrom(column, value, false)
+	return q
+}
+
+// WhereNotDistinctFrom adds a NULL-safe equality condition, i.e. `column IS NOT DISTINCT FROM value`,
+// which, unlike `=`, is true when both sides are NULL.
+func (q *SelectQuery) WhereNotDistinctFrom(column string, value interface{}) *SelectQuery {
+	q.addWhereDistinctFrom(column, value, true)
+	return q
+}
+
+// WhereILike adds a case-insensitive LIKE condition, i.e. `column ILIKE pattern` on PostgreSQL
+// and `LOWER(column) LIKE LOWER(pattern)` elsewhere. The % and _ wildcards in the pattern
+// are not escaped; use EscapeLike to match user input literally.
+func (q *SelectQuery) WhereILike(column string, pattern string) *SelectQuery {
+	q.addWhereILike(column, pattern)
+	return q
+}
+
 func (q *SelectQuery) Where(query string, args ...interface{}) *SelectQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
 	return q
@@ -147,6 +301,23 @@ func (q *SelectQuery) WhereOr(query string, args ...interface{}) *SelectQuery {
 	return q
 }
 
+// WhereIf is like Where, but only adds the condition when cond is true, for example,
+// WhereIf(filter.Name != "", "name = ?", filter.Name).
+func (q *SelectQuery) WhereIf(cond bool, query string, args ...interface{}) *SelectQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
+	}
+	return q
+}
+
+// WhereOrIf is like WhereOr, but only adds the condition when cond is true.
+func (q *SelectQuery) WhereOrIf(cond bool, query string, args ...interface{}) *SelectQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " OR "))
+	}
+	return q
+}
+
 func (q *SelectQuery) WhereGroup(sep string, fn func(*SelectQuery) *SelectQuery) *SelectQuery {
 	saved := q.where
 	q.where = nil
@@ -161,6 +332,24 @@ func (q *SelectQuery) WhereGroup(sep string, fn func(*SelectQuery) *SelectQuery)
 	return q
 }
 
+// WhereOrGroup is a shorthand for WhereGroup(" OR ", fn), which joins the group of
+// conditions to the previous conditions with OR, for example:
+//
+//	q.Where("status = ?", "active").WhereOrGroup(func(q *bun.SelectQuery) *bun.SelectQuery {
+//		return q.Where("role = ?", "admin").Where("verified")
+//	})
+//
+// produces `WHERE (status = 'active') OR ((role = 'admin') AND (verified))`.
+func (q *SelectQuery) WhereOrGroup(fn func(*SelectQuery) *SelectQuery) *SelectQuery {
+	return q.WhereGroup(" OR ", fn)
+}
+
+// WhereAndGroup is a shorthand for WhereGroup(" AND ", fn), which joins the group of
+// conditions to the previous conditions with AND.
+func (q *SelectQuery) WhereAndGroup(fn func(*SelectQuery) *SelectQuery) *SelectQuery {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *SelectQuery) WhereDeleted() *SelectQuery {
 	q.whereDeleted()
 	return q
@@ -276,6 +465,41 @@ func (q *SelectQuery) Having(having string, args ...interface{}) *SelectQuery {
 	return q
 }
 
+// Window adds a named window definition to the WINDOW clause, for example,
+// Window("w", "PARTITION BY category ORDER BY price"). Window functions
+// can then reference the window by name, e.g. ColumnExpr("row_number() OVER w").
+// The name is not quoted so it matches unquoted references in the column expressions.
+func (q *SelectQuery) Window(name, query string, args ...interface{}) *SelectQuery {
+	q.windows = append(q.windows, windowQuery{
+		name:  name,
+		query: schema.SafeQuery(query, args),
+	})
+	return q
+}
+
+// WhereTSMatch adds a PostgreSQL full-text search condition column @@ to_tsquery(query),
+// where column is a tsvector column, for example, pgdialect.TSVector.
+func (q *SelectQuery) WhereTSMatch(column, query string) *SelectQuery {
+	if !q.hasFeature(feature.FullTextSearch) {
+		q.setErr(feature.NewNotSupportError(feature.FullTextSearch))
+		return q
+	}
+	q.addWhere(schema.SafeQueryWithSep(
+		"? @@ to_tsquery(?)", []interface{}{Ident(column), query}, " AND "))
+	return q
+}
+
+// OrderByRank orders rows by ts_rank(column, to_tsquery(query)) so that the best
+// full-text search matches come first.
+func (q *SelectQuery) OrderByRank(column, query string) *SelectQuery {
+	if !q.hasFeature(feature.FullTextSearch) {
+		q.setErr(feature.NewNotSupportError(feature.FullTextSearch))
+		return q
+	}
+	q.addOrderExpr("ts_rank(?, to_tsquery(?)) DESC", Ident(column), query)
+	return q
+}
+
 func (q *SelectQuery) Order(orders ...string) *SelectQuery {
 	q.addOrder(orders...)
 	return q
@@ -286,6 +510,23 @@ func (q *SelectQuery) OrderExpr(query string, args ...interface{}) *SelectQuery
 	return q
 }
 
+// MaxRows makes Scan return an error when the query returns more than n rows,
+// which protects against loading a huge result set into memory, for example,
+// when the query is built dynamically and a LIMIT is missing.
+// Unlike Limit, MaxRows does not change the generated SQL: the check is done
+// while scanning rows into a slice and the database may still produce all rows.
+func (q *SelectQuery) MaxRows(n int) *SelectQuery {
+	q.maxRows = n
+	return q
+}
+
+// DiscardUnknownColumns makes Scan ignore columns that don't have a matching
+// model field, which is the per-query equivalent of the WithDiscardUnknownColumns option.
+func (q *SelectQuery) DiscardUnknownColumns() *SelectQuery {
+	q.discardUnknownColumns = true
+	return q
+}
+
 func (q *SelectQuery) Limit(n int) *SelectQuery {
 	q.setLimit(n)
 	return q
@@ -296,6 +537,39 @@ func (q *SelectQuery) Offset(n int) *SelectQuery {
 	return q
 }
 
+// WithTies makes the query return rows that tie with the last row according
+// to the ORDER BY clause, for example, Limit(3).WithTies() renders
+// FETCH FIRST 3 ROWS WITH TIES. It requires Order and Limit and
+// is only supported by PostgreSQL 13+ and Oracle.
+func (q *SelectQuery) WithTies() *SelectQuery {
+	q.withTies = true
+	return q
+}
+
+func (q *SelectQuery) appendFetchWithTies(fmter schema.Formatter, b []byte) ([]byte, error) {
+	if !fmter.HasFeature(feature.FetchWithTies) {
+		return nil, feature.NewNotSupportError(feature.FetchWithTies)
+	}
+	if len(q.order) == 0 {
+		return nil, errors.New("bun: WithTies requires Order")
+	}
+	if q.limit <= 0 {
+		return nil, errors.New("bun: WithTies requires Limit")
+	}
+
+	if q.offset > 0 {
+		b = append(b, " OFFSET "...)
+		b = strconv.AppendInt(b, int64(q.offset), 10)
+		b = append(b, " ROWS"...)
+	}
+
+	b = append(b, " FETCH FIRST "...)
+	b = strconv.AppendInt(b, int64(q.limit), 10)
+	b = append(b, " ROWS WITH TIES"...)
+
+	return b, nil
+}
+
 func (q *SelectQuery) For(s string, args ...interface{}) *SelectQuery {
 	q.selFor = schema.SafeQuery(s, args)
 	return q
@@ -344,6 +618,99 @@ func (q *SelectQuery) Join(join string, args ...interface{}) *SelectQuery {
 	return q
 }
 
+// LeftJoin adds `LEFT JOIN table ON cond` to the query. The table name and the optional
+// alias, e.g. "users AS u", are quoted. Use JoinOn and JoinOnOr to add more conditions.
+func (q *SelectQuery) LeftJoin(table, cond string, args ...interface{}) *SelectQuery {
+	return q.typedJoin("LEFT JOIN", table, cond, args)
+}
+
+// RightJoin adds `RIGHT JOIN table ON cond` to the query. See LeftJoin for details.
+func (q *SelectQuery) RightJoin(table, cond string, args ...interface{}) *SelectQuery {
+	return q.typedJoin("RIGHT JOIN", table, cond, args)
+}
+
+// FullJoin adds `FULL JOIN table ON cond` to the query. See LeftJoin for details.
+// The query returns an error on databases that don't support FULL JOIN, e.g. MySQL.
+func (q *SelectQuery) FullJoin(table, cond string, args ...interface{}) *SelectQuery {
+	return q.typedJoin("FULL JOIN", table, cond, args)
+}
+
+func (q *SelectQuery) typedJoin(kind, table, cond string, args []interface{}) *SelectQuery {
+	join, err := q.joinTable(kind, table)
+	if err != nil {
+		q.setErr(err)
+		return q
+	}
+
+	j := joinQuery{join: join}
+	if kind == "FULL JOIN" {
+		j.feature = feature.FullJoin
+	}
+	if cond != "" {
+		j.on = append(j.on, schema.SafeQueryWithSep(cond, args, " AND "))
+	}
+	q.joins = append(q.joins, j)
+	return q
+}
+
+// joinTable parses "table", "table alias", or "table AS alias" and quotes the identifiers.
+func (q *SelectQuery) joinTable(kind, table string) (schema.QueryWithArgs, error) {
+	fields := strings.Fields(table)
+	if len(fields) == 3 && strings.EqualFold(fields[1], "AS") {
+		fields = []string{fields[0], fields[2]}
+	}
+
+	switch len(fields) {
+	case 1:
+		return schema.SafeQuery(kind+" ?", []interface{}{schema.Ident(fields[0])}), nil
+	case 2:
+		return schema.SafeQuery(kind+" ?"+q.aliasSep()+"?",
+			[]interface{}{schema.Ident(fields[0]), schema.Ident(fields[1])}), nil
+	default:
+		return schema.QueryWithArgs{}, fmt.Errorf("bun: invalid %s table: %q", kind, table)
+	}
+}
+
+// JoinSubquery adds `JOIN (subquery) AS alias ON cond` to the query.
+// The subquery is rendered with its own args, so cond args are
+// bound independently. Use JoinOn and JoinOnOr to add more conditions.
+func (q *SelectQuery) JoinSubquery(
+	sub *SelectQuery, alias, cond string, args ...interface{},
+) *SelectQuery {
+	return q.subqueryJoin("JOIN", sub, alias, cond, args)
+}
+
+// LeftJoinSubquery adds `LEFT JOIN (subquery) AS alias ON cond` to the query.
+// See JoinSubquery for details.
+func (q *SelectQuery) LeftJoinSubquery(
+	sub *SelectQuery, alias, cond string, args ...interface{},
+) *SelectQuery {
+	return q.subqueryJoin("LEFT JOIN", sub, alias, cond, args)
+}
+
+func (q *SelectQuery) subqueryJoin(
+	kind string, sub *SelectQuery, alias, cond string, args []interface{},
+) *SelectQuery {
+	j := joinQuery{
+		join: schema.SafeQuery(kind+" (?)"+q.aliasSep()+"?",
+			[]interface{}{sub, schema.Ident(alias)}),
+	}
+	if cond != "" {
+		j.on = append(j.on, schema.SafeQueryWithSep(cond, args, " AND "))
+	}
+	q.joins = append(q.joins, j)
+	return q
+}
+
+// aliasSep returns the separator between a table and its alias.
+// Oracle does not allow AS for table aliases.
+func (q *SelectQuery) aliasSep() string {
+	if q.db.dialect.Name() == dialect.Oracle {
+		return " "
+	}
+	return " AS "
+}
+
 func (q *SelectQuery) JoinOn(cond string, args ...interface{}) *SelectQuery {
 	return q.joinOn(cond, args, " AND ")
 }
@@ -371,13 +738,13 @@ func (q *SelectQuery) Relation(name string, apply ...func(*SelectQuery) *SelectQ
 	}
 
 	if q.tableModel == nil {
-		q.setErr(errNilModel)
+		q.setErr(ErrNilModel)
 		return q
 	}
 
 	join := q.tableModel.join(name)
 	if join == nil {
-		q.setErr(fmt.Errorf("%s does not have relation=%q", q.table, name))
+		q.setErr(unknownRelationError(q.db, q.table, name))
 		return q
 	}
 
@@ -386,6 +753,155 @@ func (q *SelectQuery) Relation(name string, apply ...func(*SelectQuery) *SelectQ
 	return q
 }
 
+// Preload is an alias for Relation.
+func (q *SelectQuery) Preload(name string, apply ...func(*SelectQuery) *SelectQuery) *SelectQuery {
+	return q.Relation(name, apply...)
+}
+
+// RelationColumns is a shorthand for Relation with an apply function that selects
+// only the given columns of the relation, for example:
+//
+//	q.RelationColumns("Author", "id", "name")
+//
+// The relation columns that are needed to assemble the relation are always selected.
+func (q *SelectQuery) RelationColumns(name string, columns ...string) *SelectQuery {
+	if q.tableModel == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+
+	join := q.tableModel.join(name)
+	if join == nil {
+		q.setErr(unknownRelationError(q.db, q.table, name))
+		return q
+	}
+
+	q.applyToRelation(join, func(q *SelectQuery) *SelectQuery {
+		q = q.Column(columns...)
+		q.columns = appendRelationKeyColumns(q.columns, join.Relation)
+		return q
+	})
+
+	return q
+}
+
+// RelationExcludeColumns is a shorthand for Relation with an apply function that
+// excludes the given columns of the relation, for example:
+//
+//	q.RelationExcludeColumns("User", "password_hash")
+//
+// Use "*" to exclude all columns. Has-many and many-to-many relations are assembled
+// after the main query, so their join keys are never excluded. Has-one and belongs-to
+// relations are joined in the main query and do not need any of their columns.
+func (q *SelectQuery) RelationExcludeColumns(name string, columns ...string) *SelectQuery {
+	return q.Relation(name, func(q *SelectQuery) *SelectQuery {
+		return q.ExcludeColumn(columns...)
+	})
+}
+
+// RelationJSON loads the relation in the main query as a JSON column using
+// correlated subqueries with row_to_json and json_agg, for example:
+//
+//	db.NewSelect().Model(&users).RelationJSON("Posts.Comments").Scan(ctx)
+//
+// Unlike Relation, which uses a separate query for has-many and m2m relations,
+// all rows are loaded in a single round trip, which can be faster for deeply nested
+// relations at the cost of a more expensive query. Nested relations are separated
+// with dots. The feature is experimental and is only supported by PostgreSQL.
+//
+// The relation models can't have bytea or array columns, because row_to_json encodes
+// them as a hex string and a JSON array, which can't be scanned into the fields;
+// such relations return an error, so use Relation to load them.
+func (q *SelectQuery) RelationJSON(name string) *SelectQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	if !q.hasFeature(feature.RelationJSON) {
+		q.setErr(feature.NewNotSupportError(feature.RelationJSON))
+		return q
+	}
+
+	path := strings.Split(name, ".")
+	rels, ok := addRelationJSON(q.db, q.jsonRels, q.table, path)
+	if !ok {
+		q.setErr(unknownRelationError(q.db, q.table, name))
+		return q
+	}
+
+	table := q.table
+	for _, part := range path {
+		table = q.db.Table(table.Relations[part].JoinTable.Type)
+		if field := unsupportedJSONField(table); field != nil {
+			q.setErr(fmt.Errorf("bun: RelationJSON can't scan %s.%s (bytea and array columns are not supported)",
+				table.TypeName, field.GoName))
+			return q
+		}
+	}
+
+	q.jsonRels = rels
+	return q
+}
+
+// appendRelationKeyColumns appends the join keys and the polymorphic field of the
+// relation unless they are already selected.
+func appendRelationKeyColumns(
+	columns []schema.QueryWithArgs, rel *schema.Relation,
+) []schema.QueryWithArgs {
+	keys := rel.JoinPKs
+	if rel.PolymorphicField != nil {
+		keys = append(keys[:len(keys):len(keys)], rel.PolymorphicField)
+	}
+
+outer:
+	for _, key := range keys {
+		for _, col := range columns {
+			if col.Args == nil && col.Query == key.Name {
+				continue outer
+			}
+		}
+		columns = append(columns, schema.UnsafeIdent(key.Name))
+	}
+	return columns
+}
+
+// unknownRelationError returns an error for the relation name that can't be resolved.
+// The error reports the part of the name that is not found and lists the relations
+// that are available on that model, which makes typos easy to spot.
+func unknownRelationError(db *DB, table *schema.Table, name string) error {
+	path := strings.Split(name, ".")
+	for i, part := range path {
+		rel, ok := table.Relations[part]
+		if !ok {
+			return newUnknownRelationError(table, name, path[:i+1])
+		}
+		// Join tables are initialized lazily, so get the table with its relations.
+		table = db.Table(rel.JoinTable.Type)
+	}
+	return fmt.Errorf("%s does not have relation=%q", table, name)
+}
+
+func newUnknownRelationError(table *schema.Table, name string, path []string) error {
+	names := make([]string, 0, len(table.Relations))
+	for relName := range table.Relations {
+		names = append(names, strconv.Quote(relName))
+	}
+	sort.Strings(names)
+
+	var available string
+	if len(names) > 0 {
+		available = "available relations: " + strings.Join(names, ", ")
+	} else {
+		available = "the model has no relations"
+	}
+
+	if len(path) > 1 {
+		return fmt.Errorf("%s does not have relation=%q in %q (%s)",
+			table, path[len(path)-1], name, available)
+	}
+	return fmt.Errorf("%s does not have relation=%q (%s)", table, name, available)
+}
+
 type RelationOpts struct {
 	// Apply applies additional options to the relation.
 	Apply func(*SelectQuery) *SelectQuery
@@ -396,13 +912,13 @@ type RelationOpts struct {
 // RelationWithOpts adds a relation to the query with additional options.
 func (q *SelectQuery) RelationWithOpts(name string, opts RelationOpts) *SelectQuery {
 	if q.tableModel == nil {
-		q.setErr(errNilModel)
+		q.setErr(ErrNilModel)
 		return q
 	}
 
 	join := q.tableModel.join(name)
 	if join == nil {
-		q.setErr(fmt.Errorf("%s does not have relation=%q", q.table, name))
+		q.setErr(unknownRelationError(q.db, q.table, name))
 		return q
 	}
 
@@ -469,6 +985,15 @@ func (q *SelectQuery) _forEachInlineRelJoin(fn func(*relationJoin) error, joins
 	return nil
 }
 
+// newRelationQuery returns a query used to select has-many and m2m relations.
+func (q *SelectQuery) newRelationQuery() *SelectQuery {
+	qq := q.db.NewSelect().Conn(q.conn)
+	if q.hooksDisabled() {
+		qq = qq.WithoutHooks()
+	}
+	return qq
+}
+
 func (q *SelectQuery) selectJoins(ctx context.Context, joins []relationJoin) error {
 	for i := range joins {
 		j := &joins[i]
@@ -479,9 +1004,9 @@ func (q *SelectQuery) selectJoins(ctx context.Context, joins []relationJoin) err
 		case schema.HasOneRelation, schema.BelongsToRelation:
 			err = q.selectJoins(ctx, j.JoinModel.getJoins())
 		case schema.HasManyRelation:
-			err = j.selectMany(ctx, q.db.NewSelect().Conn(q.conn))
+			err = j.selectMany(ctx, q.newRelationQuery())
 		case schema.ManyToManyRelation:
-			err = j.selectM2M(ctx, q.db.NewSelect().Conn(q.conn))
+			err = j.selectM2M(ctx, q.newRelationQuery())
 		default:
 			panic("not reached")
 		}
@@ -635,13 +1160,37 @@ func (q *SelectQuery) appendQuery(
 		}
 	}
 
+	if len(q.windows) > 0 {
+		if !fmter.HasFeature(feature.WindowClause) {
+			return nil, feature.NewNotSupportError(feature.WindowClause)
+		}
+
+		b = append(b, " WINDOW "...)
+		for i, w := range q.windows {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = append(b, w.name...)
+			b = append(b, " AS ("...)
+			b, err = w.query.AppendQuery(fmter, b)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, ')')
+		}
+	}
+
 	if !count {
 		b, err = q.appendOrder(fmter, b)
 		if err != nil {
 			return nil, err
 		}
 
-		b, err = q.appendLimitOffset(fmter, b)
+		if q.withTies {
+			b, err = q.appendFetchWithTies(fmter, b)
+		} else {
+			b, err = q.appendLimitOffset(fmter, b)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -688,7 +1237,7 @@ func (q *SelectQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte,
 
 			if col.Args == nil && q.table != nil {
 				if field, ok := q.table.FieldMap[col.Query]; ok {
-					b = append(b, q.table.SQLAlias...)
+					b = append(b, q.sqlAlias()...)
 					b = append(b, '.')
 					b = append(b, field.SQLName...)
 					continue
@@ -702,16 +1251,26 @@ func (q *SelectQuery) appendColumns(fmter schema.Formatter, b []byte) (_ []byte,
 		}
 	case q.table != nil:
 		if len(q.table.Fields) > 10 && fmter.IsNop() {
-			b = append(b, q.table.SQLAlias...)
+			b = append(b, q.sqlAlias()...)
 			b = append(b, '.')
 			b = fmter.Dialect().AppendString(b, fmt.Sprintf("%d columns", len(q.table.Fields)))
 		} else {
-			b = appendColumns(b, q.table.SQLAlias, q.table.Fields)
+			b = appendColumns(b, q.sqlAlias(), q.table.Fields)
 		}
 	default:
 		b = append(b, '*')
 	}
 
+	for _, rel := range q.jsonRels {
+		b = append(b, ", "...)
+		b, err = rel.appendQuery(fmter, b, q.sqlAlias(), rel.rel.Field.Name, q.flags)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, " AS "...)
+		b = fmter.AppendName(b, rel.rel.Field.Name)
+	}
+
 	if err := q.forEachInlineRelJoin(func(join *relationJoin) error {
 		if len(b) != start {
 			b = append(b, ", "...)
@@ -800,7 +1359,7 @@ func (q *SelectQuery) Rows(ctx context.Context) (*sql.Rows, error) {
 
 	ctx, event := q.db.beforeQuery(ctx, q, query, nil, query, q.model)
 	rows, err := q.resolveConn(q).QueryContext(ctx, query)
-	q.db.afterQuery(ctx, event, nil, err)
+	err = q.db.afterQuery(ctx, event, nil, err)
 	return rows, err
 }
 
@@ -844,6 +1403,110 @@ func (q *SelectQuery) Scan(ctx context.Context, dest ...interface{}) error {
 	return err
 }
 
+// ScanPositional is like Scan, but it maps the result columns to the model fields
+// by position instead of by name, which is faster when scanning many wide rows.
+// The order of the selected columns must match the order of the model fields,
+// which is the case unless the columns are overridden with Column or ColumnExpr.
+// Relations are not supported.
+func (q *SelectQuery) ScanPositional(ctx context.Context, dest ...interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	if q.tableModel != nil && len(q.tableModel.getJoins()) > 0 {
+		return errors.New("bun: ScanPositional does not support relations")
+	}
+
+	model, err := q.getModel(dest)
+	if err != nil {
+		return err
+	}
+
+	var sm *structTableModel
+	switch model := model.(type) {
+	case *structTableModel:
+		sm = model
+	case *sliceTableModel:
+		sm = &model.structTableModel
+	default:
+		return fmt.Errorf("bun: ScanPositional(unsupported %T)", model.Value())
+	}
+
+	sm.positional = true
+	defer func() {
+		sm.positional = false
+	}()
+
+	return q.Scan(ctx, model)
+}
+
+// ScanColumns executes the query and returns the names of the result columns
+// together with the rows, where values are ordered the same way as the columns.
+func (q *SelectQuery) ScanColumns(ctx context.Context) ([]string, [][]interface{}, error) {
+	var rows [][]interface{}
+	model := newColumnsModel(q.db, &rows)
+	if err := q.Scan(ctx, model); err != nil {
+		return nil, nil, err
+	}
+	return model.columns, rows, nil
+}
+
+// ScanChan executes the query and scans rows one by one in a goroutine, sending each
+// model on the returned channel, for example:
+//
+//	models, errc := bun.ScanChan[Model](ctx, db.NewSelect().Model((*Model)(nil)))
+//	for model := range models {
+//		process(model)
+//	}
+//	if err := <-errc; err != nil {
+//		return err
+//	}
+//
+// The models channel is unbuffered, so the scanning waits for the consumer. Both channels
+// are closed when the rows are exhausted, an error occurs, or ctx is canceled; the error
+// channel receives at most one error. The consumer must either drain the models channel
+// or cancel ctx, otherwise the goroutine and the database connection are leaked.
+// Relations are not supported.
+func ScanChan[T any](ctx context.Context, q *SelectQuery) (<-chan *T, <-chan error) {
+	ch := make(chan *T)
+	errc := make(chan error, 1)
+
+	go func() {
+		if err := scanChan(ctx, q, ch); err != nil {
+			errc <- err
+		}
+		close(ch)
+		close(errc)
+	}()
+
+	return ch, errc
+}
+
+func scanChan[T any](ctx context.Context, q *SelectQuery, ch chan<- *T) error {
+	if q.tableModel != nil && len(q.tableModel.getJoins()) > 0 {
+		return errors.New("bun: ScanChan does not support relations")
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		model := new(T)
+		if err := q.db.ScanRow(ctx, rows, model); err != nil {
+			return err
+		}
+
+		select {
+		case ch <- model:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return rows.Err()
+}
+
 func (q *SelectQuery) scanResult(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	if q.err != nil {
 		return nil, q.err
@@ -853,6 +1516,21 @@ func (q *SelectQuery) scanResult(ctx context.Context, dest ...interface{}) (sql.
 	if err != nil {
 		return nil, err
 	}
+	if q.maxRows > 0 {
+		if l, ok := model.(interface{ setMaxRows(int) }); ok {
+			l.setMaxRows(q.maxRows)
+		}
+	}
+	if q.discardUnknownColumns {
+		if tm, ok := model.(TableModel); ok {
+			tm.setDiscardUnknownColumns()
+		}
+	}
+	if len(q.jsonRels) > 0 {
+		if m, ok := model.(interface{ setRelationJSON() }); ok {
+			m.setRelationJSON()
+		}
+	}
 	if len(dest) > 0 && q.tableModel != nil && len(q.tableModel.getJoins()) > 0 {
 		for _, j := range q.tableModel.getJoins() {
 			switch j.Relation.Type {
@@ -937,7 +1615,7 @@ func (q *SelectQuery) Count(ctx context.Context) (int, error) {
 	var num int
 	err = q.resolveConn(q).QueryRowContext(ctx, query).Scan(&num)
 
-	q.db.afterQuery(ctx, event, nil, err)
+	err = q.db.afterQuery(ctx, event, nil, err)
 
 	return num, err
 }
@@ -1041,7 +1719,7 @@ func (q *SelectQuery) selectExists(ctx context.Context) (bool, error) {
 	var exists bool
 	err = q.resolveConn(q).QueryRowContext(ctx, query).Scan(&exists)
 
-	q.db.afterQuery(ctx, event, nil, err)
+	err = q.db.afterQuery(ctx, event, nil, err)
 
 	return exists, err
 }
@@ -1100,6 +1778,14 @@ func (q *selectQueryBuilder) WhereGroup(
 	return q
 }
 
+func (q *selectQueryBuilder) WhereOrGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" OR ", fn)
+}
+
+func (q *selectQueryBuilder) WhereAndGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *selectQueryBuilder) Where(query string, args ...interface{}) QueryBuilder {
 	q.SelectQuery.Where(query, args...)
 	return q
@@ -1110,6 +1796,16 @@ func (q *selectQueryBuilder) WhereOr(query string, args ...interface{}) QueryBui
 	return q
 }
 
+func (q *selectQueryBuilder) WhereIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.SelectQuery.WhereIf(cond, query, args...)
+	return q
+}
+
+func (q *selectQueryBuilder) WhereOrIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.SelectQuery.WhereOrIf(cond, query, args...)
+	return q
+}
+
 func (q *selectQueryBuilder) WhereDeleted() QueryBuilder {
 	q.SelectQuery.WhereDeleted()
 	return q
@@ -1134,9 +1830,16 @@ func (q *selectQueryBuilder) Unwrap() interface{} {
 type joinQuery struct {
 	join schema.QueryWithArgs
 	on   []schema.QueryWithSep
+
+	// feature is the dialect feature required by the join, if any.
+	feature feature.Feature
 }
 
 func (j *joinQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if j.feature != 0 && !fmter.HasFeature(j.feature) {
+		return nil, feature.NewNotSupportError(j.feature)
+	}
+
 	b = append(b, ' ')
 
 	b, err = j.join.AppendQuery(fmter, b)
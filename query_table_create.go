@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -20,6 +21,7 @@ type CreateTableQuery struct {
 	baseQuery
 
 	temp        bool
+	onCommit    string
 	ifNotExists bool
 	fksFromRel  bool // Create foreign keys captured in table's relations.
 
@@ -30,8 +32,11 @@ type CreateTableQuery struct {
 	varchar int
 
 	fks         []schema.QueryWithArgs
+	excludes    []schema.QueryWithArgs
+	defaults    map[string]schema.QueryWithArgs
 	partitionBy schema.QueryWithArgs
 	tablespace  schema.QueryWithArgs
+	as          *SelectQuery
 	comment     string
 }
 
@@ -62,6 +67,26 @@ func (q *CreateTableQuery) Err(err error) *CreateTableQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *CreateTableQuery) Reset() *CreateTableQuery {
+	q.baseQuery.reset()
+	q.temp = false
+	q.onCommit = ""
+	q.ifNotExists = false
+	q.fksFromRel = false
+	q.varchar = q.db.Dialect().DefaultVarcharLen()
+	q.fks = resetSlice(q.fks)
+	q.excludes = resetSlice(q.excludes)
+	clear(q.defaults)
+	q.partitionBy = schema.QueryWithArgs{}
+	q.tablespace = schema.QueryWithArgs{}
+	q.as = nil
+	q.comment = ""
+	return q
+}
+
 // ------------------------------------------------------------------------------
 
 func (q *CreateTableQuery) Table(tables ...string) *CreateTableQuery {
@@ -88,11 +113,35 @@ func (q *CreateTableQuery) ColumnExpr(query string, args ...interface{}) *Create
 
 // ------------------------------------------------------------------------------
 
+// Temp is an alias for Temporary.
 func (q *CreateTableQuery) Temp() *CreateTableQuery {
+	return q.Temporary()
+}
+
+// Temporary creates a temporary table, which is only visible in the current session
+// and is dropped when the session ends, so use it with bun.Conn or bun.Tx.
+// MSSQL is not supported, because its temporary tables are the ones with names starting with #.
+//
+// Oracle creates a global temporary table instead. Its definition is permanent and shared
+// by all sessions until the table is dropped; only the rows are private to the session
+// and are deleted at the end of the transaction.
+func (q *CreateTableQuery) Temporary() *CreateTableQuery {
 	q.temp = true
 	return q
 }
 
+// OnCommit sets what happens to the temporary table at the end of the transaction:
+// "DROP", "DELETE ROWS", or "PRESERVE ROWS". Only PostgreSQL is supported.
+func (q *CreateTableQuery) OnCommit(action string) *CreateTableQuery {
+	switch action = strings.ToUpper(action); action {
+	case "DROP", "DELETE ROWS", "PRESERVE ROWS":
+		q.onCommit = action
+	default:
+		q.setErr(fmt.Errorf("bun: unsupported ON COMMIT action: %q", action))
+	}
+	return q
+}
+
 func (q *CreateTableQuery) IfNotExists() *CreateTableQuery {
 	q.ifNotExists = true
 	return q
@@ -108,11 +157,33 @@ func (q *CreateTableQuery) Varchar(n int) *CreateTableQuery {
 	return q
 }
 
+// DefaultExpr sets the DEFAULT expression of the column, overriding the `default` tag option.
+// Unlike the tag option, the expression is formatted like other queries, so arguments are
+// properly quoted, for example:
+//
+//	q.DefaultExpr("id", "nextval(?)", "app.users_id_seq")
+//	q.DefaultExpr("created_at", "?", bun.Safe("CURRENT_TIMESTAMP"))
+func (q *CreateTableQuery) DefaultExpr(column, query string, args ...interface{}) *CreateTableQuery {
+	if q.defaults == nil {
+		q.defaults = make(map[string]schema.QueryWithArgs)
+	}
+	q.defaults[column] = schema.SafeQuery(query, args)
+	return q
+}
+
 func (q *CreateTableQuery) ForeignKey(query string, args ...interface{}) *CreateTableQuery {
 	q.fks = append(q.fks, schema.SafeQuery(query, args))
 	return q
 }
 
+// Exclude adds a PostgreSQL exclusion constraint, for example,
+// Exclude("USING gist (room_id WITH =, during WITH &&)").
+// Use it from BeforeCreateTableHook to declare the constraint on the model.
+func (q *CreateTableQuery) Exclude(query string, args ...interface{}) *CreateTableQuery {
+	q.excludes = append(q.excludes, schema.SafeQuery(query, args))
+	return q
+}
+
 func (q *CreateTableQuery) PartitionBy(query string, args ...interface{}) *CreateTableQuery {
 	q.partitionBy = schema.SafeQuery(query, args)
 	return q
@@ -123,6 +194,13 @@ func (q *CreateTableQuery) TableSpace(tablespace string) *CreateTableQuery {
 	return q
 }
 
+// As creates the table from the result of the select query, i.e. CREATE TABLE ... AS SELECT ...
+// Columns are taken from the select query, so the model is optional and its fields are ignored.
+func (q *CreateTableQuery) As(query *SelectQuery) *CreateTableQuery {
+	q.as = query
+	return q
+}
+
 // WithForeignKeys adds a FOREIGN KEY clause for each of the model's existing relations.
 func (q *CreateTableQuery) WithForeignKeys() *CreateTableQuery {
 	q.fksFromRel = true
@@ -150,13 +228,29 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 
 	b = appendComment(b, q.comment)
 
-	if q.table == nil {
-		return nil, errNilModel
+	if q.table == nil && q.as == nil {
+		return nil, ErrNilModel
 	}
 
 	b = append(b, "CREATE "...)
 	if q.temp {
-		b = append(b, "TEMP "...)
+		switch fmter.Dialect().Name() {
+		case dialect.MSSQL:
+			return nil, fmt.Errorf("bun: %s does not support CREATE TEMPORARY TABLE "+
+				"(use a table name starting with #)", fmter.Dialect().Name())
+		case dialect.Oracle:
+			b = append(b, "GLOBAL TEMPORARY "...)
+		default:
+			b = append(b, "TEMPORARY "...)
+		}
+	}
+	if q.onCommit != "" {
+		if !q.temp {
+			return nil, errors.New("bun: ON COMMIT requires a temporary table")
+		}
+		if fmter.Dialect().Name() != dialect.PG {
+			return nil, fmt.Errorf("bun: %s does not support ON COMMIT", fmter.Dialect().Name())
+		}
 	}
 	b = append(b, "TABLE "...)
 	if q.ifNotExists && fmter.HasFeature(feature.TableNotExists) {
@@ -167,6 +261,16 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 		return nil, err
 	}
 
+	if q.as != nil {
+		return q.appendAs(fmter, b)
+	}
+
+	for column := range q.defaults {
+		if _, err := q.table.Field(column); err != nil {
+			return nil, err
+		}
+	}
+
 	b = append(b, " ("...)
 
 	for i, field := range q.table.Fields {
@@ -177,6 +281,9 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 		b = append(b, field.SQLName...)
 		b = append(b, " "...)
 		b = q.appendSQLType(b, field)
+		if field.Collation != "" {
+			b = q.appendCollation(fmter, b, field.Collation)
+		}
 		if field.NotNull && q.db.dialect.Name() != dialect.Oracle {
 			b = append(b, " NOT NULL"...)
 		}
@@ -186,7 +293,13 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 			b = q.db.dialect.AppendSequence(b, q.table, field)
 		}
 
-		if field.SQLDefault != "" {
+		if expr, ok := q.defaults[field.Name]; ok {
+			b = append(b, " DEFAULT "...)
+			b, err = expr.AppendQuery(fmter, b)
+			if err != nil {
+				return nil, err
+			}
+		} else if field.SQLDefault != "" {
 			b = append(b, " DEFAULT "...)
 			b = append(b, field.SQLDefault...)
 		}
@@ -223,6 +336,10 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 	if err != nil {
 		return nil, err
 	}
+	b, err = q.appendExcludeConstraints(fmter, b)
+	if err != nil {
+		return nil, err
+	}
 
 	b = append(b, ")"...)
 
@@ -234,6 +351,8 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 		}
 	}
 
+	b = q.appendOnCommit(b)
+
 	if !q.tablespace.IsZero() {
 		b = append(b, " TABLESPACE "...)
 		b, err = q.tablespace.AppendQuery(fmter, b)
@@ -245,6 +364,33 @@ func (q *CreateTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []by
 	return b, nil
 }
 
+func (q *CreateTableQuery) appendAs(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if fmter.Dialect().Name() == dialect.MSSQL {
+		return nil, fmt.Errorf("bun: %s does not support CREATE TABLE ... AS SELECT", fmter.Dialect().Name())
+	}
+
+	b = q.appendOnCommit(b)
+
+	if !q.tablespace.IsZero() {
+		b = append(b, " TABLESPACE "...)
+		b, err = q.tablespace.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b = append(b, " AS "...)
+	return q.as.AppendQuery(fmter, b)
+}
+
+func (q *CreateTableQuery) appendOnCommit(b []byte) []byte {
+	if q.onCommit == "" {
+		return b
+	}
+	b = append(b, " ON COMMIT "...)
+	return append(b, q.onCommit...)
+}
+
 func (q *CreateTableQuery) appendSQLType(b []byte, field *schema.Field) []byte {
 	// Most of the time these two will match, but for the cases where DiscoveredSQLType is dialect-specific,
 	// e.g. pgdialect would change sqltype.SmallInt to pgTypeSmallSerial for columns that have `bun:",autoincrement"`
@@ -269,6 +415,16 @@ func (q *CreateTableQuery) appendSQLType(b []byte, field *schema.Field) []byte {
 	return b
 }
 
+func (q *CreateTableQuery) appendCollation(fmter schema.Formatter, b []byte, collation string) []byte {
+	b = append(b, " COLLATE "...)
+	// PostgreSQL collation names are case-sensitive identifiers like "en_US.utf8",
+	// while other databases expect bare collation names.
+	if q.db.dialect.Name() == dialect.PG {
+		return fmter.AppendName(b, collation)
+	}
+	return append(b, collation...)
+}
+
 func (q *CreateTableQuery) appendUniqueConstraints(fmter schema.Formatter, b []byte) []byte {
 	unique := q.table.Unique
 
@@ -323,6 +479,14 @@ func (q *CreateTableQuery) appendFKConstraintsRel(fmter schema.Formatter, b []by
 			if err != nil {
 				return nil, err
 			}
+
+			if rel.Deferrable != "" {
+				if !fmter.HasFeature(feature.DeferrableFK) {
+					return nil, feature.NewNotSupportError(feature.DeferrableFK)
+				}
+				b = append(b, ' ')
+				b = append(b, rel.Deferrable...)
+			}
 		}
 	}
 	return b, nil
@@ -344,6 +508,26 @@ func (q *CreateTableQuery) appendFKConstraints(
 	return b, nil
 }
 
+func (q *CreateTableQuery) appendExcludeConstraints(
+	fmter schema.Formatter, b []byte,
+) (_ []byte, err error) {
+	if len(q.excludes) == 0 {
+		return b, nil
+	}
+	if fmter.Dialect().Name() != dialect.PG {
+		return nil, fmt.Errorf("bun: %s does not support EXCLUDE constraints", fmter.Dialect().Name())
+	}
+
+	for _, exclude := range q.excludes {
+		b = append(b, ", EXCLUDE "...)
+		b, err = exclude.AppendQuery(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
 func (q *CreateTableQuery) appendPKConstraint(b []byte, pks []*schema.Field) []byte {
 	b = append(b, ", PRIMARY KEY ("...)
 	b = appendColumns(b, "", pks)
@@ -380,6 +564,9 @@ func (q *CreateTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.R
 }
 
 func (q *CreateTableQuery) beforeCreateTableHook(ctx context.Context) error {
+	if q.table == nil {
+		return nil
+	}
 	if hook, ok := q.table.ZeroIface.(BeforeCreateTableHook); ok {
 		if err := hook.BeforeCreateTable(ctx, q); err != nil {
 			return err
@@ -3,7 +3,10 @@ package bun
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"strconv"
 
+	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/schema"
 )
@@ -12,8 +15,9 @@ type DropTableQuery struct {
 	baseQuery
 	cascadeQuery
 
-	ifExists bool
-	comment  string
+	ifExists        bool
+	disableFKChecks bool
+	comment         string
 }
 
 var _ Query = (*DropTableQuery)(nil)
@@ -42,6 +46,18 @@ func (q *DropTableQuery) Err(err error) *DropTableQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *DropTableQuery) Reset() *DropTableQuery {
+	q.baseQuery.reset()
+	q.cascadeQuery = cascadeQuery{}
+	q.ifExists = false
+	q.disableFKChecks = false
+	q.comment = ""
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *DropTableQuery) Table(tables ...string) *DropTableQuery {
@@ -68,6 +84,8 @@ func (q *DropTableQuery) IfExists() *DropTableQuery {
 	return q
 }
 
+// Cascade adds CASCADE to the query. It is ignored by dialects that don't support it,
+// e.g. MySQL, where DisableForeignKeyChecks can be used instead.
 func (q *DropTableQuery) Cascade() *DropTableQuery {
 	q.cascade = true
 	return q
@@ -78,6 +96,14 @@ func (q *DropTableQuery) Restrict() *DropTableQuery {
 	return q
 }
 
+// DisableForeignKeyChecks disables foreign key checks while the table is dropped,
+// which allows to drop tables referenced by other tables in MySQL.
+// It returns an error for dialects that don't support it.
+func (q *DropTableQuery) DisableForeignKeyChecks() *DropTableQuery {
+	q.disableFKChecks = true
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Comment adds a comment to the query, wrapped by /* ... */.
@@ -96,6 +122,9 @@ func (q *DropTableQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte
 	if q.err != nil {
 		return nil, q.err
 	}
+	if q.disableFKChecks && !fmter.HasFeature(feature.ForeignKeyChecks) {
+		return nil, feature.NewNotSupportError(feature.ForeignKeyChecks)
+	}
 
 	b = appendComment(b, q.comment)
 
@@ -130,7 +159,12 @@ func (q *DropTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Res
 
 	query := internal.String(queryBytes)
 
-	res, err := q.exec(ctx, q, query)
+	var res sql.Result
+	if q.disableFKChecks {
+		res, err = q.execWithoutFKChecks(ctx, query)
+	} else {
+		res, err = q.exec(ctx, q, query)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +178,56 @@ func (q *DropTableQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Res
 	return res, nil
 }
 
+// execWithoutFKChecks executes the query with foreign key checks disabled.
+// FOREIGN_KEY_CHECKS is a session variable, so all statements must use the same connection.
+func (q *DropTableQuery) execWithoutFKChecks(ctx context.Context, query string) (_ sql.Result, err error) {
+	conn := q.resolveConn(q)
+	if db, ok := conn.(*sql.DB); ok {
+		c, err := db.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		conn = c
+	}
+
+	var checks int
+	if err := conn.QueryRowContext(ctx, "SELECT @@FOREIGN_KEY_CHECKS").Scan(&checks); err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// The connection outlives the query, so the previous value is restored
+		// even if ctx is canceled.
+		_, resetErr := conn.ExecContext(context.WithoutCancel(ctx),
+			"SET FOREIGN_KEY_CHECKS = "+strconv.Itoa(checks))
+		if resetErr == nil {
+			return
+		}
+		// Don't return a connection with foreign key checks disabled to the pool.
+		if c, ok := conn.(interface {
+			Raw(func(driverConn interface{}) error) error
+		}); ok {
+			_ = c.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+		if err == nil {
+			err = resetErr
+		}
+	}()
+
+	prevConn := q.conn
+	q.conn = conn
+	defer func() {
+		q.conn = prevConn
+	}()
+
+	return q.exec(ctx, q, query)
+}
+
 func (q *DropTableQuery) beforeDropTableHook(ctx context.Context) error {
 	if hook, ok := q.table.ZeroIface.(BeforeDropTableHook); ok {
 		if err := hook.BeforeDropTable(ctx, q); err != nil {
@@ -43,6 +43,17 @@ func (q *TruncateTableQuery) Err(err error) *TruncateTableQuery {
 	return q
 }
 
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *TruncateTableQuery) Reset() *TruncateTableQuery {
+	q.baseQuery.reset()
+	q.cascadeQuery = cascadeQuery{}
+	q.continueIdentity = false
+	q.comment = ""
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *TruncateTableQuery) Table(tables ...string) *TruncateTableQuery {
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/uptrace/bun/dialect"
 
@@ -13,6 +15,11 @@ import (
 	"github.com/uptrace/bun/schema"
 )
 
+// ErrVersionConflict is returned when a model with a `version` field is updated,
+// but no row matched the model's version, because the row was changed or deleted
+// after it was selected.
+var ErrVersionConflict = errors.New("bun: version conflict: the row was modified concurrently")
+
 type UpdateQuery struct {
 	whereBaseQuery
 	orderLimitOffsetQuery
@@ -23,6 +30,7 @@ type UpdateQuery struct {
 
 	joins    []joinQuery
 	omitZero bool
+	hasFrom  bool
 	comment  string
 }
 
@@ -44,6 +52,13 @@ func (q *UpdateQuery) Conn(db IConn) *UpdateQuery {
 	return q
 }
 
+// WithoutHooks disables the query hooks registered with DB.AddQueryHook for this query,
+// for example, to exclude a noisy health check from logging and tracing.
+func (q *UpdateQuery) WithoutHooks() *UpdateQuery {
+	q.flags = q.flags.Set(withoutHooksFlag)
+	return q
+}
+
 func (q *UpdateQuery) Model(model interface{}) *UpdateQuery {
 	q.setModel(model)
 	return q
@@ -64,6 +79,31 @@ func (q *UpdateQuery) Apply(fns ...func(*UpdateQuery) *UpdateQuery) *UpdateQuery
 	return q
 }
 
+// WithScope applies the scope to the query. A nil scope is ignored.
+func (q *UpdateQuery) WithScope(scope Scope) *UpdateQuery {
+	if scope != nil {
+		scope.ApplyUpdate(q)
+	}
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *UpdateQuery) Reset() *UpdateQuery {
+	q.whereBaseQuery.reset()
+	q.orderLimitOffsetQuery.reset()
+	q.returningQuery.reset()
+	q.customValueQuery.reset()
+	q.setQuery.reset()
+	q.idxHintsQuery = idxHintsQuery{}
+	q.joins = resetSlice(q.joins)
+	q.omitZero = false
+	q.hasFrom = false
+	q.comment = ""
+	return q
+}
+
 func (q *UpdateQuery) With(name string, query Query) *UpdateQuery {
 	q.addWith(name, query, false)
 	return q
@@ -88,11 +128,34 @@ func (q *UpdateQuery) TableExpr(query string, args ...interface{}) *UpdateQuery
 	return q
 }
 
+// From adds a table to the FROM clause, so the rows can be updated using the values
+// from another table. It generates different queries depending on the DBMS:
+//   - On PostgreSQL and SQLite, it generates `UPDATE t AS a SET ... FROM other WHERE ...`.
+//   - On MSSQL, it generates `UPDATE t SET ... FROM other WHERE ...`.
+//   - On MySQL, it generates `UPDATE t AS a, other SET ... WHERE ...`.
+func (q *UpdateQuery) From(query string, args ...interface{}) *UpdateQuery {
+	if !q.hasFeature(feature.UpdateMultiTable | feature.UpdateTableAlias | feature.UpdateFromTable) {
+		q.setErr(feature.NewNotSupportError(feature.UpdateFromTable))
+		return q
+	}
+	q.addTable(schema.SafeQuery(query, args))
+	q.hasFrom = true
+	return q
+}
+
 func (q *UpdateQuery) ModelTableExpr(query string, args ...interface{}) *UpdateQuery {
 	q.modelTableName = schema.SafeQuery(query, args)
 	return q
 }
 
+// WithTableName overrides the model table name for this query, for example,
+// to query a shard of the table. Unlike ModelTableExpr, it keeps the model alias
+// and the ?TableName placeholder resolves to the new name.
+func (q *UpdateQuery) WithTableName(name string) *UpdateQuery {
+	q.tableName = name
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 func (q *UpdateQuery) Column(columns ...string) *UpdateQuery {
@@ -120,10 +183,41 @@ func (q *UpdateQuery) SetColumn(column string, query string, args ...interface{}
 	return q
 }
 
+// SetMap adds a SET clause for each map key, binding the map value to the column.
+// Keys must be model column names, which is useful for PATCH-style updates
+// built from request data. Keys are sorted to produce a stable query.
+func (q *UpdateQuery) SetMap(m map[string]interface{}) *UpdateQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		field, err := q.table.Field(k)
+		if err != nil {
+			q.setErr(err)
+			return q
+		}
+
+		column := field.SQLName
+		if q.db.HasFeature(feature.UpdateMultiTable) {
+			column = q.sqlAlias() + "." + column
+		}
+		q.addSet(schema.SafeQuery("? = ?", []interface{}{column, m[k]}))
+	}
+	return q
+}
+
 // Value overwrites model value for the column.
 func (q *UpdateQuery) Value(column string, query string, args ...interface{}) *UpdateQuery {
 	if q.table == nil {
-		q.err = errNilModel
+		q.err = ErrNilModel
 		return q
 	}
 	q.addValue(q.table, column, query, args)
@@ -169,6 +263,29 @@ func (q *UpdateQuery) WherePK(cols ...string) *UpdateQuery {
 	return q
 }
 
+// WhereDistinctFrom adds a NULL-safe inequality condition, i.e. `column IS DISTINCT FROM value`,
+// which, unlike `<>`, is true when only one of the sides is NULL. Databases without
+// IS DISTINCT FROM use an equivalent expression, e.g. `NOT (column <=> value)` on MySQL.
+func (q *UpdateQuery) WhereDistinctFrom(column string, value interface{}) *UpdateQuery {
+	q.addWhereDistinctFrom(column, value, false)
+	return q
+}
+
+// WhereNotDistinctFrom adds a NULL-safe equality condition, i.e. `column IS NOT DISTINCT FROM value`,
+// which, unlike `=`, is true when both sides are NULL.
+func (q *UpdateQuery) WhereNotDistinctFrom(column string, value interface{}) *UpdateQuery {
+	q.addWhereDistinctFrom(column, value, true)
+	return q
+}
+
+// WhereILike adds a case-insensitive LIKE condition, i.e. `column ILIKE pattern` on PostgreSQL
+// and `LOWER(column) LIKE LOWER(pattern)` elsewhere. The % and _ wildcards in the pattern
+// are not escaped; use EscapeLike to match user input literally.
+func (q *UpdateQuery) WhereILike(column string, pattern string) *UpdateQuery {
+	q.addWhereILike(column, pattern)
+	return q
+}
+
 func (q *UpdateQuery) Where(query string, args ...interface{}) *UpdateQuery {
 	q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
 	return q
@@ -179,6 +296,23 @@ func (q *UpdateQuery) WhereOr(query string, args ...interface{}) *UpdateQuery {
 	return q
 }
 
+// WhereIf is like Where, but only adds the condition when cond is true, for example,
+// WhereIf(filter.Name != "", "name = ?", filter.Name).
+func (q *UpdateQuery) WhereIf(cond bool, query string, args ...interface{}) *UpdateQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " AND "))
+	}
+	return q
+}
+
+// WhereOrIf is like WhereOr, but only adds the condition when cond is true.
+func (q *UpdateQuery) WhereOrIf(cond bool, query string, args ...interface{}) *UpdateQuery {
+	if cond {
+		q.addWhere(schema.SafeQueryWithSep(query, args, " OR "))
+	}
+	return q
+}
+
 func (q *UpdateQuery) WhereGroup(sep string, fn func(*UpdateQuery) *UpdateQuery) *UpdateQuery {
 	saved := q.where
 	q.where = nil
@@ -193,6 +327,18 @@ func (q *UpdateQuery) WhereGroup(sep string, fn func(*UpdateQuery) *UpdateQuery)
 	return q
 }
 
+// WhereOrGroup is a shorthand for WhereGroup(" OR ", fn), which joins the group of
+// conditions to the previous conditions with OR.
+func (q *UpdateQuery) WhereOrGroup(fn func(*UpdateQuery) *UpdateQuery) *UpdateQuery {
+	return q.WhereGroup(" OR ", fn)
+}
+
+// WhereAndGroup is a shorthand for WhereGroup(" AND ", fn), which joins the group of
+// conditions to the previous conditions with AND.
+func (q *UpdateQuery) WhereAndGroup(fn func(*UpdateQuery) *UpdateQuery) *UpdateQuery {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *UpdateQuery) WhereDeleted() *UpdateQuery {
 	q.whereDeleted()
 	return q
@@ -241,6 +387,18 @@ func (q *UpdateQuery) Returning(query string, args ...interface{}) *UpdateQuery
 	return q
 }
 
+// ReturningModel adds a RETURNING clause with exactly the model columns,
+// excluding relations and scanonly fields, so the returned columns match the model.
+// It replaces columns added with Returning.
+func (q *UpdateQuery) ReturningModel() *UpdateQuery {
+	if q.table == nil {
+		q.setErr(ErrNilModel)
+		return q
+	}
+	q.addReturningModel(q.table)
+	return q
+}
+
 //------------------------------------------------------------------------------
 
 // Comment adds a comment to the query, wrapped by /* ... */.
@@ -271,7 +429,11 @@ func (q *UpdateQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 
 	b = append(b, "UPDATE "...)
 
-	if fmter.HasFeature(feature.UpdateMultiTable) {
+	fromAlias := q.hasFromAlias(fmter)
+	if fromAlias {
+		// The table is aliased in the FROM clause, e.g. UPDATE a SET ... FROM t AS a, other.
+		b = append(b, q.sqlAlias()...)
+	} else if fmter.HasFeature(feature.UpdateMultiTable) {
 		b, err = q.appendTablesWithAlias(fmter, b)
 	} else if fmter.HasFeature(feature.UpdateTableAlias) {
 		b, err = q.appendFirstTableWithAlias(fmter, b)
@@ -292,23 +454,30 @@ func (q *UpdateQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 		return nil, err
 	}
 
-	if !fmter.HasFeature(feature.UpdateMultiTable) {
-		b, err = q.appendOtherTables(fmter, b)
+	// MSSQL expects OUTPUT before FROM.
+	if q.hasFeature(feature.Output) && q.hasReturning() {
+		b = append(b, " OUTPUT "...)
+		b, err = q.appendOutput(fmter, b)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	for _, j := range q.joins {
-		b, err = j.AppendQuery(fmter, b)
+	if fromAlias {
+		b = append(b, " FROM "...)
+		b, err = q.appendTablesWithAlias(fmter, b)
+		if err != nil {
+			return nil, err
+		}
+	} else if !fmter.HasFeature(feature.UpdateMultiTable) {
+		b, err = q.appendOtherTables(fmter, b)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if q.hasFeature(feature.Output) && q.hasReturning() {
-		b = append(b, " OUTPUT "...)
-		b, err = q.appendOutput(fmter, b)
+	for _, j := range q.joins {
+		b, err = j.AppendQuery(fmter, b)
 		if err != nil {
 			return nil, err
 		}
@@ -319,6 +488,10 @@ func (q *UpdateQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 		return nil, err
 	}
 
+	if field := q.versionField(); field != nil {
+		b = q.appendWhereVersion(fmter, b, field)
+	}
+
 	b, err = q.appendOrder(fmter, b)
 	if err != nil {
 		return nil, err
@@ -331,7 +504,14 @@ func (q *UpdateQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 
 	if q.hasFeature(feature.Returning) && q.hasReturning() {
 		b = append(b, " RETURNING "...)
-		b, err = q.appendReturning(fmter, b)
+		// The tables added with From may have columns with the same names.
+		// SQLite resolves RETURNING columns only against the updated table
+		// and rejects the alias there.
+		if q.hasFrom && q.hasTableAlias(fmter) && fmter.Dialect().Name() != dialect.SQLite {
+			b, err = q._appendReturning(fmter, b, string(q.sqlAlias()))
+		} else {
+			b, err = q.appendReturning(fmter, b)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -352,7 +532,7 @@ func (q *UpdateQuery) mustAppendSet(fmter schema.Formatter, b []byte) (_ []byte,
 	}
 
 	if q.tableModel == nil {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	switch model := q.tableModel.(type) {
@@ -387,9 +567,10 @@ func (q *UpdateQuery) appendSetStruct(
 	}
 
 	isTemplate := fmter.IsNop()
+	version := q.versionField()
 	pos := len(b)
 	for _, f := range fields {
-		if f.SkipUpdate() {
+		if f.SkipUpdate() || f == version {
 			continue
 		}
 
@@ -422,6 +603,16 @@ func (q *UpdateQuery) appendSetStruct(
 		}
 	}
 
+	if version != nil {
+		if len(b) != pos {
+			b = append(b, ", "...)
+		}
+		b = append(b, version.SQLName...)
+		b = append(b, " = "...)
+		b = append(b, version.SQLName...)
+		b = append(b, " + 1"...)
+	}
+
 	for i, v := range q.extraValues {
 		if i > 0 || len(fields) > 0 {
 			b = append(b, ", "...)
@@ -439,6 +630,58 @@ func (q *UpdateQuery) appendSetStruct(
 	return b, nil
 }
 
+// versionField returns the field used for optimistic locking when the SET clause
+// is generated from a struct model.
+func (q *UpdateQuery) versionField() *schema.Field {
+	if q.table == nil || q.table.VersionField == nil || len(q.set) > 0 {
+		return nil
+	}
+	if _, ok := q.tableModel.(*structTableModel); !ok {
+		return nil
+	}
+	return q.table.VersionField
+}
+
+func (q *UpdateQuery) appendWhereVersion(
+	fmter schema.Formatter, b []byte, field *schema.Field,
+) []byte {
+	b = append(b, " AND ("...)
+	if q.hasTableAlias(fmter) {
+		b = append(b, q.sqlAlias()...)
+	} else {
+		b = append(b, q.sqlTableName()...)
+	}
+	b = append(b, '.')
+	b = append(b, field.SQLName...)
+	b = append(b, " = "...)
+	if fmter.IsNop() {
+		b = append(b, '?')
+	} else {
+		b = field.AppendValue(fmter, b, q.tableModel.(*structTableModel).strct)
+	}
+	return append(b, ')')
+}
+
+// checkVersion returns ErrVersionConflict when the update did not match any row,
+// otherwise it sets the version of the model to the version of the updated row.
+func (q *UpdateQuery) checkVersion(field *schema.Field, version reflect.Value, res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrVersionConflict
+	}
+
+	fv := field.Value(q.tableModel.(*structTableModel).strct)
+	if fv.CanInt() {
+		fv.SetInt(version.Int() + 1)
+	} else {
+		fv.SetUint(version.Uint() + 1)
+	}
+	return nil
+}
+
 func (q *UpdateQuery) appendOtherTables(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	if !q.hasMultiTables() {
 		return b, nil
@@ -517,7 +760,7 @@ func (q *UpdateQuery) updateSliceWhere(fmter schema.Formatter, model *sliceTable
 		if q.hasTableAlias(fmter) {
 			b = append(b, model.table.SQLAlias...)
 		} else {
-			b = append(b, model.table.SQLName...)
+			b = append(b, q.sqlTableName()...)
 		}
 		b = append(b, '.')
 		b = append(b, pk.SQLName...)
@@ -534,6 +777,9 @@ func (q *UpdateQuery) Scan(ctx context.Context, dest ...interface{}) error {
 	return err
 }
 
+// Exec executes the query. Rows returned by RETURNING are scanned into dest
+// or, when dest is omitted, into the model, which can be a slice that receives
+// all updated rows.
 func (q *UpdateQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
 	return q.scanOrExec(ctx, dest, len(dest) > 0)
 }
@@ -555,6 +801,12 @@ func (q *UpdateQuery) scanOrExec(
 	if err := q.beforeAppendModel(ctx, q); err != nil {
 		return nil, err
 	}
+	if len(q.set) == 0 {
+		q.updateTimestamps(false)
+	}
+	if err := q.validate(ctx, q); err != nil {
+		return nil, err
+	}
 
 	// Generate the query before checking hasReturning.
 	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
@@ -577,9 +829,19 @@ func (q *UpdateQuery) scanOrExec(
 
 	var res sql.Result
 
+	version := q.versionField()
+	var oldVersion reflect.Value
+	if version != nil {
+		// Copy the version, because RETURNING may scan the new one into the model.
+		oldVersion = reflect.ValueOf(version.Value(q.tableModel.(*structTableModel).strct).Interface())
+	}
+
 	if useScan {
 		res, err = q.scan(ctx, q, query, model, hasDest)
 		if err != nil {
+			if version != nil && errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrVersionConflict
+			}
 			return nil, err
 		}
 	} else {
@@ -589,6 +851,12 @@ func (q *UpdateQuery) scanOrExec(
 		}
 	}
 
+	if version != nil {
+		if err := q.checkVersion(version, oldVersion, res); err != nil {
+			return res, err
+		}
+	}
+
 	if q.table != nil {
 		if err := q.afterUpdateHook(ctx); err != nil {
 			return nil, err
@@ -629,7 +897,15 @@ func (q *UpdateQuery) FQN(column string) Ident {
 }
 
 func (q *UpdateQuery) hasTableAlias(fmter schema.Formatter) bool {
-	return fmter.HasFeature(feature.UpdateMultiTable | feature.UpdateTableAlias)
+	return fmter.HasFeature(feature.UpdateMultiTable|feature.UpdateTableAlias) || q.hasFromAlias(fmter)
+}
+
+// hasFromAlias reports whether the model table is aliased in the FROM clause,
+// so on MSSQL the tables added with From can be joined using ?TableAlias.
+func (q *UpdateQuery) hasFromAlias(fmter schema.Formatter) bool {
+	return q.hasFrom && fmter.HasFeature(feature.UpdateFromTable) &&
+		!fmter.HasFeature(feature.UpdateTableAlias) &&
+		q.table != nil && q.modelTableName.IsZero()
 }
 
 func (q *UpdateQuery) String() string {
@@ -664,6 +940,14 @@ func (q *updateQueryBuilder) WhereGroup(
 	return q
 }
 
+func (q *updateQueryBuilder) WhereOrGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" OR ", fn)
+}
+
+func (q *updateQueryBuilder) WhereAndGroup(fn func(QueryBuilder) QueryBuilder) QueryBuilder {
+	return q.WhereGroup(" AND ", fn)
+}
+
 func (q *updateQueryBuilder) Where(query string, args ...interface{}) QueryBuilder {
 	q.UpdateQuery.Where(query, args...)
 	return q
@@ -674,6 +958,16 @@ func (q *updateQueryBuilder) WhereOr(query string, args ...interface{}) QueryBui
 	return q
 }
 
+func (q *updateQueryBuilder) WhereIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.UpdateQuery.WhereIf(cond, query, args...)
+	return q
+}
+
+func (q *updateQueryBuilder) WhereOrIf(cond bool, query string, args ...interface{}) QueryBuilder {
+	q.UpdateQuery.WhereOrIf(cond, query, args...)
+	return q
+}
+
 func (q *updateQueryBuilder) WhereDeleted() QueryBuilder {
 	q.UpdateQuery.WhereDeleted()
 	return q
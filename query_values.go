@@ -1,10 +1,12 @@
 package bun
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/feature"
 	"github.com/uptrace/bun/schema"
 )
@@ -13,8 +15,9 @@ type ValuesQuery struct {
 	baseQuery
 	customValueQuery
 
-	withOrder bool
-	comment   string
+	withOrder   bool
+	columnNames []string
+	comment     string
 }
 
 var (
@@ -32,6 +35,82 @@ func NewValuesQuery(db *DB, model interface{}) *ValuesQuery {
 	return q
 }
 
+// Values returns a query argument that appends the model, a pointer to a struct or
+// to a slice of structs, as a parenthesized VALUES list, for example, (VALUES (1, 'a'), (2, 'b')).
+// It allows to inline the list anywhere in a query:
+//
+//	db.NewSelect().TableExpr("? AS v (id, str)", bun.Values(&models))
+//
+// All model columns are appended. To choose the columns or to override the values,
+// pass a ValuesQuery instead, e.g. "(?)" with db.NewValues(&models).Column("id").
+func Values(model interface{}) schema.QueryAppender {
+	return valuesArg{model: model}
+}
+
+type valuesArg struct {
+	model interface{}
+}
+
+func (v valuesArg) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	rv := reflect.ValueOf(v.model)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("bun: Values(non-pointer %T)", v.model)
+	}
+	rv = rv.Elem()
+
+	var typ reflect.Type
+	switch rv.Kind() {
+	case reflect.Struct:
+		typ = rv.Type()
+	case reflect.Slice:
+		if rv.Len() == 0 {
+			return nil, errors.New("bun: Values(empty slice)")
+		}
+		typ = indirectType(rv.Type().Elem())
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bun: Values(unsupported %T)", v.model)
+	}
+	table := fmter.Dialect().Tables().Get(typ)
+
+	b = append(b, "(VALUES "...)
+	if rv.Kind() == reflect.Struct {
+		b = appendValuesRow(fmter, b, table.Fields, rv)
+	} else {
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = appendValuesRow(fmter, b, table.Fields, rv.Index(i))
+		}
+	}
+	return append(b, ')'), nil
+}
+
+func appendValuesRow(
+	fmter schema.Formatter, b []byte, fields []*schema.Field, strct reflect.Value,
+) []byte {
+	if fmter.HasFeature(feature.ValuesRow) {
+		b = append(b, "ROW("...)
+	} else {
+		b = append(b, '(')
+	}
+
+	strct = indirect(strct)
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = f.AppendValue(fmter, b, strct)
+
+		if fmter.HasFeature(feature.DoubleColonCast) {
+			b = append(b, "::"...)
+			b = append(b, f.UserSQLType...)
+		}
+	}
+	return append(b, ')')
+}
+
 func (q *ValuesQuery) Conn(db IConn) *ValuesQuery {
 	q.setConn(db)
 	return q
@@ -42,6 +121,19 @@ func (q *ValuesQuery) Err(err error) *ValuesQuery {
 	return q
 }
 
+// Reset clears the query state and sets the new model, so the query can be reused,
+// for example, with sync.Pool. The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *ValuesQuery) Reset(model interface{}) *ValuesQuery {
+	q.baseQuery.reset()
+	q.customValueQuery.reset()
+	q.withOrder = false
+	q.columnNames = nil
+	q.comment = ""
+	q.setModel(model)
+	return q
+}
+
 func (q *ValuesQuery) Column(columns ...string) *ValuesQuery {
 	for _, column := range columns {
 		q.addColumn(schema.UnsafeIdent(column))
@@ -52,7 +144,7 @@ func (q *ValuesQuery) Column(columns ...string) *ValuesQuery {
 // Value overwrites model value for the column.
 func (q *ValuesQuery) Value(column string, expr string, args ...interface{}) *ValuesQuery {
 	if q.table == nil {
-		q.err = errNilModel
+		q.err = ErrNilModel
 		return q
 	}
 	q.addValue(q.table, column, expr, args)
@@ -64,6 +156,20 @@ func (q *ValuesQuery) WithOrder() *ValuesQuery {
 	return q
 }
 
+// ColumnNames turns the query into a derived table with the named columns,
+// for example, (VALUES (...), (...)) AS "alias" ("id", "str"), so it can be used with TableExpr.
+// The model table alias is used unless it is overridden with TableAlias.
+func (q *ValuesQuery) ColumnNames(columns ...string) *ValuesQuery {
+	q.columnNames = columns
+	return q
+}
+
+// TableAlias sets the alias of the derived table created with ColumnNames.
+func (q *ValuesQuery) TableAlias(alias string) *ValuesQuery {
+	q.tableAlias = schema.Safe(q.db.fmter.AppendIdent(nil, alias))
+	return q
+}
+
 // Comment adds a comment to the query, wrapped by /* ... */.
 func (q *ValuesQuery) Comment(comment string) *ValuesQuery {
 	q.comment = comment
@@ -89,7 +195,7 @@ func (q *ValuesQuery) AppendColumns(fmter schema.Formatter, b []byte) (_ []byte,
 		return nil, q.err
 	}
 	if q.model == nil {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	if q.tableModel != nil {
@@ -124,13 +230,20 @@ func (q *ValuesQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 		return nil, q.err
 	}
 	if q.model == nil {
-		return nil, errNilModel
+		return nil, ErrNilModel
 	}
 
 	b = appendComment(b, q.comment)
 
 	fmter = formatterWithModel(fmter, q)
 
+	if len(q.columnNames) > 0 {
+		return q.appendDerivedTable(fmter, b)
+	}
+	return q.appendValuesList(fmter, b)
+}
+
+func (q *ValuesQuery) appendValuesList(fmter schema.Formatter, b []byte) (_ []byte, err error) {
 	if q.tableModel != nil {
 		fields, err := q.getFields()
 		if err != nil {
@@ -147,6 +260,76 @@ func (q *ValuesQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, e
 	return nil, fmt.Errorf("bun: Values does not support %T", q.model)
 }
 
+// appendDerivedTable appends (VALUES ...) AS alias (columns).
+// SQLite does not support column names in the table alias,
+// so VALUES columns (column1, column2, ...) are renamed with a subquery instead.
+func (q *ValuesQuery) appendDerivedTable(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	alias := q.tableAlias
+	if alias == "" && q.table != nil {
+		alias = q.table.SQLAlias
+	}
+	if alias == "" {
+		return nil, errors.New("bun: Values.ColumnNames requires a table alias")
+	}
+
+	columns := q.columnNames
+	if q.withOrder {
+		columns = append(columns[:len(columns):len(columns)], "_order")
+	}
+
+	if q.tableModel != nil {
+		fields, err := q.getFields()
+		if err != nil {
+			return nil, err
+		}
+		if len(q.columnNames) != len(fields) {
+			return nil, fmt.Errorf("bun: Values has %d columns, but %d column names are specified",
+				len(fields), len(q.columnNames))
+		}
+	}
+
+	isSQLite := q.db.dialect.Name() == dialect.SQLite
+
+	b = append(b, '(')
+	if isSQLite {
+		b = append(b, "SELECT "...)
+		for i, col := range columns {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = append(b, "column"...)
+			b = strconv.AppendInt(b, int64(i+1), 10)
+			b = append(b, " AS "...)
+			b = fmter.AppendIdent(b, col)
+		}
+		b = append(b, " FROM ("...)
+	}
+
+	b, err = q.appendValuesList(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if isSQLite {
+		b = append(b, ')')
+	}
+	b = append(b, ") AS "...)
+	b = append(b, alias...)
+
+	if !isSQLite {
+		b = append(b, " ("...)
+		for i, col := range columns {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = fmter.AppendIdent(b, col)
+		}
+		b = append(b, ')')
+	}
+
+	return b, nil
+}
+
 func (q *ValuesQuery) appendQuery(
 	fmter schema.Formatter,
 	b []byte,
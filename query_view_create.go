@@ -0,0 +1,316 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// CreateViewQuery builds CREATE VIEW ... AS SELECT ...
+type CreateViewQuery struct {
+	baseQuery
+
+	orReplace bool
+	as        *SelectQuery
+	comment   string
+}
+
+var _ Query = (*CreateViewQuery)(nil)
+
+func NewCreateViewQuery(db *DB) *CreateViewQuery {
+	q := &CreateViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *CreateViewQuery) Conn(db IConn) *CreateViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *CreateViewQuery) Model(model interface{}) *CreateViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *CreateViewQuery) Err(err error) *CreateViewQuery {
+	q.setErr(err)
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *CreateViewQuery) Reset() *CreateViewQuery {
+	q.baseQuery.reset()
+	q.orReplace = false
+	q.as = nil
+	q.comment = ""
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) View(views ...string) *CreateViewQuery {
+	for _, view := range views {
+		q.addTable(schema.UnsafeIdent(view))
+	}
+	return q
+}
+
+func (q *CreateViewQuery) ViewExpr(query string, args ...interface{}) *CreateViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *CreateViewQuery) ModelTableExpr(query string, args ...interface{}) *CreateViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// OrReplace replaces the view if it already exists. MSSQL uses CREATE OR ALTER VIEW
+// and SQLite, which does not support replacing views, returns an error.
+func (q *CreateViewQuery) OrReplace() *CreateViewQuery {
+	q.orReplace = true
+	return q
+}
+
+// As sets the select query that defines the view.
+func (q *CreateViewQuery) As(query *SelectQuery) *CreateViewQuery {
+	q.as = query
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *CreateViewQuery) Comment(comment string) *CreateViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) Operation() string {
+	return "CREATE VIEW"
+}
+
+func (q *CreateViewQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.as == nil {
+		return nil, errors.New("bun: CreateViewQuery requires a select query, use As")
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "CREATE "...)
+	if q.orReplace {
+		switch fmter.Dialect().Name() {
+		case dialect.SQLite:
+			return nil, fmt.Errorf("bun: %s does not support CREATE OR REPLACE VIEW", fmter.Dialect().Name())
+		case dialect.MSSQL:
+			b = append(b, "OR ALTER "...)
+		default:
+			b = append(b, "OR REPLACE "...)
+		}
+	}
+	b = append(b, "VIEW "...)
+
+	b, err = q.appendFirstTable(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, " AS "...)
+	return q.as.AppendQuery(fmter, b)
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateViewQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+//------------------------------------------------------------------------------
+
+// CreateMaterializedViewQuery builds CREATE MATERIALIZED VIEW ... AS SELECT ...
+// It requires feature.MaterializedView.
+type CreateMaterializedViewQuery struct {
+	baseQuery
+
+	ifNotExists bool
+	withNoData  bool
+	as          *SelectQuery
+	comment     string
+}
+
+var _ Query = (*CreateMaterializedViewQuery)(nil)
+
+func NewCreateMaterializedViewQuery(db *DB) *CreateMaterializedViewQuery {
+	q := &CreateMaterializedViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *CreateMaterializedViewQuery) Conn(db IConn) *CreateMaterializedViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *CreateMaterializedViewQuery) Model(model interface{}) *CreateMaterializedViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *CreateMaterializedViewQuery) Err(err error) *CreateMaterializedViewQuery {
+	q.setErr(err)
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *CreateMaterializedViewQuery) Reset() *CreateMaterializedViewQuery {
+	q.baseQuery.reset()
+	q.ifNotExists = false
+	q.withNoData = false
+	q.as = nil
+	q.comment = ""
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateMaterializedViewQuery) View(views ...string) *CreateMaterializedViewQuery {
+	for _, view := range views {
+		q.addTable(schema.UnsafeIdent(view))
+	}
+	return q
+}
+
+func (q *CreateMaterializedViewQuery) ViewExpr(query string, args ...interface{}) *CreateMaterializedViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *CreateMaterializedViewQuery) ModelTableExpr(query string, args ...interface{}) *CreateMaterializedViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateMaterializedViewQuery) IfNotExists() *CreateMaterializedViewQuery {
+	q.ifNotExists = true
+	return q
+}
+
+// As sets the select query that populates the view.
+func (q *CreateMaterializedViewQuery) As(query *SelectQuery) *CreateMaterializedViewQuery {
+	q.as = query
+	return q
+}
+
+// WithNoData creates the view without populating it. The view can't be queried
+// until it is refreshed with REFRESH MATERIALIZED VIEW.
+func (q *CreateMaterializedViewQuery) WithNoData() *CreateMaterializedViewQuery {
+	q.withNoData = true
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *CreateMaterializedViewQuery) Comment(comment string) *CreateMaterializedViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateMaterializedViewQuery) Operation() string {
+	return "CREATE MATERIALIZED VIEW"
+}
+
+func (q *CreateMaterializedViewQuery) AppendQuery(
+	fmter schema.Formatter, b []byte,
+) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if !fmter.HasFeature(feature.MaterializedView) {
+		return nil, feature.NewNotSupportError(feature.MaterializedView)
+	}
+	if q.as == nil {
+		return nil, errors.New("bun: CreateMaterializedViewQuery requires a select query, use As")
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "CREATE MATERIALIZED VIEW "...)
+	if q.ifNotExists {
+		b = append(b, "IF NOT EXISTS "...)
+	}
+	b, err = q.appendFirstTable(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, " AS "...)
+	b, err = q.as.AppendQuery(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.withNoData {
+		b = append(b, " WITH NO DATA"...)
+	}
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *CreateMaterializedViewQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,164 @@
+package bun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// DropViewQuery builds DROP VIEW.
+type DropViewQuery struct {
+	baseQuery
+	cascadeQuery
+
+	ifExists bool
+	comment  string
+}
+
+var _ Query = (*DropViewQuery)(nil)
+
+func NewDropViewQuery(db *DB) *DropViewQuery {
+	q := &DropViewQuery{
+		baseQuery: baseQuery{
+			db: db,
+		},
+	}
+	return q
+}
+
+func (q *DropViewQuery) Conn(db IConn) *DropViewQuery {
+	q.setConn(db)
+	return q
+}
+
+func (q *DropViewQuery) Model(model interface{}) *DropViewQuery {
+	q.setModel(model)
+	return q
+}
+
+func (q *DropViewQuery) Err(err error) *DropViewQuery {
+	q.setErr(err)
+	return q
+}
+
+// Reset clears the query state, so the query can be reused, for example, with sync.Pool.
+// The DB and the connection set with Conn are kept.
+// Reset invalidates the SQL previously produced by the query.
+func (q *DropViewQuery) Reset() *DropViewQuery {
+	q.baseQuery.reset()
+	q.cascadeQuery = cascadeQuery{}
+	q.ifExists = false
+	q.comment = ""
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) View(views ...string) *DropViewQuery {
+	for _, view := range views {
+		q.addTable(schema.UnsafeIdent(view))
+	}
+	return q
+}
+
+func (q *DropViewQuery) ViewExpr(query string, args ...interface{}) *DropViewQuery {
+	q.addTable(schema.SafeQuery(query, args))
+	return q
+}
+
+func (q *DropViewQuery) ModelTableExpr(query string, args ...interface{}) *DropViewQuery {
+	q.modelTableName = schema.SafeQuery(query, args)
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) IfExists() *DropViewQuery {
+	q.ifExists = true
+	return q
+}
+
+// Cascade adds CASCADE to the query. It is ignored by dialects that don't support it,
+// except SQLite, which returns an error.
+func (q *DropViewQuery) Cascade() *DropViewQuery {
+	q.cascade = true
+	return q
+}
+
+func (q *DropViewQuery) Restrict() *DropViewQuery {
+	q.restrict = true
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+// Comment adds a comment to the query, wrapped by /* ... */.
+func (q *DropViewQuery) Comment(comment string) *DropViewQuery {
+	q.comment = comment
+	return q
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) Operation() string {
+	return "DROP VIEW"
+}
+
+func (q *DropViewQuery) AppendQuery(fmter schema.Formatter, b []byte) (_ []byte, err error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	if fmter.Dialect().Name() == dialect.SQLite {
+		if q.cascade || q.restrict {
+			return nil, fmt.Errorf("bun: %s does not support CASCADE or RESTRICT in DROP VIEW",
+				fmter.Dialect().Name())
+		}
+		numViews := len(q.tables)
+		if q.modelHasTableName() {
+			numViews++
+		}
+		if numViews > 1 {
+			return nil, fmt.Errorf("bun: %s does not support dropping multiple views in one query",
+				fmter.Dialect().Name())
+		}
+	}
+
+	b = appendComment(b, q.comment)
+
+	b = append(b, "DROP VIEW "...)
+	if q.ifExists {
+		b = append(b, "IF EXISTS "...)
+	}
+
+	b, err = q.appendTables(fmter, b)
+	if err != nil {
+		return nil, err
+	}
+
+	b = q.appendCascade(fmter, b)
+
+	return b, nil
+}
+
+//------------------------------------------------------------------------------
+
+func (q *DropViewQuery) Exec(ctx context.Context, dest ...interface{}) (sql.Result, error) {
+	queryBytes, err := q.AppendQuery(q.db.fmter, q.db.makeQueryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	query := internal.String(queryBytes)
+
+	res, err := q.exec(ctx, q, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -52,6 +52,7 @@ func (j *relationJoin) selectMany(ctx context.Context, q *SelectQuery) error {
 	if q == nil {
 		return nil
 	}
+	q.db.onRelationLoad(ctx, j)
 	return q.Scan(ctx)
 }
 
@@ -136,8 +137,12 @@ func (j *relationJoin) hasManyColumns(q *SelectQuery) *SelectQuery {
 	b := make([]byte, 0, 32)
 
 	joinTable := j.JoinModel.Table()
-	if len(j.columns) > 0 {
-		for i, col := range j.columns {
+	if j.columns != nil {
+		// Excluded or selected columns must not drop the keys used to assemble the relation.
+		columns := j.columns[:len(j.columns):len(j.columns)]
+		columns = appendRelationKeyColumns(columns, j.Relation)
+
+		for i, col := range columns {
 			if i > 0 {
 				b = append(b, ", "...)
 			}
@@ -173,6 +178,7 @@ func (j *relationJoin) selectM2M(ctx context.Context, q *SelectQuery) error {
 	if q == nil {
 		return nil
 	}
+	q.db.onRelationLoad(ctx, j)
 	return q.Scan(ctx)
 }
 
@@ -267,7 +273,7 @@ func (j *relationJoin) appendAliasColumn(fmter schema.Formatter, b []byte, colum
 	return b
 }
 
-func (j *relationJoin) appendBaseAlias(fmter schema.Formatter, b []byte) []byte {
+func (j *relationJoin) appendBaseAlias(fmter schema.Formatter, b []byte, q *SelectQuery) []byte {
 	quote := fmter.IdentQuote()
 
 	if j.hasParent() {
@@ -276,15 +282,24 @@ func (j *relationJoin) appendBaseAlias(fmter schema.Formatter, b []byte) []byte
 		b = append(b, quote)
 		return b
 	}
+	if q.tableAlias != "" {
+		return append(b, q.tableAlias...)
+	}
 	return append(b, j.BaseModel.Table().SQLAlias...)
 }
 
 func (j *relationJoin) appendSoftDelete(
 	fmter schema.Formatter, b []byte, flags internal.Flag,
 ) []byte {
-	b = append(b, '.')
+	return appendSoftDeleteField(fmter, b, j.JoinModel.Table().SoftDeleteField, flags)
+}
 
-	field := j.JoinModel.Table().SoftDeleteField
+// appendSoftDeleteField appends `.column IS NULL` or the equivalent condition
+// that filters rows by the soft delete field. The table alias is appended by the caller.
+func appendSoftDeleteField(
+	fmter schema.Formatter, b []byte, field *schema.Field, flags internal.Flag,
+) []byte {
+	b = append(b, '.')
 	b = append(b, field.SQLName...)
 
 	if field.IsPtr || field.NullZero {
@@ -335,7 +350,7 @@ func (j *relationJoin) appendHasOneJoin(
 		b = append(b, '.')
 		b = append(b, j.Relation.JoinPKs[i].SQLName...)
 		b = append(b, " = "...)
-		b = j.appendBaseAlias(fmter, b)
+		b = j.appendBaseAlias(fmter, b, q)
 		b = append(b, '.')
 		b = append(b, baseField.SQLName...)
 	}
@@ -0,0 +1,309 @@
+package bun
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/uptrace/bun/internal"
+	"github.com/uptrace/bun/schema"
+)
+
+// relationJSON is a relation that is selected by the main query as a JSON column,
+// see SelectQuery.RelationJSON.
+type relationJSON struct {
+	rel      *schema.Relation
+	children []*relationJSON
+}
+
+func addRelationJSON(
+	db *DB, rels []*relationJSON, table *schema.Table, path []string,
+) ([]*relationJSON, bool) {
+	rel, ok := table.Relations[path[0]]
+	if !ok {
+		return nil, false
+	}
+
+	var node *relationJSON
+	for _, r := range rels {
+		if r.rel == rel {
+			node = r
+			break
+		}
+	}
+	if node == nil {
+		node = &relationJSON{rel: rel}
+		rels = append(rels, node)
+	}
+
+	if len(path) > 1 {
+		// Join tables are initialized lazily, so get the table with its relations.
+		children, ok := addRelationJSON(db, node.children, db.Table(rel.JoinTable.Type), path[1:])
+		if !ok {
+			return nil, false
+		}
+		node.children = children
+	}
+	return rels, true
+}
+
+func (j *relationJSON) isMany() bool {
+	switch j.rel.Type {
+	case schema.HasManyRelation, schema.ManyToManyRelation:
+		return true
+	}
+	return false
+}
+
+// appendQuery appends a subquery that selects the relation rows of the base row
+// as a JSON object (has-one and belongs-to) or a JSON array (has-many and m2m).
+// The derived table reuses the relation alias, so row_to_json and json_agg
+// produce objects with the column names as keys.
+func (j *relationJSON) appendQuery(
+	fmter schema.Formatter, b []byte, baseAlias schema.Safe, name string, flags internal.Flag,
+) (_ []byte, err error) {
+	table := j.rel.JoinTable
+	alias := schema.Safe(fmter.AppendName(nil, name))
+
+	if j.isMany() {
+		b = append(b, "(SELECT coalesce(json_agg("...)
+		b = append(b, alias...)
+		if len(table.PKs) > 0 {
+			b = append(b, " ORDER BY "...)
+			b = appendColumns(b, alias, table.PKs)
+		}
+		b = append(b, "), '[]')"...)
+	} else {
+		b = append(b, "(SELECT row_to_json("...)
+		b = append(b, alias...)
+		b = append(b, ')')
+	}
+
+	b = append(b, " FROM (SELECT "...)
+	b = appendColumns(b, alias, table.Fields)
+
+	for _, child := range j.children {
+		b = append(b, ", "...)
+		b, err = child.appendQuery(fmter, b, alias, name+"__"+child.rel.Field.Name, flags)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, " AS "...)
+		b = fmter.AppendName(b, child.rel.Field.Name)
+	}
+
+	b = append(b, " FROM "...)
+	b = fmter.AppendQuery(b, string(table.SQLNameForSelects))
+	b = append(b, " AS "...)
+	b = append(b, alias...)
+
+	joinPKs := j.rel.JoinPKs
+	basePKs := j.rel.BasePKs
+	condAlias := alias
+
+	if j.rel.Type == schema.ManyToManyRelation {
+		m2mAlias := schema.Safe(fmter.AppendName(nil, name+"__m2m"))
+
+		b = append(b, " JOIN "...)
+		b = fmter.AppendQuery(b, string(j.rel.M2MTable.SQLName))
+		b = append(b, " AS "...)
+		b = append(b, m2mAlias...)
+		b = append(b, " ON ("...)
+		for i, f := range j.rel.M2MJoinPKs {
+			if i > 0 {
+				b = append(b, " AND "...)
+			}
+			b = append(b, m2mAlias...)
+			b = append(b, '.')
+			b = append(b, f.SQLName...)
+			b = append(b, " = "...)
+			b = append(b, alias...)
+			b = append(b, '.')
+			b = append(b, joinPKs[i].SQLName...)
+		}
+		b = append(b, ')')
+
+		joinPKs = j.rel.M2MBasePKs
+		condAlias = m2mAlias
+	}
+
+	b = append(b, " WHERE ("...)
+	for i, f := range joinPKs {
+		if i > 0 {
+			b = append(b, " AND "...)
+		}
+		b = append(b, condAlias...)
+		b = append(b, '.')
+		b = append(b, f.SQLName...)
+		b = append(b, " = "...)
+		b = append(b, baseAlias...)
+		b = append(b, '.')
+		b = append(b, basePKs[i].SQLName...)
+	}
+	b = append(b, ')')
+
+	if field := j.rel.PolymorphicField; field != nil {
+		b = append(b, " AND "...)
+		b = append(b, alias...)
+		b = append(b, '.')
+		b = append(b, field.SQLName...)
+		b = append(b, " = "...)
+		b = fmter.Dialect().AppendString(b, j.rel.PolymorphicValue)
+	}
+
+	if table.SoftDeleteField != nil && !flags.Has(allWithDeletedFlag) {
+		b = append(b, " AND "...)
+		b = append(b, alias...)
+		b = appendSoftDeleteField(fmter, b, table.SoftDeleteField, flags)
+	}
+
+	if !j.isMany() {
+		b = append(b, " LIMIT 1"...)
+	}
+
+	b = append(b, ") AS "...)
+	b = append(b, alias...)
+	b = append(b, ')')
+	return b, nil
+}
+
+// unsupportedJSONField returns the first field that can't be scanned from the JSON
+// produced by row_to_json: bytea is encoded as a hex string and arrays as JSON arrays
+// instead of the text formats the field scanners expect.
+func unsupportedJSONField(table *schema.Table) *schema.Field {
+	for _, f := range table.Fields {
+		sqlType := f.UserSQLType
+		if sqlType == "" {
+			sqlType = f.DiscoveredSQLType
+		}
+		if strings.EqualFold(sqlType, "bytea") || strings.HasSuffix(sqlType, "[]") ||
+			f.Tag.HasOption("array") || f.Tag.HasOption("multirange") {
+			return f
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// relationByName returns the relation with the given column name, which is
+// how relations loaded with SelectQuery.RelationJSON are named in the result.
+func relationByName(table *schema.Table, name string) *schema.Relation {
+	for _, rel := range table.Relations {
+		if rel.Field.Name == name {
+			return rel
+		}
+	}
+	return nil
+}
+
+// scanRelationJSON decodes the JSON produced by relationJSON into the relation field.
+func scanRelationJSON(db *DB, strct reflect.Value, rel *schema.Relation, src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = internal.Bytes(src)
+	default:
+		return fmt.Errorf("bun: can't scan %T into %s", src, rel)
+	}
+
+	table := db.Table(rel.JoinTable.Type)
+	v := rel.Field.Value(strct)
+
+	if v.Kind() == reflect.Slice {
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+
+		elemType := v.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+
+		slice := reflect.MakeSlice(v.Type(), 0, len(elems))
+		for _, elem := range elems {
+			ev := reflect.New(elemType)
+			if err := scanRelationJSONObject(db, ev.Elem(), table, elem); err != nil {
+				return err
+			}
+			if isPtr {
+				slice = reflect.Append(slice, ev)
+			} else {
+				slice = reflect.Append(slice, ev.Elem())
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return scanRelationJSONObject(db, v, table, data)
+}
+
+func scanRelationJSONObject(
+	db *DB, strct reflect.Value, table *schema.Table, data []byte,
+) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	for key, value := range obj {
+		if field := table.LookupField(key); field != nil {
+			src, err := jsonScanValue(value)
+			if err != nil {
+				return err
+			}
+			if err := field.ScanValue(strct, src); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if rel := relationByName(table, key); rel != nil {
+			if err := scanRelationJSON(db, strct, rel, []byte(value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonScanValue converts a JSON value to a value that is accepted by the field scanners.
+// Numbers, objects, and arrays are passed as text, like drivers do for numeric and JSON columns.
+func jsonScanValue(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	switch raw[0] {
+	case 'n':
+		return nil, nil
+	case 't':
+		return true, nil
+	case 'f':
+		return false, nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return []byte(raw), nil
+	}
+}
@@ -53,6 +53,33 @@ var appenders = []AppenderFunc{
 
 var appenderCache = xsync.NewMapOf[reflect.Type, AppenderFunc]()
 
+type registeredAppenderKey struct {
+	dialect dialect.Name
+	typ     reflect.Type
+}
+
+var registeredAppenders = xsync.NewMapOf[registeredAppenderKey, AppenderFunc]()
+
+// RegisterAppender registers the fn that appends values of the typ for the dialect.
+// It is useful for third-party types that don't implement driver.Valuer or
+// need a dialect-specific representation. Pointers to the typ are handled as well.
+// The appender must be registered before the models using the type are first used.
+func RegisterAppender(name dialect.Name, typ reflect.Type, fn AppenderFunc) {
+	registeredAppenders.Store(registeredAppenderKey{dialect: name, typ: typ}, fn)
+}
+
+func registeredAppender(name dialect.Name, typ reflect.Type) AppenderFunc {
+	if fn, ok := registeredAppenders.Load(registeredAppenderKey{dialect: name, typ: typ}); ok {
+		return fn
+	}
+	if typ.Kind() == reflect.Ptr {
+		if fn, ok := registeredAppenders.Load(registeredAppenderKey{dialect: name, typ: typ.Elem()}); ok {
+			return PtrAppender(fn)
+		}
+	}
+	return nil
+}
+
 func FieldAppender(dialect Dialect, field *Field) AppenderFunc {
 	if field.Tag.HasOption("msgpack") {
 		return appendMsgpack
@@ -60,6 +87,10 @@ func FieldAppender(dialect Dialect, field *Field) AppenderFunc {
 
 	fieldType := field.StructField.Type
 
+	if fn := registeredAppender(dialect.Name(), fieldType); fn != nil {
+		return fn
+	}
+
 	switch strings.ToUpper(field.UserSQLType) {
 	case sqltype.JSON, sqltype.JSONB:
 		if fieldType.Implements(driverValuerType) {
@@ -79,6 +110,9 @@ func FieldAppender(dialect Dialect, field *Field) AppenderFunc {
 }
 
 func Appender(dialect Dialect, typ reflect.Type) AppenderFunc {
+	if fn := registeredAppender(dialect.Name(), typ); fn != nil {
+		return fn
+	}
 	if v, ok := appenderCache.Load(typ); ok {
 		return v
 	}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun/dialect"
+)
+
+type geoPoint struct {
+	X, Y float64
+}
+
+func TestRegisterAppender(t *testing.T) {
+	typ := reflect.TypeFor[geoPoint]()
+	t.Cleanup(func() {
+		unregisterAppender(dialect.Invalid, typ)
+		unregisterAppender(dialect.PG, typ)
+	})
+
+	RegisterAppender(dialect.Invalid, typ, func(fmter Formatter, b []byte, v reflect.Value) []byte {
+		p := v.Interface().(geoPoint)
+		b = append(b, "POINT("...)
+		b = strconv.AppendFloat(b, p.X, 'f', -1, 64)
+		b = append(b, ' ')
+		b = strconv.AppendFloat(b, p.Y, 'f', -1, 64)
+		return append(b, ')')
+	})
+	RegisterAppender(dialect.PG, typ, func(fmter Formatter, b []byte, v reflect.Value) []byte {
+		return append(b, "pg"...)
+	})
+
+	fmter := NewFormatter(newNopDialect())
+
+	b := Append(fmter, nil, geoPoint{X: 1.5, Y: -2})
+	require.Equal(t, "POINT(1.5 -2)", string(b))
+
+	b = Append(fmter, nil, &geoPoint{X: 3, Y: 4})
+	require.Equal(t, "POINT(3 4)", string(b))
+
+	b = Append(fmter, nil, (*geoPoint)(nil))
+	require.Equal(t, "NULL", string(b))
+
+	type Model struct {
+		Location geoPoint
+	}
+
+	table := newNopDialect().Tables().Get(reflect.TypeFor[Model]())
+	field := table.FieldMap["location"]
+	b = field.AppendValue(fmter, nil, reflect.ValueOf(Model{Location: geoPoint{X: 5, Y: 6}}))
+	require.Equal(t, "POINT(5 6)", string(b))
+}
+
+// unregisterAppender removes the appender registered with RegisterAppender,
+// so the registration does not leak into other tests.
+func unregisterAppender(name dialect.Name, typ reflect.Type) {
+	registeredAppenders.Delete(registeredAppenderKey{dialect: name, typ: typ})
+}
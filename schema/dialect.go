@@ -44,6 +44,12 @@ type Dialect interface {
 	DefaultSchema() string
 }
 
+// identQuotingDialect is implemented by dialects that can be configured
+// to quote only the identifiers that require quoting.
+type identQuotingDialect interface {
+	IdentQuoting() dialect.IdentQuoting
+}
+
 // ------------------------------------------------------------------------------
 
 type BaseDialect struct{}
@@ -25,6 +25,7 @@ type Field struct {
 	UserSQLType        string
 	CreateTableSQLType string
 	SQLDefault         string
+	Collation          string
 
 	OnDelete string
 	OnUpdate string
@@ -32,9 +33,20 @@ type Field struct {
 	IsPK          bool
 	NotNull       bool
 	NullZero      bool
+	NullEmpty     bool
 	AutoIncrement bool
 	Identity      bool
 
+	// ReadOnly fields, declared with the `readonly` tag option, are selected and scanned,
+	// but never inserted or updated, e.g. columns generated by the database.
+	// Unlike `scanonly` fields, they are part of Fields and CREATE TABLE.
+	ReadOnly bool
+
+	// Discriminator is the column set with the `discriminator` tag option.
+	// The column value selects the concrete type of the interface field
+	// when the field is scanned, see bun.DB.RegisterPolymorphic.
+	Discriminator string
+
 	Append AppenderFunc
 	Scan   ScannerFunc
 	IsZero IsZeroerFunc
@@ -103,7 +115,7 @@ func (f *Field) AppendValue(fmter Formatter, b []byte, strct reflect.Value) []by
 		return dialect.AppendNull(b)
 	}
 
-	if (f.IsPtr && fv.IsNil()) || (f.NullZero && f.IsZero(fv)) {
+	if (f.IsPtr && fv.IsNil()) || (f.NullZero && f.IsZero(fv)) || (f.NullEmpty && isEmpty(fv)) {
 		return dialect.AppendNull(b)
 	}
 	if f.Append == nil {
@@ -112,6 +124,21 @@ func (f *Field) AppendValue(fmter Formatter, b []byte, strct reflect.Value) []by
 	return f.Append(fmter, b, fv)
 }
 
+// isEmpty reports whether v is a nil or empty slice or map.
+func isEmpty(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
 func (f *Field) ScanValue(strct reflect.Value, src interface{}) error {
 	if src == nil {
 		if fv, ok := fieldByIndex(strct, f.Index); ok {
@@ -132,5 +159,5 @@ func (f *Field) ScanWithCheck(fv reflect.Value, src interface{}) error {
 }
 
 func (f *Field) SkipUpdate() bool {
-	return f.Tag.HasOption("skipupdate")
+	return f.ReadOnly || f.Tag.HasOption("skipupdate")
 }
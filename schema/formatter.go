@@ -18,12 +18,17 @@ var nopFormatter = Formatter{
 type Formatter struct {
 	dialect Dialect
 	args    *namedArgList
+	quoting dialect.IdentQuoting
 }
 
-func NewFormatter(dialect Dialect) Formatter {
-	return Formatter{
-		dialect: dialect,
+func NewFormatter(d Dialect) Formatter {
+	f := Formatter{
+		dialect: d,
 	}
+	if d, ok := d.(identQuotingDialect); ok {
+		f.quoting = d.IdentQuoting()
+	}
+	return f
 }
 
 func NewNopFormatter() Formatter {
@@ -43,10 +48,16 @@ func (f Formatter) IdentQuote() byte {
 }
 
 func (f Formatter) AppendName(b []byte, name string) []byte {
+	if f.quoting == dialect.QuoteMinimal {
+		return dialect.AppendNameMinimal(b, name, f.IdentQuote())
+	}
 	return dialect.AppendName(b, name, f.IdentQuote())
 }
 
 func (f Formatter) AppendIdent(b []byte, ident string) []byte {
+	if f.quoting == dialect.QuoteMinimal {
+		return dialect.AppendIdentMinimal(b, ident, f.IdentQuote())
+	}
 	return dialect.AppendIdent(b, ident, f.IdentQuote())
 }
 
@@ -63,17 +74,13 @@ func (f Formatter) HasFeature(feature feature.Feature) bool {
 }
 
 func (f Formatter) WithArg(arg NamedArgAppender) Formatter {
-	return Formatter{
-		dialect: f.dialect,
-		args:    f.args.WithArg(arg),
-	}
+	f.args = f.args.WithArg(arg)
+	return f
 }
 
 func (f Formatter) WithNamedArg(name string, value interface{}) Formatter {
-	return Formatter{
-		dialect: f.dialect,
-		args:    f.args.WithArg(&namedArg{name: name, value: value}),
-	}
+	f.args = f.args.WithArg(&namedArg{name: name, value: value})
+	return f
 }
 
 func (f Formatter) FormatQuery(query string, args ...interface{}) string {
@@ -93,10 +100,15 @@ func (f Formatter) AppendQuery(dst []byte, query string, args ...interface{}) []
 func (f Formatter) append(dst []byte, p *parser.Parser, args []interface{}) []byte {
 	var namedArgs NamedArgAppender
 	if len(args) == 1 {
-		if v, ok := args[0].(NamedArgAppender); ok {
-			namedArgs = v
-		} else if v, ok := newStructArgs(f, args[0]); ok {
-			namedArgs = v
+		switch arg := args[0].(type) {
+		case NamedArgAppender:
+			namedArgs = arg
+		case QueryAppender:
+			// Nested queries such as SafeQuery are values, not structs with named args.
+		default:
+			if v, ok := newStructArgs(f, arg); ok {
+				namedArgs = v
+			}
 		}
 	}
 
@@ -28,6 +28,17 @@ var beforeAppendModelHookType = reflect.TypeFor[BeforeAppendModelHook]()
 
 //------------------------------------------------------------------------------
 
+// ValidateHook is called by insert and update queries after BeforeAppendModel
+// and before the query is generated. If Validate returns an error,
+// the query is not executed and the error is returned to the caller.
+type ValidateHook interface {
+	Validate(ctx context.Context, query Query) error
+}
+
+var validateHookType = reflect.TypeFor[ValidateHook]()
+
+//------------------------------------------------------------------------------
+
 type BeforeScanRowHook interface {
 	BeforeScanRow(context.Context) error
 }
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"github.com/uptrace/bun/extra/bunjson"
+)
+
+// SetJSONEncoder replaces encoding/json with the provided functions to marshal
+// and unmarshal JSON values, for example, jsoniter.ConfigCompatibleWithStandardLibrary.Marshal
+// and Unmarshal. A nil function keeps the encoding/json implementation, and
+// SetJSONEncoder(nil, nil) restores the default bunjson.StdProvider, including
+// after bunjson.SetProvider.
+//
+// The functions must respect json.Marshaler and json.Unmarshaler like encoding/json does
+// and must be safe for concurrent use. See bunjson.Provider for the full contract.
+// SetJSONEncoder is not safe to call concurrently with queries.
+func SetJSONEncoder(
+	marshal func(v interface{}) ([]byte, error),
+	unmarshal func(data []byte, v interface{}) error,
+) {
+	if marshal == nil && unmarshal == nil {
+		bunjson.SetProvider(bunjson.StdProvider{})
+		return
+	}
+	bunjson.SetProvider(bunjson.FuncProvider{
+		MarshalFunc:   marshal,
+		UnmarshalFunc: unmarshal,
+	})
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uptrace/bun/extra/bunjson"
+)
+
+type jsonMarshalerValue struct{}
+
+func (jsonMarshalerValue) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+func TestSetJSONEncoder(t *testing.T) {
+	var marshaled, unmarshaled int
+	SetJSONEncoder(func(v interface{}) ([]byte, error) {
+		marshaled++
+		return json.Marshal(v)
+	}, func(data []byte, v interface{}) error {
+		unmarshaled++
+		return json.Unmarshal(data, v)
+	})
+	defer SetJSONEncoder(nil, nil)
+
+	fmter := NewFormatter(newNopDialect())
+
+	b := AppendJSONValue(fmter, nil, reflect.ValueOf(map[string]int{"a": 1}))
+	require.Equal(t, `'{"a":1}'`, string(b))
+	require.Equal(t, 1, marshaled)
+
+	b = AppendJSONValue(fmter, nil, reflect.ValueOf(jsonMarshalerValue{}))
+	require.Equal(t, `'"custom"'`, string(b))
+
+	var dest map[string]int
+	err := scanJSON(reflect.ValueOf(&dest).Elem(), []byte(`{"b":2}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"b": 2}, dest)
+	require.Equal(t, 1, unmarshaled)
+}
+
+func TestSetJSONEncoderReset(t *testing.T) {
+	var marshaled int
+	bunjson.SetProvider(bunjson.FuncProvider{
+		MarshalFunc: func(v interface{}) ([]byte, error) {
+			marshaled++
+			return json.Marshal(v)
+		},
+	})
+	SetJSONEncoder(nil, nil)
+
+	fmter := NewFormatter(newNopDialect())
+
+	b := AppendJSONValue(fmter, nil, reflect.ValueOf(map[string]int{"a": 1}))
+	require.Equal(t, `'{"a":1}'`, string(b))
+	require.Equal(t, 0, marshaled)
+}
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"slices"
 )
 
 const (
@@ -24,7 +25,10 @@ type Relation struct {
 	JoinPKs   []*Field
 	OnUpdate  string
 	OnDelete  string
-	Condition []string
+	// Deferrable is `DEFERRABLE INITIALLY DEFERRED` or `DEFERRABLE INITIALLY IMMEDIATE`
+	// when the foreign key constraint is deferrable.
+	Deferrable string
+	Condition  []string
 
 	PolymorphicField *Field
 	PolymorphicValue string
@@ -43,3 +47,87 @@ func (r *Relation) References() bool {
 func (r *Relation) String() string {
 	return fmt.Sprintf("relation=%s", r.Field.GoName)
 }
+
+// RelationInfo is a read-only description of a model relation
+// that is meant for tools that need to enumerate relations, for example, generic preloaders.
+type RelationInfo struct {
+	// Name is the name of the struct field that holds the relation,
+	// which is also the name used with Relation.
+	Name string
+	// Type is the relation type, for example, HasManyRelation.
+	Type int
+
+	// BaseColumns and JoinColumns are the columns used to join the tables.
+	// For m2m relations they are the PKs of the base and join tables.
+	BaseColumns []string
+	JoinColumns []string
+
+	// JoinTable is the related table.
+	JoinTable *Table
+
+	// M2MTable is the intermediate table of m2m relations.
+	M2MTable *Table
+	// M2MBaseColumns and M2MJoinColumns are the m2m table columns
+	// that reference BaseColumns and JoinColumns.
+	M2MBaseColumns []string
+	M2MJoinColumns []string
+}
+
+// TypeName returns the relation type as it is written in the struct tag,
+// for example, "has-many" or "m2m".
+func (r RelationInfo) TypeName() string {
+	switch r.Type {
+	case HasOneRelation:
+		return "has-one"
+	case BelongsToRelation:
+		return "belongs-to"
+	case HasManyRelation:
+		return "has-many"
+	case ManyToManyRelation:
+		return "m2m"
+	default:
+		return "invalid"
+	}
+}
+
+// Info returns a read-only description of the relation.
+func (r *Relation) Info() RelationInfo {
+	return RelationInfo{
+		Name:           r.Field.GoName,
+		Type:           r.Type,
+		BaseColumns:    fieldNames(r.BasePKs),
+		JoinColumns:    fieldNames(r.JoinPKs),
+		JoinTable:      r.JoinTable,
+		M2MTable:       r.M2MTable,
+		M2MBaseColumns: fieldNames(r.M2MBasePKs),
+		M2MJoinColumns: fieldNames(r.M2MJoinPKs),
+	}
+}
+
+// RelationInfos returns the table relations in the struct field order.
+func (t *Table) RelationInfos() []RelationInfo {
+	rels := make([]*Relation, 0, len(t.Relations))
+	for _, rel := range t.Relations {
+		rels = append(rels, rel)
+	}
+	slices.SortFunc(rels, func(a, b *Relation) int {
+		return slices.Compare(a.Field.Index, b.Field.Index)
+	})
+
+	infos := make([]RelationInfo, len(rels))
+	for i, rel := range rels {
+		infos[i] = rel.Info()
+	}
+	return infos
+}
+
+func fieldNames(fields []*Field) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
@@ -3,10 +3,15 @@ package schema
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/puzpuzpuz/xsync/v3"
+
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/sqltype"
 	"github.com/uptrace/bun/internal"
@@ -14,6 +19,7 @@ import (
 
 var (
 	bunNullTimeType = reflect.TypeFor[NullTime]()
+	decimalType     = reflect.TypeFor[Decimal]()
 	nullTimeType    = reflect.TypeFor[sql.NullTime]()
 	nullBoolType    = reflect.TypeFor[sql.NullBool]()
 	nullFloatType   = reflect.TypeFor[sql.NullFloat64]()
@@ -47,6 +53,35 @@ var sqlTypes = []string{
 	reflect.Struct:     sqltype.VarChar,
 }
 
+var sqlTypeFuncs = xsync.NewMapOf[reflect.Type, func(Dialect) string]()
+
+// RegisterSQLType registers a func that returns the SQL type of the Go type for the dialect,
+// which is used as if the fields of that type had the `type` tag option, for example:
+//
+//	schema.RegisterSQLType(reflect.TypeFor[uuid.UUID](), func(d schema.Dialect) string {
+//		switch d.Name() {
+//		case dialect.PG:
+//			return "uuid"
+//		case dialect.MySQL:
+//			return "char(36)"
+//		}
+//		return "" // discover the type as usual
+//	})
+//
+// The `type` tag option takes precedence over the registered type. The func must be
+// registered before the models that use the type, because tables are cached.
+func RegisterSQLType(typ reflect.Type, fn func(Dialect) string) {
+	sqlTypeFuncs.Store(typ, fn)
+}
+
+// registeredSQLType returns the SQL type registered with RegisterSQLType, if any.
+func registeredSQLType(d Dialect, typ reflect.Type) string {
+	if fn, ok := sqlTypeFuncs.Load(typ); ok {
+		return fn(d)
+	}
+	return ""
+}
+
 func DiscoverSQLType(typ reflect.Type) string {
 	switch typ {
 	case timeType, nullTimeType, bunNullTimeType:
@@ -59,6 +94,8 @@ func DiscoverSQLType(typ reflect.Type) string {
 		return sqltype.BigInt
 	case nullStringType:
 		return sqltype.VarChar
+	case decimalType:
+		return sqltype.Numeric
 	case jsonRawMessageType:
 		return sqltype.JSON
 	}
@@ -139,3 +176,113 @@ func (tm *NullTime) Scan(src interface{}) error {
 		return scanError(bunNullTimeType, src)
 	}
 }
+
+//------------------------------------------------------------------------------
+
+// Decimal is an arbitrary-precision number kept in its text form, so numeric
+// and money values round-trip without the precision loss of float64.
+// An empty Decimal is NULL.
+//
+// Decimal fields are created as NUMERIC. MySQL defaults NUMERIC to scale 0, so use
+// the `type` tag option there, e.g. `bun:",type:decimal(20,4)"`.
+type Decimal string
+
+var (
+	_ sql.Scanner   = (*Decimal)(nil)
+	_ driver.Valuer = (*Decimal)(nil)
+	_ QueryAppender = (*Decimal)(nil)
+)
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+func (d Decimal) AppendQuery(fmter Formatter, b []byte) ([]byte, error) {
+	if d == "" {
+		return dialect.AppendNull(b), nil
+	}
+	if isDecimalLiteral(string(d)) {
+		return append(b, d...), nil
+	}
+	// NaN, Infinity and the like are passed as strings.
+	return fmter.Dialect().AppendString(b, string(d)), nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*d = ""
+		return nil
+	case []byte:
+		*d = Decimal(parseMoney(string(src)))
+		return nil
+	case string:
+		*d = Decimal(parseMoney(src))
+		return nil
+	case int64:
+		*d = Decimal(strconv.FormatInt(src, 10))
+		return nil
+	case float64:
+		*d = Decimal(strconv.FormatFloat(src, 'f', -1, 64))
+		return nil
+	default:
+		return scanError(decimalType, src)
+	}
+}
+
+// parseMoney strips the currency symbol and group separators that Postgres
+// adds to money values, e.g. "-$1,234.56" becomes "-1234.56".
+func parseMoney(s string) string {
+	if isDecimalLiteral(s) || !strings.ContainsRune(s, '$') {
+		return s
+	}
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.TrimPrefix(s, "$")
+	s = strings.ReplaceAll(s, ",", "")
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// isDecimalLiteral reports whether s is a plain number like -12.34 or 1e-10
+// that can be safely appended to a query as is.
+func isDecimalLiteral(s string) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+
+	var digits bool
+	var dot, exp bool
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == '.' && !dot && !exp:
+			dot = true
+		case (c == 'e' || c == 'E') && digits && !exp:
+			exp = true
+			digits = false
+			if i+1 < len(s) && (s[i+1] == '-' || s[i+1] == '+') {
+				i++
+			}
+		default:
+			return false
+		}
+	}
+	return digits
+}
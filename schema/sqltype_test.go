@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	fmter := NewFormatter(newNopDialect())
+
+	for _, test := range []struct {
+		in   Decimal
+		want string
+	}{
+		{"", "NULL"},
+		{"12345678901234567890.123456789", "12345678901234567890.123456789"},
+		{"-0.000000000000000001", "-0.000000000000000001"},
+		{"1e-10", "1e-10"},
+		{"NaN", "'NaN'"},
+		{"1; DROP TABLE users", "'1; DROP TABLE users'"},
+	} {
+		b, err := test.in.AppendQuery(fmter, nil)
+		require.NoError(t, err)
+		require.Equal(t, test.want, string(b), "in=%q", test.in)
+	}
+
+	for _, test := range []struct {
+		src  interface{}
+		want Decimal
+	}{
+		{nil, ""},
+		{[]byte("12345678901234567890.123456789"), "12345678901234567890.123456789"},
+		{"-$1,234.56", "-1234.56"},
+		{"($1,234.56)", "-1234.56"},
+		{int64(42), "42"},
+		{0.1, "0.1"},
+	} {
+		var d Decimal
+		require.NoError(t, d.Scan(test.src))
+		require.Equal(t, test.want, d, "src=%#v", test.src)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/jinzhu/inflection"
+	"github.com/puzpuzpuz/xsync/v3"
 
 	"github.com/uptrace/bun/internal"
 	"github.com/uptrace/bun/internal/tagparser"
@@ -16,6 +17,7 @@ import (
 
 const (
 	beforeAppendModelHookFlag internal.Flag = 1 << iota
+	validateHookFlag
 	beforeScanHookFlag
 	afterScanHookFlag
 	beforeScanRowHookFlag
@@ -25,6 +27,9 @@ const (
 var (
 	baseModelType      = reflect.TypeFor[BaseModel]()
 	tableNameInflector = inflection.Plural
+	columnNamer        func(goName string) string // nil means internal.Underscore
+
+	inflectionExceptions = xsync.NewMapOf[string, string]()
 )
 
 type BaseModel struct{}
@@ -35,6 +40,54 @@ func SetTableNameInflector(fn func(string) string) {
 	tableNameInflector = fn
 }
 
+// SetColumnNamer overrides the default func that converts Go field names to
+// column names, e.g. AuthorID becomes author_id. Column names set with the tag,
+// e.g. `bun:"author"`, are used as is. Call it with nil to restore the default.
+// Like SetTableNameInflector, it must be called before the models are used.
+func SetColumnNamer(fn func(goName string) string) {
+	columnNamer = fn
+}
+
+func columnName(goName string) string {
+	if columnNamer != nil {
+		return columnNamer(goName)
+	}
+	return internal.Underscore(goName)
+}
+
+// namedFKField returns the foreign key field named by the custom column namer,
+// e.g. AuthorID for the Author relation with the ID primary key.
+func namedFKField(t *Table, prefix string, pk *Field) *Field {
+	if columnNamer == nil {
+		return nil
+	}
+	return t.FieldMap[columnNamer(prefix+pk.GoName)]
+}
+
+// RegisterInflectionException overrides the plural form of a single word while the
+// table name inflector is used for the rest, for example:
+//
+//	schema.RegisterInflectionException("campus", "campuses")
+//
+// The exception applies to the whole model name and to its last word, so UserCampus
+// becomes user_campuses. Like SetTableNameInflector, it must be called before
+// the models are used.
+func RegisterInflectionException(singular, plural string) {
+	inflectionExceptions.Store(strings.ToLower(singular), plural)
+}
+
+func pluralize(modelName string) string {
+	if plural, ok := inflectionExceptions.Load(modelName); ok {
+		return plural
+	}
+	if i := strings.LastIndexByte(modelName, '_'); i >= 0 {
+		if plural, ok := inflectionExceptions.Load(modelName[i+1:]); ok {
+			return modelName[:i+1] + plural
+		}
+	}
+	return tableNameInflector(modelName)
+}
+
 // Table represents a SQL table created from Go struct.
 type Table struct {
 	dialect Dialect
@@ -64,10 +117,20 @@ type Table struct {
 
 	Relations map[string]*Relation
 	Unique    map[string][]*Field
+	Indexes   map[string][]*Field
 
 	SoftDeleteField       *Field
 	UpdateSoftDeleteField func(fv reflect.Value, tm time.Time) error
 
+	// VersionField is the integer field marked with the `version` tag option
+	// that is used for optimistic locking.
+	VersionField *Field
+
+	// CreatedAtField and UpdatedAtField are the fields marked with the `created_at`
+	// and `updated_at` tag options that bun populates on insert and update.
+	CreatedAtField *Field
+	UpdatedAtField *Field
+
 	flags internal.Flag
 }
 
@@ -83,7 +146,7 @@ func (table *Table) init(dialect Dialect, typ reflect.Type) {
 	table.ZeroIface = reflect.New(table.Type).Interface()
 	table.TypeName = internal.ToExported(table.Type.Name())
 	table.ModelName = internal.Underscore(table.Type.Name())
-	tableName := tableNameInflector(table.ModelName)
+	tableName := pluralize(table.ModelName)
 	table.setName(tableName)
 	table.Alias = table.ModelName
 	table.SQLAlias = table.quoteIdent(table.ModelName)
@@ -98,6 +161,7 @@ func (table *Table) init(dialect Dialect, typ reflect.Type) {
 		flag internal.Flag
 	}{
 		{beforeAppendModelHookType, beforeAppendModelHookFlag},
+		{validateHookType, validateHookFlag},
 
 		{beforeScanRowHookType, beforeScanRowHookFlag},
 		{afterScanRowHookType, afterScanRowHookFlag},
@@ -250,6 +314,9 @@ func (t *Table) processFields(typ reflect.Type) {
 		if v, ok := subfield.Tag.Options["unique"]; ok {
 			t.addUnique(subfield, embfield.prefix, v)
 		}
+		if v, ok := subfield.Tag.Options["index"]; ok {
+			t.addIndex(subfield, embfield.prefix, v)
+		}
 	}
 
 	if len(embedded) > 0 {
@@ -278,24 +345,37 @@ func sortFieldsByStruct(fields []*Field) {
 }
 
 func (t *Table) addUnique(field *Field, prefix string, tagOptions []string) {
+	if t.Unique == nil {
+		t.Unique = make(map[string][]*Field)
+	}
+	addFieldGroups(t.Unique, field, prefix, tagOptions)
+}
+
+// addIndex registers the field in the index groups declared with the `index` tag option.
+// Fields with an unnamed index get a separate single-column index each.
+func (t *Table) addIndex(field *Field, prefix string, tagOptions []string) {
+	if t.Indexes == nil {
+		t.Indexes = make(map[string][]*Field)
+	}
+	addFieldGroups(t.Indexes, field, prefix, tagOptions)
+}
+
+func addFieldGroups(groups map[string][]*Field, field *Field, prefix string, tagOptions []string) {
 	var names []string
 	if len(tagOptions) == 1 {
 		// Split the value by comma, this will allow multiple names to be specified.
-		// We can use this to create multiple named unique constraints where a single column
-		// might be included in multiple constraints.
+		// We can use this to create multiple named groups where a single column
+		// might be included in multiple constraints or indexes.
 		names = strings.Split(tagOptions[0], ",")
 	} else {
 		names = tagOptions
 	}
 
-	for _, uname := range names {
-		if t.Unique == nil {
-			t.Unique = make(map[string][]*Field)
-		}
-		if uname != "" && prefix != "" {
-			uname = prefix + uname
+	for _, name := range names {
+		if name != "" && prefix != "" {
+			name = prefix + name
 		}
-		t.Unique[uname] = append(t.Unique[uname], field)
+		groups[name] = append(groups[name], field)
 	}
 }
 
@@ -349,6 +429,26 @@ func (t *Table) addField(field *Field) {
 		t.UpdateSoftDeleteField = softDeleteFieldUpdater(field)
 	}
 
+	if field.Tag.HasOption("version") {
+		switch field.StructField.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			panic(fmt.Errorf("bun: %s.%s: version field must be an integer, got %s",
+				t.TypeName, field.GoName, field.StructField.Type))
+		}
+		t.VersionField = field
+	}
+
+	if field.Tag.HasOption("created_at") {
+		checkTimestampField(t, field, "created_at")
+		t.CreatedAtField = field
+	}
+	if field.Tag.HasOption("updated_at") {
+		checkTimestampField(t, field, "updated_at")
+		t.UpdatedAtField = field
+	}
+
 	t.Fields = append(t.Fields, field)
 	if field.IsPK {
 		t.PKs = append(t.PKs, field)
@@ -357,6 +457,9 @@ func (t *Table) addField(field *Field) {
 	}
 }
 
+// LookupField returns the field by the column name. Names of nested struct fields
+// are joined with double underscores, e.g. "stats__last__id" for the Stats.Last.ID field.
+// The returned field index is relative to the table struct.
 func (t *Table) LookupField(name string) *Field {
 	if field, ok := t.FieldMap[name]; ok {
 		return field
@@ -461,7 +564,7 @@ func (t *Table) schemaFromTagName(name string) (string, string) {
 
 // nolint
 func (t *Table) newField(sf reflect.StructField, tag tagparser.Tag) *Field {
-	sqlName := internal.Underscore(sf.Name)
+	sqlName := columnName(sf.Name)
 	if tag.Name != "" && tag.Name != sqlName {
 		if isKnownFieldOption(tag.Name) {
 			internal.Warn.Printf(
@@ -497,6 +600,8 @@ func (t *Table) newField(sf reflect.StructField, tag tagparser.Tag) *Field {
 
 	field.NotNull = tag.HasOption("notnull")
 	field.NullZero = tag.HasOption("nullzero")
+	field.NullEmpty = tag.HasOption("nullempty")
+	field.ReadOnly = tag.HasOption("readonly")
 	if tag.HasOption("pk") {
 		field.IsPK = true
 		field.NotNull = true
@@ -512,11 +617,26 @@ func (t *Table) newField(sf reflect.StructField, tag tagparser.Tag) *Field {
 	if v, ok := tag.Options["unique"]; ok {
 		t.addUnique(field, "", v)
 	}
+	if v, ok := tag.Options["index"]; ok {
+		t.addIndex(field, "", v)
+	}
 	if s, ok := tag.Option("default"); ok {
 		field.SQLDefault = s
 	}
+	if s, ok := tag.Option("collate"); ok {
+		field.Collation = s
+	}
+	if s, ok := tag.Option("discriminator"); ok {
+		if sf.Type.Kind() != reflect.Interface {
+			panic(fmt.Errorf("bun: %s.%s must be an interface to use discriminator (got %s)",
+				t.TypeName, sf.Name, sf.Type))
+		}
+		field.Discriminator = s
+	}
 	if s, ok := field.Tag.Option("type"); ok {
 		field.UserSQLType = s
+	} else {
+		field.UserSQLType = registeredSQLType(t.dialect, field.IndirectType)
 	}
 	field.DiscoveredSQLType = DiscoverSQLType(field.IndirectType)
 	field.Append = FieldAppender(t.dialect, field)
@@ -616,6 +736,22 @@ func (t *Table) belongsToRelation(field *Field) *Relation {
 		rel.OnDelete = s
 	}
 
+	if deferrable, ok := field.Tag.Options["deferrable"]; ok {
+		if len(deferrable) > 1 {
+			panic(fmt.Errorf("bun: %s belongs-to %s: deferrable option must be a single field", t.TypeName, field.GoName))
+		}
+
+		switch initially := strings.ToUpper(deferrable[0]); initially {
+		case "", "DEFERRED":
+			rel.Deferrable = "DEFERRABLE INITIALLY DEFERRED"
+		case "IMMEDIATE":
+			rel.Deferrable = "DEFERRABLE INITIALLY IMMEDIATE"
+		default:
+			panic(fmt.Errorf("bun: %s belongs-to %s: unknown deferrable option %s (expected deferred or immediate)",
+				t.TypeName, field.GoName, deferrable[0]))
+		}
+	}
+
 	if join, ok := field.Tag.Options["join"]; ok {
 		baseColumns, joinColumns := parseRelationJoin(join)
 		for i, baseColumn := range baseColumns {
@@ -651,6 +787,11 @@ func (t *Table) belongsToRelation(field *Field) *Relation {
 			continue
 		}
 
+		if fk := namedFKField(t, field.GoName, joinPK); fk != nil {
+			rel.BasePKs = append(rel.BasePKs, fk)
+			continue
+		}
+
 		if fk := t.FieldMap[joinPK.Name]; fk != nil {
 			rel.BasePKs = append(rel.BasePKs, fk)
 			continue
@@ -715,6 +856,11 @@ func (t *Table) hasOneRelation(field *Field) *Relation {
 			continue
 		}
 
+		if f := namedFKField(joinTable, t.TypeName, pk); f != nil {
+			rel.JoinPKs = append(rel.JoinPKs, f)
+			continue
+		}
+
 		if f := joinTable.FieldMap[pk.Name]; f != nil {
 			rel.JoinPKs = append(rel.JoinPKs, f)
 			continue
@@ -796,6 +942,11 @@ func (t *Table) hasManyRelation(field *Field) *Relation {
 				continue
 			}
 
+			if fk := namedFKField(joinTable, t.TypeName, pk); fk != nil {
+				rel.JoinPKs = append(rel.JoinPKs, fk)
+				continue
+			}
+
 			if fk := joinTable.FieldMap[pk.Name]; fk != nil {
 				rel.JoinPKs = append(rel.JoinPKs, fk)
 				continue
@@ -867,6 +1018,11 @@ func (t *Table) m2mRelation(field *Field) *Relation {
 		rel.Condition = field.Tag.Options["join_on"]
 	}
 
+	if columns, ok := field.Tag.Option("columns"); ok {
+		t.m2mColumns(rel, columns)
+		return rel
+	}
+
 	var leftColumn, rightColumn string
 
 	if join, ok := field.Tag.Options["join"]; ok {
@@ -907,12 +1063,60 @@ func (t *Table) m2mRelation(field *Field) *Relation {
 	return rel
 }
 
+// m2mColumns maps m2m table columns to the primary keys using the tag option
+// columns:m2m_column=pk_column;..., for example, columns:order_ref=id;item_ref=id.
+// The first len(t.PKs) pairs reference the base table and the rest reference the join table.
+func (t *Table) m2mColumns(rel *Relation, columns string) {
+	m2mColumns, pkColumns := parseRelationJoin(strings.Split(columns, ";"))
+
+	numBase := len(t.PKs)
+	if len(m2mColumns) != numBase+len(rel.JoinTable.PKs) {
+		panic(fmt.Errorf(
+			"bun: %s many-to-many %s: columns option must map %d %s and %d %s primary keys, got %d",
+			t.TypeName, rel.Field.GoName,
+			numBase, t.TypeName, len(rel.JoinTable.PKs), rel.JoinTable.TypeName, len(m2mColumns),
+		))
+	}
+
+	for i, m2mColumn := range m2mColumns {
+		m2mField := rel.M2MTable.FieldMap[m2mColumn]
+		if m2mField == nil {
+			panic(fmt.Errorf(
+				"bun: %s many-to-many %s: %s must have column %s",
+				t.TypeName, rel.Field.GoName, rel.M2MTable.TypeName, m2mColumn,
+			))
+		}
+
+		table := t
+		if i >= numBase {
+			table = rel.JoinTable
+		}
+		pkField := table.FieldMap[pkColumns[i]]
+		if pkField == nil || !pkField.IsPK {
+			panic(fmt.Errorf(
+				"bun: %s many-to-many %s: %s must have primary key column %s",
+				t.TypeName, rel.Field.GoName, table.TypeName, pkColumns[i],
+			))
+		}
+
+		if i < numBase {
+			rel.BasePKs = append(rel.BasePKs, pkField)
+			rel.M2MBasePKs = append(rel.M2MBasePKs, m2mField)
+		} else {
+			rel.JoinPKs = append(rel.JoinPKs, pkField)
+			rel.M2MJoinPKs = append(rel.M2MJoinPKs, m2mField)
+		}
+	}
+}
+
 //------------------------------------------------------------------------------
 
 func (t *Table) Dialect() Dialect { return t.dialect }
 
 func (t *Table) HasBeforeAppendModelHook() bool { return t.flags.Has(beforeAppendModelHookFlag) }
 
+func (t *Table) HasValidateHook() bool { return t.flags.Has(validateHookFlag) }
+
 // DEPRECATED. Use HasBeforeScanRowHook.
 func (t *Table) HasBeforeScanHook() bool { return t.flags.Has(beforeScanHookFlag) }
 
@@ -968,10 +1172,18 @@ func isKnownFieldOption(name string) bool {
 		"msgpack",
 		"notnull",
 		"nullzero",
+		"nullempty",
 		"default",
+		"collate",
+		"discriminator",
 		"unique",
+		"index",
 		"soft_delete",
+		"version",
+		"created_at",
+		"updated_at",
 		"scanonly",
+		"readonly",
 		"skipupdate",
 
 		"pk",
@@ -981,7 +1193,9 @@ func isKnownFieldOption(name string) bool {
 		"join_on",
 		"on_update",
 		"on_delete",
+		"deferrable",
 		"m2m",
+		"columns",
 		"polymorphic",
 		"identity":
 		return true
@@ -1023,6 +1237,49 @@ func parseRelationJoin(join []string) ([]string, []string) {
 
 //------------------------------------------------------------------------------
 
+// UpdateTimestamps sets the created_at and updated_at fields of the strct to the tm.
+// On insert, fields that already have a non-zero value are left untouched
+// so explicitly set timestamps are preserved. On update, only the updated_at
+// field is set.
+func (t *Table) UpdateTimestamps(strct reflect.Value, tm time.Time, isInsert bool) {
+	if isInsert && t.CreatedAtField != nil {
+		setTimestampField(t.CreatedAtField, strct, tm, true)
+	}
+	if t.UpdatedAtField != nil {
+		setTimestampField(t.UpdatedAtField, strct, tm, isInsert)
+	}
+}
+
+func checkTimestampField(t *Table, field *Field, option string) {
+	switch field.IndirectType {
+	case timeType, nullTimeType, bunNullTimeType:
+		return
+	}
+	panic(fmt.Errorf("bun: %s.%s: %s field must be time.Time or sql.NullTime, got %s",
+		t.TypeName, field.GoName, option, field.StructField.Type))
+}
+
+func setTimestampField(field *Field, strct reflect.Value, tm time.Time, onlyZero bool) {
+	if onlyZero && !field.HasZeroValue(strct) {
+		return
+	}
+
+	fv := field.Value(strct)
+	if fv.Kind() == reflect.Ptr {
+		fv.Set(reflect.New(fv.Type().Elem()))
+		fv = fv.Elem()
+	}
+
+	switch ptr := fv.Addr().Interface().(type) {
+	case *time.Time:
+		*ptr = tm
+	case *sql.NullTime:
+		*ptr = sql.NullTime{Time: tm, Valid: true}
+	case *NullTime:
+		*ptr = NullTime{Time: tm}
+	}
+}
+
 func softDeleteFieldUpdater(field *Field) func(fv reflect.Value, tm time.Time) error {
 	typ := field.StructField.Type
 
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -280,4 +281,113 @@ func TestTable(t *testing.T) {
 
 		require.Equal(t, table.FieldMap["foo"].SQLName, table.FieldMap["alt_name"].SQLName)
 	})
+
+	t.Run("m2m columns", func(t *testing.T) {
+		type Item struct {
+			ID int64 `bun:",pk"`
+		}
+
+		type Order struct {
+			ID    int64  `bun:",pk"`
+			Items []Item `bun:"m2m:legacy_order_items,columns:order_ref=id;item_ref=id"`
+		}
+
+		type OrderItem struct {
+			BaseModel `bun:"table:legacy_order_items"`
+
+			OrderRef int64 `bun:",pk"`
+			ItemRef  int64 `bun:",pk"`
+		}
+
+		tables := dialect.Tables()
+		tables.Register((*OrderItem)(nil))
+		table := tables.Get(reflect.TypeFor[*Order]())
+
+		rel, ok := table.Relations["Items"]
+		require.True(t, ok)
+		require.Equal(t, ManyToManyRelation, rel.Type)
+		require.Equal(t, "id", rel.BasePKs[0].Name)
+		require.Equal(t, "order_ref", rel.M2MBasePKs[0].Name)
+		require.Equal(t, "id", rel.JoinPKs[0].Name)
+		require.Equal(t, "item_ref", rel.M2MJoinPKs[0].Name)
+	})
+
+	t.Run("relation infos", func(t *testing.T) {
+		type Profile struct {
+			ID     int64 `bun:",pk"`
+			UserID int64
+		}
+
+		type User struct {
+			ID       int64      `bun:",pk"`
+			Profiles []*Profile `bun:"rel:has-many"`
+			Profile  *Profile   `bun:"rel:has-one"`
+		}
+
+		table := dialect.Tables().Get(reflect.TypeFor[*User]())
+
+		infos := table.RelationInfos()
+		require.Len(t, infos, 2)
+
+		require.Equal(t, "Profiles", infos[0].Name)
+		require.Equal(t, "has-many", infos[0].TypeName())
+		require.Equal(t, []string{"id"}, infos[0].BaseColumns)
+		require.Equal(t, []string{"user_id"}, infos[0].JoinColumns)
+		require.Equal(t, "profiles", infos[0].JoinTable.Name)
+		require.Nil(t, infos[0].M2MTable)
+
+		require.Equal(t, "Profile", infos[1].Name)
+		require.Equal(t, HasOneRelation, infos[1].Type)
+		require.Equal(t, "has-one", infos[1].TypeName())
+	})
+}
+
+func TestRegisterInflectionException(t *testing.T) {
+	RegisterInflectionException("campus", "campuses")
+	RegisterInflectionException("Person", "persons")
+	t.Cleanup(func() {
+		inflectionExceptions.Delete("campus")
+		inflectionExceptions.Delete("person")
+	})
+
+	type Campus struct{}
+	type UserCampus struct{}
+	type Person struct{}
+	type Octopus struct{}
+
+	tables := NewTables(newNopDialect())
+	require.Equal(t, "campuses", tables.Get(reflect.TypeFor[*Campus]()).Name)
+	require.Equal(t, "user_campuses", tables.Get(reflect.TypeFor[*UserCampus]()).Name)
+	require.Equal(t, "persons", tables.Get(reflect.TypeFor[*Person]()).Name)
+	require.Equal(t, "octopi", tables.Get(reflect.TypeFor[*Octopus]()).Name, "default inflection")
+}
+
+func TestSetColumnNamer(t *testing.T) {
+	SetColumnNamer(func(goName string) string {
+		return strings.ToLower(goName[:1]) + goName[1:]
+	})
+	t.Cleanup(func() { SetColumnNamer(nil) })
+
+	type Author struct {
+		ID        int64 `bun:",pk"`
+		FirstName string
+		LastName  string `bun:"surname"`
+	}
+	type Book struct {
+		ID       int64 `bun:",pk"`
+		AuthorID int64
+		Author   *Author `bun:"rel:belongs-to"`
+	}
+
+	tables := NewTables(newNopDialect())
+
+	table := tables.Get(reflect.TypeFor[*Author]())
+	require.Contains(t, table.FieldMap, "iD")
+	require.Contains(t, table.FieldMap, "firstName")
+	require.Contains(t, table.FieldMap, "surname", "tag overrides the namer")
+
+	table = tables.Get(reflect.TypeFor[*Book]())
+	rel := table.Relations["Author"]
+	require.Len(t, rel.BasePKs, 1)
+	require.Equal(t, "authorID", rel.BasePKs[0].Name)
 }